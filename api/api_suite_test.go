@@ -25,8 +25,11 @@ import (
 	"github.com/concourse/atc/api/teamserver/teamserverfakes"
 	"github.com/concourse/atc/api/volumeserver/volumeserverfakes"
 	"github.com/concourse/atc/api/workerserver/workerserverfakes"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -56,10 +59,12 @@ var (
 	teamDB                        *dbfakes.FakeTeamDB
 	pipelinesDB                   *dbfakes.FakePipelinesDB
 	buildsDB                      *authfakes.FakeBuildsDB
+	auditDB                       *auditfakes.FakeAuditDB
 	buildServerDB                 *buildserverfakes.FakeBuildsDB
 	build                         *dbfakes.FakeBuild
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
+	fakeCredentialManager         *credsfakes.FakeCredentialManager
 	configValidationErrorMessages []string
 	configValidationWarnings      []config.Warning
 	peerAddr                      string
@@ -107,6 +112,7 @@ var _ = BeforeEach(func() {
 	pipeDB = new(pipesfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
 	buildsDB = new(authfakes.FakeBuildsDB)
+	auditDB = new(auditfakes.FakeAuditDB)
 
 	authValidator = new(authfakes.FakeValidator)
 	userContextReader = new(authfakes.FakeUserContextReader)
@@ -123,6 +129,7 @@ var _ = BeforeEach(func() {
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
+	fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 
 	var err error
 
@@ -158,6 +165,7 @@ var _ = BeforeEach(func() {
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
+			audit.NewHandlerFactory(logger, auditDB),
 		),
 
 		fakeTokenGenerator,
@@ -187,6 +195,7 @@ var _ = BeforeEach(func() {
 
 		fakeSchedulerFactory,
 		fakeScannerFactory,
+		fakeCredentialManager,
 
 		sink,
 
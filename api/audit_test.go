@@ -0,0 +1,189 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/teams/:team_name/audit", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/audit" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look up any events", func() {
+				Expect(teamDB.GetAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-other-team", 5, false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", 5, false, true)
+			})
+
+			Context("when no pagination params are given", func() {
+				It("looks up the team's events with the default limit", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("some-team"))
+					Expect(teamDB.GetAuditEventsCallCount()).To(Equal(1))
+					Expect(teamDB.GetAuditEventsArgsForCall(0)).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when pagination params are given", func() {
+				BeforeEach(func() {
+					queryParams = "?since=5&limit=2"
+				})
+
+				It("passes them through", func() {
+					Expect(teamDB.GetAuditEventsArgsForCall(0)).To(Equal(db.Page{Since: 5, Limit: 2}))
+				})
+			})
+
+			Context("when getting the events succeeds", func() {
+				BeforeEach(func() {
+					teamDB.GetAuditEventsReturns([]db.AuditEvent{
+						{
+							ID:            4,
+							TeamName:      "some-team",
+							Route:         atc.PausePipeline,
+							Target:        map[string]string{"pipeline_name": "some-pipeline"},
+							Status:        200,
+							ActorTeamName: "some-team",
+							ActorRole:     atc.TeamRoleOperator,
+							CreatedAt:     time.Unix(100, 0),
+						},
+						{
+							ID:            2,
+							TeamName:      "some-team",
+							Route:         atc.RegisterWorker,
+							Status:        200,
+							ActorIsSystem: true,
+							CreatedAt:     time.Unix(50, 0),
+						},
+					}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 2, Limit: 2},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Link headers per rfc5988", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						`<https://example.com/api/v1/teams/some-team/audit?until=4&limit=2>; rel="previous"`,
+						`<https://example.com/api/v1/teams/some-team/audit?since=2&limit=2>; rel="next"`,
+					}))
+				})
+
+				It("returns the events", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 4,
+							"team_name": "some-team",
+							"route": "PausePipeline",
+							"target": {"pipeline_name": "some-pipeline"},
+							"status": 200,
+							"actor_team_name": "some-team",
+							"actor_role": "operator",
+							"created_at": 100
+						},
+						{
+							"id": 2,
+							"team_name": "some-team",
+							"route": "RegisterWorker",
+							"status": 200,
+							"actor_is_system": true,
+							"created_at": 50
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					teamDB.GetAuditEventsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("mutating requests", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+			userContextReader.GetTeamReturns("some-team", 5, false, true)
+			userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/pause", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records rejected attempts", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+
+			Expect(auditDB.CreateAuditEventCallCount()).To(Equal(1))
+			Expect(auditDB.CreateAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName:      "some-team",
+				Route:         atc.PausePipeline,
+				Target:        map[string]string{"pipeline_name": "some-pipeline"},
+				Status:        http.StatusForbidden,
+				ActorTeamName: "some-team",
+				ActorRole:     atc.TeamRoleViewer,
+			}))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	teamName := r.FormValue(":team_name")
+
+	until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit == 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	teamDB := s.teamDBFactory.GetTeamDB(teamName)
+
+	events, pagination, err := teamDB.GetAuditEvents(db.Page{Until: until, Since: since, Limit: limit})
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		s.addNextLink(w, teamName, *pagination.Next)
+	}
+
+	if pagination.Previous != nil {
+		s.addPreviousLink(w, teamName, *pagination.Previous)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	presented := make([]atc.AuditEvent, len(events))
+	for i, event := range events {
+		presented[i] = present.AuditEvent(event)
+	}
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) addNextLink(w http.ResponseWriter, teamName string, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		atc.PaginationQuerySince,
+		page.Since,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelNext,
+	))
+}
+
+func (s *Server) addPreviousLink(w http.ResponseWriter, teamName string, page db.Page) {
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s/api/v1/teams/%s/audit?%s=%d&%s=%d>; rel="%s"`,
+		s.externalURL,
+		teamName,
+		atc.PaginationQueryUntil,
+		page.Until,
+		atc.PaginationQueryLimit,
+		page.Limit,
+		atc.LinkRelPrevious,
+	))
+}
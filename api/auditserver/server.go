@@ -0,0 +1,24 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger        lager.Logger
+	externalURL   string
+	teamDBFactory db.TeamDBFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	teamDBFactory db.TeamDBFactory,
+) *Server {
+	return &Server{
+		logger:        logger,
+		externalURL:   externalURL,
+		teamDBFactory: teamDBFactory,
+	}
+}
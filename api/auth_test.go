@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -69,11 +70,28 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, teamID, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, teamID, isAdmin, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(teamID).To(Equal(savedTeam.ID))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(role).To(Equal(atc.TeamRoleOwner))
+						Expect(user).To(BeEmpty())
+					})
+				})
+
+				Context("when logging in with basic auth", func() {
+					BeforeEach(func() {
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+						request.Header.Del("Authorization")
+						request.SetBasicAuth("some-username", "some-password")
+					})
+
+					It("generates a token for the basic auth user", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						_, _, _, _, _, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(user).To(Equal("some-username"))
 					})
 				})
 
@@ -87,6 +105,24 @@ var _ = Describe("Auth API", func() {
 					})
 				})
 
+				Context("when the request carries a token with a restricted role", func() {
+					BeforeEach(func() {
+						fakeTokenGenerator.GenerateTokenReturns("some type", "some value", nil)
+						userContextReader.GetTeamReturns("some-team", 0, true, true)
+						userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+						userContextReader.GetUserReturns("some-user", true)
+					})
+
+					It("generates a token with the same role and user, which is not an admin", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						_, _, _, isAdmin, role, user := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						Expect(isAdmin).To(BeFalse())
+						Expect(role).To(Equal(atc.TeamRoleViewer))
+						Expect(user).To(Equal("some-user"))
+					})
+				})
+
 				Context("when the team can't be found", func() {
 					BeforeEach(func() {
 						fakeTokenGenerator.GenerateTokenReturns("", "", errors.New("nope"))
@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 )
 
 const CookieName = "ATC-Authorization"
@@ -33,7 +34,24 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, team.Admin)
+	role := atc.TeamRoleOwner
+
+	// a token may only be exchanged for one with the same role
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if authTeamFound {
+		role = authTeam.Role()
+	}
+
+	// likewise for the user it was issued to, unless logging in with basic
+	// auth, whose users are known by their username
+	user, _ := auth.GetUser(r)
+	if username, _, ok := r.BasicAuth(); ok {
+		user = username
+	}
+
+	isAdmin := team.Admin && role.Includes(atc.TeamRoleOwner)
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.ID, isAdmin, role, user)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -1148,6 +1148,334 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/builds/:build_id/rerun", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/rerun", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					buildsDB.GetBuildByIDReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, true, true)
+					})
+
+					Context("when the build can be rerun", func() {
+						BeforeEach(func() {
+							rerun := new(dbfakes.FakeBuild)
+							rerun.IDReturns(129)
+							rerun.NameReturns("2.1")
+							rerun.JobNameReturns("job1")
+							rerun.PipelineNameReturns("pipeline1")
+							rerun.TeamNameReturns("some-team")
+							rerun.StatusReturns(db.StatusPending)
+							rerun.IsManuallyTriggeredReturns(true)
+							rerun.RerunOfReturns(128)
+
+							build.RerunReturns(rerun, nil)
+						})
+
+						It("reruns the build", func() {
+							Expect(build.RerunCallCount()).To(Equal(1))
+						})
+
+						It("returns 201 Created", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						})
+
+						It("returns the new build, linked to the original", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 129,
+								"name": "2.1",
+								"status": "pending",
+								"job_name": "job1",
+								"pipeline_name": "pipeline1",
+								"team_name": "some-team",
+								"url": "/teams/some-team/pipelines/pipeline1/jobs/job1/builds/2.1",
+								"api_url": "/api/v1/builds/129",
+								"rerun_of": 128
+							}`))
+						})
+					})
+
+					Context("when the build cannot be rerun", func() {
+						BeforeEach(func() {
+							build.RerunReturns(nil, db.ErrCannotRerunBuild)
+						})
+
+						It("returns 409 Conflict", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+					})
+
+					Context("when rerunning the build fails", func() {
+						BeforeEach(func() {
+							build.RerunReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 2, true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not rerun the build", func() {
+						Expect(build.RerunCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildByIDReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not rerun the build", func() {
+				Expect(build.RerunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/builds/:build_id/gates/:plan_id", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"approved":true}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/gates/some-plan-id", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the build can be found", func() {
+				BeforeEach(func() {
+					build.TeamNameReturns("some-team")
+					buildsDB.GetBuildByIDReturns(build, true, nil)
+				})
+
+				Context("when accessing same team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-team", 2, true, true)
+					})
+
+					Context("when the gate is waiting", func() {
+						BeforeEach(func() {
+							build.DecideGateReturns(true, nil)
+						})
+
+						It("returns 204", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						})
+
+						It("decides the gate on behalf of the team", func() {
+							Expect(build.DecideGateCallCount()).To(Equal(1))
+
+							planID, approved, decidedBy := build.DecideGateArgsForCall(0)
+							Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+							Expect(approved).To(BeTrue())
+							Expect(decidedBy).To(Equal("some-team"))
+						})
+
+						Context("when the token names the user", func() {
+							BeforeEach(func() {
+								userContextReader.GetUserReturns("some-user", true)
+							})
+
+							It("decides the gate on behalf of the user", func() {
+								Expect(build.DecideGateCallCount()).To(Equal(1))
+
+								_, _, decidedBy := build.DecideGateArgsForCall(0)
+								Expect(decidedBy).To(Equal("some-user"))
+							})
+						})
+
+						Context("when rejecting", func() {
+							BeforeEach(func() {
+								requestBody = `{"approved":false}`
+							})
+
+							It("rejects the gate", func() {
+								Expect(build.DecideGateCallCount()).To(Equal(1))
+
+								_, approved, _ := build.DecideGateArgsForCall(0)
+								Expect(approved).To(BeFalse())
+							})
+						})
+					})
+
+					Context("when the gate is not waiting", func() {
+						BeforeEach(func() {
+							build.DecideGateReturns(false, nil)
+						})
+
+						It("returns 404", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+
+					Context("when deciding the gate fails", func() {
+						BeforeEach(func() {
+							build.DecideGateReturns(false, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = `{`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not decide the gate", func() {
+							Expect(build.DecideGateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the request does not say whether to approve", func() {
+						BeforeEach(func() {
+							requestBody = `{}`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("approved must be true or false")))
+						})
+
+						It("does not decide the gate", func() {
+							Expect(build.DecideGateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the request approves with null", func() {
+						BeforeEach(func() {
+							requestBody = `{"approved":null}`
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+
+						It("does not decide the gate", func() {
+							Expect(build.DecideGateCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when authorized as an operator", func() {
+						BeforeEach(func() {
+							userContextReader.GetRoleReturns(atc.TeamRoleOperator, true)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+
+						It("does not decide the gate", func() {
+							Expect(build.DecideGateCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when accessing other team's build", func() {
+					BeforeEach(func() {
+						userContextReader.GetTeamReturns("some-other-team", 2, true, true)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+				})
+			})
+
+			Context("when the build can not be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildByIDReturns(nil, false, nil)
+				})
+
+				It("returns Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not decide the gate", func() {
+				Expect(build.DecideGateCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
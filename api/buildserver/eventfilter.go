@@ -0,0 +1,86 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+// eventFilter restricts a build's event stream to the given event types and
+// plan origin IDs. An empty set of either matches everything.
+type eventFilter struct {
+	types   map[atc.EventType]bool
+	origins map[event.OriginID]bool
+}
+
+func parseEventFilter(query url.Values) eventFilter {
+	filter := eventFilter{}
+
+	if len(query["event"]) > 0 {
+		filter.types = map[atc.EventType]bool{}
+		for _, t := range query["event"] {
+			filter.types[atc.EventType(t)] = true
+		}
+	}
+
+	if len(query["origin"]) > 0 {
+		filter.origins = map[event.OriginID]bool{}
+		for _, id := range query["origin"] {
+			filter.origins[event.OriginID(id)] = true
+		}
+	}
+
+	return filter
+}
+
+func (filter eventFilter) empty() bool {
+	return filter.types == nil && filter.origins == nil
+}
+
+func (filter eventFilter) matches(envelope event.Envelope) bool {
+	if filter.types != nil && !filter.types[envelope.Event] {
+		return false
+	}
+
+	if filter.origins != nil && !filter.origins[originID(envelope)] {
+		return false
+	}
+
+	return true
+}
+
+func originID(envelope event.Envelope) event.OriginID {
+	if envelope.Data == nil {
+		return ""
+	}
+
+	var payload struct {
+		Origin struct {
+			ID event.OriginID `json:"id"`
+		} `json:"origin"`
+	}
+
+	err := json.Unmarshal(*envelope.Data, &payload)
+	if err != nil {
+		return ""
+	}
+
+	return payload.Origin.ID
+}
+
+func parseTail(query url.Values) (uint, error) {
+	tail := query.Get("tail")
+	if tail == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseUint(tail, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(n), nil
+}
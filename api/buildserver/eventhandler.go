@@ -7,20 +7,39 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/logarchive"
+	"github.com/gorilla/websocket"
 	"github.com/vito/go-sse/sse"
 )
 
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: 5 * time.Second,
+}
+
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		clientNotifier := w.(http.CloseNotifier)
+	return newEventHandler(logger, build, nil)
+}
+
+// NewEventHandlerFactory returns an EventHandlerFactory which streams the
+// events of reaped builds from the log archive, if they were archived.
+func NewEventHandlerFactory(logArchive logarchive.LogArchive) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		return newEventHandler(logger, build, logArchive)
+	}
+}
 
+func newEventHandler(logger lager.Logger, build db.Build, logArchive logarchive.LogArchive) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var eventID uint = 0
+		resuming := false
 		if r.Header.Get("Last-Event-ID") != "" {
 			startString := r.Header.Get("Last-Event-ID")
 			_, err := fmt.Sscanf(startString, "%d", &eventID)
@@ -31,30 +50,37 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			}
 
 			eventID++
+			resuming = true
 		}
 
-		w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
-		w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.Header().Add(ProtocolVersionHeader, CurrentProtocolVersion)
+		filter := parseEventFilter(r.URL.Query())
 
-		writer := eventWriter{
-			responseWriter:  w,
-			writeFlusher:    nil,
-			responseFlusher: w.(http.Flusher),
+		tail, err := parseTail(r.URL.Query())
+		if err != nil {
+			logger.Info("failed-to-parse-tail", lager.Data{"tail": r.URL.Query().Get("tail")})
+			w.WriteHeader(http.StatusBadRequest)
+			return
 		}
 
-		w.Header().Add("Vary", "Accept-Encoding")
-		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
-			w.Header().Set("Content-Encoding", "gzip")
-
-			gz := gzip.NewWriter(w)
-			defer gz.Close()
+		// reaped builds have finished, so their events are read until the end
+		tailUntil := ^uint(0)
+		if tail > 0 && !resuming && !reaped(build, logArchive) {
+			tailUntil, err = build.EventCount()
+			if err != nil {
+				logger.Error("failed-to-count-build-events", err, lager.Data{"build-id": build.ID()})
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 
-			writer.responseWriter = gz
-			writer.writeFlusher = gz
+			// without a filter the last events are the last ones in the stream,
+			// so there's no need to read the ones before them; with one, the
+			// whole stream has to be scanned to find the ones that match
+			if filter.empty() && tailUntil > tail {
+				eventID = tailUntil - tail
+			}
 		}
 
-		events, err := build.Events(eventID)
+		events, err := buildEvents(build, logArchive, eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -63,38 +89,174 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 
 		defer events.Close()
 
-		for {
-			logger = logger.WithData(lager.Data{"id": eventID})
+		var writer eventStreamWriter
+		var waitForClose func()
 
-			ev, err := events.Next()
+		if websocket.IsWebSocketUpgrade(r) {
+			conn, err := upgrader.Upgrade(w, r, nil)
 			if err != nil {
-				if err == db.ErrEndOfBuildEventStream {
-					err := writer.WriteEnd(eventID)
+				logger.Error("unable-to-upgrade-connection-for-websockets", err)
+				return
+			}
+
+			defer conn.Close()
+
+			writer = websocketEventWriter{conn: conn}
+
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+
+				// read until the client goes away, handling any control messages
+				for {
+					_, _, err := conn.NextReader()
 					if err != nil {
-						logger.Info("failed-to-write-end", lager.Data{"error": err.Error()})
 						return
 					}
+				}
+			}()
+
+			waitForClose = func() { <-closed }
+		} else {
+			clientNotifier := w.(http.CloseNotifier)
+
+			w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+			w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+			w.Header().Add(ProtocolVersionHeader, CurrentProtocolVersion)
+
+			sseWriter := eventWriter{
+				responseWriter:  w,
+				writeFlusher:    nil,
+				responseFlusher: w.(http.Flusher),
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+			if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				w.Header().Set("Content-Encoding", "gzip")
+
+				gz := gzip.NewWriter(w)
+				defer gz.Close()
+
+				sseWriter.responseWriter = gz
+				sseWriter.writeFlusher = gz
+			}
+
+			writer = sseWriter
+			waitForClose = func() { <-clientNotifier.CloseNotify() }
+		}
+
+		finish := func(err error) {
+			if err != db.ErrEndOfBuildEventStream {
+				logger.Error("failed-to-get-next-build-event", err)
+				return
+			}
+
+			err = writer.WriteEnd(eventID)
+			if err != nil {
+				logger.Info("failed-to-write-end", lager.Data{"error": err.Error()})
+				return
+			}
 
-					<-clientNotifier.CloseNotify()
-				} else {
-					logger.Error("failed-to-get-next-build-event", err)
+			waitForClose()
+		}
+
+		if tail > 0 && !resuming {
+			var backlog []numberedEvent
+			backlog, eventID, err = tailEvents(events, filter, tail, eventID, tailUntil)
+
+			for _, ev := range backlog {
+				writeErr := writer.WriteEvent(ev.id, ev.envelope)
+				if writeErr != nil {
+					logger.Info("failed-to-write-event", lager.Data{"error": writeErr.Error()})
 					return
 				}
+			}
 
+			if err != nil {
+				finish(err)
 				return
 			}
+		}
 
-			err = writer.WriteEvent(eventID, ev)
+		for {
+			logger = logger.WithData(lager.Data{"id": eventID})
+
+			ev, err := events.Next()
 			if err != nil {
-				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+				finish(err)
 				return
 			}
 
+			if filter.matches(ev) {
+				err = writer.WriteEvent(eventID, ev)
+				if err != nil {
+					logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+					return
+				}
+			}
+
 			eventID++
 		}
 	})
 }
 
+type numberedEvent struct {
+	id       uint
+	envelope event.Envelope
+}
+
+// tailEvents reads the events from one ID up to another, keeping only the
+// last matching ones, and returns them along with the ID of the next event.
+// With a filter, everything up to the end of the tail has to be read, as
+// there's no knowing which events match without looking at them.
+func tailEvents(events db.EventSource, filter eventFilter, tail uint, from uint, until uint) ([]numberedEvent, uint, error) {
+	var backlog []numberedEvent
+
+	eventID := from
+	for eventID < until {
+		ev, err := events.Next()
+		if err != nil {
+			return backlog, eventID, err
+		}
+
+		if filter.matches(ev) {
+			backlog = append(backlog, numberedEvent{id: eventID, envelope: ev})
+
+			if uint(len(backlog)) > tail {
+				backlog = backlog[1:]
+			}
+		}
+
+		eventID++
+	}
+
+	return backlog, eventID, nil
+}
+
+func reaped(build db.Build, logArchive logarchive.LogArchive) bool {
+	return logArchive != nil && !build.ReapTime().IsZero()
+}
+
+func buildEvents(build db.Build, logArchive logarchive.LogArchive, from uint) (db.EventSource, error) {
+	if reaped(build, logArchive) {
+		events, found, err := logarchive.Events(logArchive, build.ID(), from)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			return events, nil
+		}
+	}
+
+	return build.Events(from)
+}
+
+type eventStreamWriter interface {
+	WriteEvent(id uint, envelope interface{}) error
+	WriteEnd(id uint) error
+}
+
 type flusher interface {
 	Flush() error
 }
@@ -144,3 +306,34 @@ func (writer eventWriter) flush() error {
 
 	return nil
 }
+
+type websocketEventWriter struct {
+	conn *websocket.Conn
+}
+
+type websocketEvent struct {
+	ID   uint        `json:"id"`
+	Name string      `json:"name"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+func (writer websocketEventWriter) WriteEvent(id uint, envelope interface{}) error {
+	return writer.conn.WriteJSON(websocketEvent{
+		ID:   id,
+		Name: "event",
+		Data: envelope,
+	})
+}
+
+func (writer websocketEventWriter) WriteEnd(id uint) error {
+	err := writer.conn.WriteJSON(websocketEvent{ID: id, Name: "end"})
+	if err != nil {
+		return err
+	}
+
+	return writer.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(5*time.Second),
+	)
+}
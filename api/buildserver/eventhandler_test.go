@@ -3,22 +3,38 @@ package buildserver_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/logarchive"
+	"github.com/gorilla/websocket"
 	"github.com/vito/go-sse/sse"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+func typedEvent(eventType atc.EventType, payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   eventType,
+		Version: "42.0",
+	}
+}
+
 func fakeEvent(payload string) event.Envelope {
 	msg := json.RawMessage(payload)
 	return event.Envelope{
@@ -165,6 +181,181 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when filtering the events", func() {
+				BeforeEach(func() {
+					returnedEvents = []event.Envelope{
+						typedEvent("status", `{"status":"started"}`),
+						typedEvent("log", `{"origin":{"id":"some-task"},"payload":"hello"}`),
+						typedEvent("error", `{"origin":{"id":"some-task"},"message":"oh no"}`),
+						typedEvent("error", `{"origin":{"id":"other-task"},"message":"nope"}`),
+					}
+				})
+
+				Context("by event type", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "event=status&event=error"
+					})
+
+					It("emits only events of those types, keeping their ids", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "0",
+							Name: "event",
+							Data: []byte(`{"data":{"status":"started"},"event":"status","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "2",
+							Name: "event",
+							Data: []byte(`{"data":{"origin":{"id":"some-task"},"message":"oh no"},"event":"error","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "3",
+							Name: "event",
+							Data: []byte(`{"data":{"origin":{"id":"other-task"},"message":"nope"},"event":"error","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "4",
+							Name: "end",
+							Data: []byte{},
+						}))
+					})
+				})
+
+				Context("by origin", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "origin=some-task"
+					})
+
+					It("emits only events from that origin", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "1",
+							Name: "event",
+							Data: []byte(`{"data":{"origin":{"id":"some-task"},"payload":"hello"},"event":"log","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "2",
+							Name: "event",
+							Data: []byte(`{"data":{"origin":{"id":"some-task"},"message":"oh no"},"event":"error","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "4",
+							Name: "end",
+							Data: []byte{},
+						}))
+					})
+				})
+			})
+
+			Context("when a tail is given", func() {
+				BeforeEach(func() {
+					request.URL.RawQuery = "tail=2"
+					build.EventCountReturns(3, nil)
+				})
+
+				It("starts the stream at the last events", func() {
+					response.Body.Close()
+
+					Expect(build.EventsCallCount()).To(Equal(1))
+					Expect(build.EventsArgsForCall(0)).To(Equal(uint(1)))
+				})
+
+				It("emits only the last events, followed by an end event", func() {
+					defer response.Body.Close()
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "1",
+						Name: "event",
+						Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "2",
+						Name: "event",
+						Data: []byte(`{"data":{"event":3},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "3",
+						Name: "end",
+						Data: []byte{},
+					}))
+				})
+
+				Context("when the Last-Event-ID header is given", func() {
+					BeforeEach(func() {
+						request.Header.Set("Last-Event-ID", "0")
+					})
+
+					It("resumes from after the id instead", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "1",
+							Name: "event",
+							Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+						}))
+
+						Expect(build.EventCountCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the tail is longer than the stream", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "tail=5"
+					})
+
+					It("emits every event from the start", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(build.EventsArgsForCall(0)).To(Equal(uint(0)))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "0",
+							Name: "event",
+							Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+						}))
+					})
+				})
+
+				Context("when the events are also filtered", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "tail=1&event=fake"
+					})
+
+					It("scans the stream from the start, emitting only the last matching events", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(build.EventsArgsForCall(0)).To(Equal(uint(0)))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "2",
+							Name: "event",
+							Data: []byte(`{"data":{"event":3},"event":"fake","version":"42.0"}`),
+						}))
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "3",
+							Name: "end",
+							Data: []byte{},
+						}))
+					})
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
@@ -273,5 +464,223 @@ var _ = Describe("Handler", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 			})
 		})
+
+		Context("when the tail is invalid", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = "tail=bogus"
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not subscribe to the build", func() {
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when counting the events to tail fails", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = "tail=2"
+				build.EventCountReturns(0, errors.New("nope"))
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+
+			It("does not subscribe to the build", func() {
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the build's events have been archived", func() {
+			var archiveDir string
+
+			BeforeEach(func() {
+				var err error
+				archiveDir, err = ioutil.TempDir("", "log-archive")
+				Expect(err).NotTo(HaveOccurred())
+
+				logArchive := logarchive.NewLocalArchive(archiveDir)
+
+				archivedEvents := new(dbfakes.FakeEventSource)
+				archivedEvents.NextStub = func() (event.Envelope, error) {
+					if archivedEvents.NextCallCount() > 2 {
+						return event.Envelope{}, db.ErrEndOfBuildEventStream
+					}
+
+					return fakeEvent(fmt.Sprintf(`{"event":%d}`, archivedEvents.NextCallCount())), nil
+				}
+
+				Expect(logarchive.Archive(logArchive, 42, archivedEvents)).To(Succeed())
+
+				build.IDReturns(42)
+
+				server.Close()
+				server = httptest.NewServer(NewEventHandlerFactory(logArchive)(lagertest.NewTestLogger("test"), build))
+
+				request, err = http.NewRequest("GET", server.URL, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(archiveDir)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				var err error
+
+				client := &http.Client{
+					Transport: &http.Transport{},
+				}
+				response, err = client.Do(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the build has been reaped", func() {
+				BeforeEach(func() {
+					build.ReapTimeReturns(time.Unix(200, 0))
+				})
+
+				It("streams the archived events, followed by an end event", func() {
+					defer response.Body.Close()
+					reader := sse.NewReadCloser(response.Body)
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "0",
+						Name: "event",
+						Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "1",
+						Name: "event",
+						Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+					}))
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "2",
+						Name: "end",
+						Data: []byte{},
+					}))
+
+					Expect(build.EventsCallCount()).To(BeZero())
+				})
+
+				Context("when the Last-Event-ID header is given", func() {
+					BeforeEach(func() {
+						request.Header.Set("Last-Event-ID", "0")
+					})
+
+					It("resumes from after the id", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "1",
+							Name: "event",
+							Data: []byte(`{"data":{"event":2},"event":"fake","version":"42.0"}`),
+						}))
+					})
+				})
+			})
+
+			Context("when the build has not been reaped", func() {
+				var fakeEventSource *dbfakes.FakeEventSource
+
+				BeforeEach(func() {
+					fakeEventSource = new(dbfakes.FakeEventSource)
+					fakeEventSource.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+					build.EventsReturns(fakeEventSource, nil)
+				})
+
+				It("streams the events from the database", func() {
+					response.Body.Close()
+					Eventually(build.EventsCallCount).Should(Equal(1))
+				})
+			})
+		})
+	})
+
+	Describe("WebSocket", func() {
+		var fakeEventSource *dbfakes.FakeEventSource
+		var conn *websocket.Conn
+
+		BeforeEach(func() {
+			fakeEventSource = new(dbfakes.FakeEventSource)
+
+			from := 0
+			fakeEventSource.NextStub = func() (event.Envelope, error) {
+				from++
+
+				switch from {
+				case 1:
+					return typedEvent("status", `{"status":"started"}`), nil
+				case 2:
+					return typedEvent("log", `{"payload":"hello"}`), nil
+				default:
+					return event.Envelope{}, db.ErrEndOfBuildEventStream
+				}
+			}
+
+			build.EventsReturns(fakeEventSource, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			conn, _, err = websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"?event=status", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			conn.Close()
+			Eventually(fakeEventSource.CloseCallCount, 30*time.Second).Should(Equal(1))
+		})
+
+		It("sends the matching events as messages, followed by an end message", func() {
+			var msg map[string]interface{}
+
+			Expect(conn.ReadJSON(&msg)).To(Succeed())
+			Expect(msg).To(Equal(map[string]interface{}{
+				"id":   float64(0),
+				"name": "event",
+				"data": map[string]interface{}{
+					"data":    map[string]interface{}{"status": "started"},
+					"event":   "status",
+					"version": "42.0",
+				},
+			}))
+
+			msg = nil
+			Expect(conn.ReadJSON(&msg)).To(Succeed())
+			Expect(msg).To(Equal(map[string]interface{}{
+				"id":   float64(2),
+				"name": "end",
+			}))
+
+			_, _, err := conn.NextReader()
+			Expect(websocket.IsCloseError(err, websocket.CloseNormalClosure)).To(BeTrue())
+		})
 	})
 })
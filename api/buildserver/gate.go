@@ -0,0 +1,69 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) DecideGate(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+
+		hLog := s.logger.Session("decide-gate", lager.Data{
+			"build": build.ID(),
+			"plan":  planID,
+		})
+
+		var decision atc.GateDecision
+		err := json.NewDecoder(r.Body).Decode(&decision)
+		if err != nil {
+			hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if decision.Approved == nil {
+			hLog.Info("missing-decision")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "approved must be true or false")
+			return
+		}
+
+		decided, err := build.DecideGate(planID, *decision.Approved, decidedBy(r))
+		if err != nil {
+			hLog.Error("failed-to-decide-gate", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !decided {
+			hLog.Info("gate-not-waiting")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// decidedBy identifies who is deciding a gate, for the build's events. Users
+// whose auth provider cannot identify them are known only by their team.
+func decidedBy(r *http.Request) string {
+	user, found := auth.GetUser(r)
+	if found {
+		return user
+	}
+
+	team, found := auth.GetTeam(r)
+	if !found {
+		return ""
+	}
+
+	return team.Name()
+}
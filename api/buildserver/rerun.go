@@ -0,0 +1,36 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) RerunBuild(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hLog := s.logger.Session("rerun", lager.Data{
+			"build": build.ID(),
+		})
+
+		rerun, err := build.Rerun()
+		if err == db.ErrCannotRerunBuild {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "%s", err)
+			return
+		}
+
+		if err != nil {
+			hLog.Error("failed-to-rerun-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		json.NewEncoder(w).Encode(present.Build(rerun))
+	})
+}
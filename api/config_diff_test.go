@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Diff API", func() {
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", func() {
+		var (
+			proposedConfig atc.Config
+
+			request  *http.Request
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			proposedConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-new-uri"}},
+					{Name: "some-new-resource", Type: "time"},
+				},
+			}
+
+			var err error
+			request, err = rata.NewRequestGenerator(server.URL, atc.Routes).CreateRequest(atc.DiffConfig, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Content-Type", "application/json")
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(proposedConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			if request.Body == nil {
+				request.Body = gbytes.BufferWithBytes(payload)
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+				userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+			})
+
+			Context("when the current config can be loaded", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(atc.Config{
+						Resources: atc.ResourceConfigs{
+							{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+						},
+					}, atc.RawConfig("raw-config"), 1, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("diffs against the pipeline's config", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.GetConfigArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns the diff", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"diff": {
+							"resources": [
+								{
+									"name": "some-resource",
+									"action": "changed",
+									"fields": [{"path": "source.uri", "old": "some-uri", "new": "some-new-uri"}]
+								},
+								{
+									"name": "some-new-resource",
+									"action": "added"
+								}
+							]
+						}
+					}`))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+				})
+
+				Context("when the config has warnings", func() {
+					BeforeEach(func() {
+						configValidationWarnings = []config.Warning{
+							{Type: "some-type", Message: "some-message"},
+						}
+					})
+
+					It("returns them along with the diff", func() {
+						var diffResponse struct {
+							Warnings []config.Warning `json:"warnings"`
+						}
+
+						err := json.NewDecoder(response.Body).Decode(&diffResponse)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(diffResponse.Warnings).To(Equal(configValidationWarnings))
+					})
+				})
+			})
+
+			Context("when the config is invalid", func() {
+				BeforeEach(func() {
+					configValidationErrorMessages = []string{"some-error"}
+				})
+
+				It("returns 400 with the errors", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["some-error"]}`))
+				})
+
+				It("does not look up the current config", func() {
+					Expect(teamDB.GetConfigCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the config is malformed", func() {
+				BeforeEach(func() {
+					request.Body = gbytes.BufferWithBytes([]byte(`{`))
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{"errors": ["malformed config"]}`))
+				})
+			})
+
+			Context("when loading the current config fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
@@ -172,6 +173,40 @@ var _ = Describe("Config API", func() {
 				})
 			})
 
+			Context("when the pipeline was saved from a template", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 1, nil)
+					teamDB.GetPipelineTemplateReturns(db.PipelineTemplate{
+						Template:  "uri: {{uri}}\nbranch: {{branch}}",
+						Variables: template.Variables{"uri": "some-uri"},
+					}, true, nil)
+				})
+
+				It("returns the template and the variables which are not set", func() {
+					var actualConfigResponse atc.ConfigResponse
+					err := json.NewDecoder(response.Body).Decode(&actualConfigResponse)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(actualConfigResponse.Template).To(Equal("uri: {{uri}}\nbranch: {{branch}}"))
+					Expect(actualConfigResponse.UnsetVariables).To(Equal([]string{"branch"}))
+				})
+
+				It("does not return the variables themselves", func() {
+					Expect(ioutil.ReadAll(response.Body)).NotTo(ContainSubstring("some-uri"))
+				})
+			})
+
+			Context("when getting the pipeline template fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigReturns(pipelineConfig, atc.RawConfig("raw-config"), 1, nil)
+					teamDB.GetPipelineTemplateReturns(db.PipelineTemplate{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
 			Context("when getting the config fails", func() {
 				BeforeEach(func() {
 					teamDB.GetConfigReturns(atc.Config{}, atc.RawConfig(""), 0, errors.New("oh no!"))
@@ -244,6 +279,7 @@ var _ = Describe("Config API", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(true)
 				userContextReader.GetTeamReturns("a-team", 42, true, true)
+				userContextReader.GetUserReturns("some-user", true)
 			})
 
 			Context("when a config version is specified", func() {
@@ -272,7 +308,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -296,7 +332,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -317,18 +353,80 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							savedBy, name, savedConfig, savedTemplate, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+							Expect(savedBy).To(Equal("some-user"))
 							Expect(name).To(Equal("a-pipeline"))
+							Expect(savedTemplate).To(Equal(db.PipelineTemplate{}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
 						})
 
+						Context("when the user cannot be identified", func() {
+							BeforeEach(func() {
+								userContextReader.GetUserReturns("", false)
+							})
+
+							It("records the team as having saved it", func() {
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+								savedBy, _, _, _, _, _ := teamDB.SaveConfigAsArgsForCall(0)
+								Expect(savedBy).To(Equal("a-team"))
+							})
+						})
+
+						Context("when a passed constraint references a job in another pipeline", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs = append(pipelineConfig.Jobs, atc.JobConfig{
+									Name: "downstream-job",
+									Plan: atc.PlanSequence{
+										{Get: "some-resource", Passed: []string{"other-pipeline/other-job"}},
+									},
+								})
+
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							Context("when the pipeline does not exist", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, false, nil)
+								})
+
+								It("saves it, warning about the reference", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+									Expect(teamDB.GetPipelineByNameCallCount()).To(Equal(1))
+									Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("other-pipeline"))
+
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"warnings": [
+											{"type":"pipeline", "message":"jobs.downstream-job.get.some-resource.passed references a job in an unknown pipeline ('other-pipeline/other-job')"}
+										]
+									}`))
+								})
+							})
+
+							Context("when looking up the pipeline fails", func() {
+								BeforeEach(func() {
+									teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								})
+
+								It("returns 500 without saving it", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+								})
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -352,7 +450,7 @@ var _ = Describe("Config API", func() {
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -379,7 +477,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -399,9 +497,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+							_, name, savedConfig, _, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
 							Expect(name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -409,9 +507,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
-							Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-							_, savedConfig, _, _ := teamDB.SaveConfigArgsForCall(0)
+							_, _, savedConfig, _, _, _ := teamDB.SaveConfigAsArgsForCall(0)
 							Expect(savedConfig).To(Equal(pipelineConfig))
 
 							_, err := json.Marshal(pipelineConfig)
@@ -446,9 +544,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								_, name, savedConfig, _, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -499,7 +597,7 @@ jobs:
 										Version: db.ConfigVersion(42),
 									},
 								}
-								teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+								teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -509,7 +607,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+								teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -540,7 +638,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+								Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 							})
 						})
 					})
@@ -584,9 +682,9 @@ jobs:
 							})
 
 							It("saves it", func() {
-								Expect(teamDB.SaveConfigCallCount()).To(Equal(1))
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := teamDB.SaveConfigArgsForCall(0)
+								_, name, savedConfig, _, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
 								Expect(name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -605,7 +703,7 @@ jobs:
 											Version: db.ConfigVersion(42),
 										},
 									}
-									teamDB.SaveConfigReturns(returnedPipeline, true, nil)
+									teamDB.SaveConfigAsReturns(returnedPipeline, true, nil)
 								})
 
 								It("returns 201", func() {
@@ -615,7 +713,7 @@ jobs:
 
 							Context("and saving it fails", func() {
 								BeforeEach(func() {
-									teamDB.SaveConfigReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+									teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
 								})
 
 								It("returns 500", func() {
@@ -645,7 +743,7 @@ jobs:
 								})
 
 								It("does not save it", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 								})
 							})
 
@@ -738,6 +836,147 @@ jobs:
 							})
 						})
 
+						Context("when a template and variables are specified", func() {
+							var pipelineTemplate string
+
+							BeforeEach(func() {
+								pipelineTemplate = `
+resources:
+- name: some-resource
+  type: {{type}}
+  source:
+    uri: {{uri}}
+`
+
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								err := writer.WriteField("template", pipelineTemplate)
+								Expect(err).NotTo(HaveOccurred())
+
+								err = writer.WriteField("variables", "type: git\nuri: some-uri\nbranch: master\n")
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							})
+
+							It("returns 200", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusOK))
+							})
+
+							It("saves the rendered config along with the template", func() {
+								Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+								_, name, savedConfig, savedTemplate, id, pipelineState := teamDB.SaveConfigAsArgsForCall(0)
+								Expect(name).To(Equal("a-pipeline"))
+								Expect(savedConfig).To(Equal(atc.Config{
+									Resources: atc.ResourceConfigs{
+										{
+											Name:   "some-resource",
+											Type:   "git",
+											Source: atc.Source{"uri": "some-uri"},
+										},
+									},
+								}))
+								Expect(savedTemplate).To(Equal(db.PipelineTemplate{
+									Template: pipelineTemplate,
+									Variables: template.Variables{
+										"type":   "git",
+										"uri":    "some-uri",
+										"branch": "master",
+									},
+								}))
+								Expect(id).To(Equal(db.ConfigVersion(42)))
+								Expect(pipelineState).To(Equal(db.PipelineNoChange))
+							})
+
+							It("warns about unused variables", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"warnings": [
+										{"type":"template", "message":"variable 'branch' is not referenced by the template"}
+									]
+								}`))
+							})
+
+							Context("when the variables are malformed", func() {
+								BeforeEach(func() {
+									body := &bytes.Buffer{}
+									writer := multipart.NewWriter(body)
+
+									err := writer.WriteField("template", pipelineTemplate)
+									Expect(err).NotTo(HaveOccurred())
+
+									err = writer.WriteField("variables", "{")
+									Expect(err).NotTo(HaveOccurred())
+
+									writer.Close()
+
+									request.Header.Set("Content-Type", writer.FormDataContentType())
+									request.Body = gbytes.BufferWithBytes(body.Bytes())
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+										"errors": [
+											"malformed variables"
+										]
+									}`))
+								})
+
+								It("does not save it", func() {
+									Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when variables are specified without a template", func() {
+							BeforeEach(func() {
+								body := &bytes.Buffer{}
+								writer := multipart.NewWriter(body)
+
+								yamlWriter, err := writer.CreatePart(
+									textproto.MIMEHeader{
+										"Content-type": {"application/x-yaml"},
+									},
+								)
+								Expect(err).NotTo(HaveOccurred())
+
+								_, err = yamlWriter.Write([]byte("resources: []\n"))
+								Expect(err).NotTo(HaveOccurred())
+
+								err = writer.WriteField("variables", "type: git\n")
+								Expect(err).NotTo(HaveOccurred())
+
+								writer.Close()
+
+								request.Header.Set("Content-Type", writer.FormDataContentType())
+								request.Body = gbytes.BufferWithBytes(body.Bytes())
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("returns error JSON", func() {
+								Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+									"errors": [
+										"variables given without a template"
+									]
+								}`))
+							})
+
+							It("does not save it", func() {
+								Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+							})
+						})
+
 						Context("when the config is malformed", func() {
 							Context("JSON", func() {
 								BeforeEach(func() {
@@ -775,7 +1014,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -814,7 +1053,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(teamDB.SaveConfigCallCount()).To(Equal(0))
+									Expect(teamDB.SaveConfigAsCallCount()).To(Equal(0))
 								})
 							})
 						})
@@ -836,7 +1075,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 					})
 				})
 
@@ -867,7 +1106,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 					})
 				})
 			})
@@ -891,7 +1130,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 				})
 			})
 
@@ -914,7 +1153,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 				})
 			})
 		})
@@ -929,7 +1168,7 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(teamDB.SaveConfigCallCount()).To(BeZero())
+				Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
 			})
 		})
 	})
@@ -0,0 +1,383 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Versions API", func() {
+	var (
+		requestGenerator *rata.RequestGenerator
+
+		oldVersion db.SavedConfigVersion
+		newVersion db.SavedConfigVersion
+	)
+
+	BeforeEach(func() {
+		requestGenerator = rata.NewRequestGenerator(server.URL, atc.Routes)
+
+		oldVersion = db.SavedConfigVersion{
+			Version: 3,
+			Config: atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-job"}},
+			},
+			RawConfig: atc.RawConfig(`{"jobs":[{"name":"some-job"}]}`),
+			SavedBy:   "a-team",
+			SavedAt:   time.Unix(100, 0),
+		}
+
+		newVersion = db.SavedConfigVersion{
+			Version: 7,
+			Config: atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-other-job"}},
+			},
+			RawConfig: atc.RawConfig(`{"jobs":[{"name":"some-other-job"}]}`),
+			SavedAt:   time.Unix(200, 0),
+		}
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigVersions, rata.Params{
+				"team_name":     "a-team",
+				"pipeline_name": "a-pipeline",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the versions can be loaded", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns([]db.SavedConfigVersion{newVersion, oldVersion}, nil)
+				})
+
+				It("looks up the pipeline's versions", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(0)).To(Equal("a-team"))
+					Expect(teamDB.GetConfigVersionsArgsForCall(0)).To(Equal("a-pipeline"))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the versions without their configs", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"version": 7, "saved_at": 200},
+						{"version": 3, "saved_by": "a-team", "saved_at": 100}
+					]`))
+				})
+			})
+
+			Context("when loading the versions fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionsReturns(nil, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", func() {
+		var response *http.Response
+		var version string
+
+		BeforeEach(func() {
+			version = "3"
+		})
+
+		JustBeforeEach(func() {
+			req, err := requestGenerator.CreateRequest(atc.GetConfigVersion, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": version,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					oldVersion.Template = db.PipelineTemplate{
+						Template:  "some-template",
+						Variables: template.Variables{"some": "variable"},
+					}
+
+					teamDB.GetConfigVersionReturns(oldVersion, true, nil)
+				})
+
+				It("looks up the requested version", func() {
+					pipelineName, configVersion := teamDB.GetConfigVersionArgsForCall(0)
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(configVersion).To(Equal(db.ConfigVersion(3)))
+				})
+
+				It("returns the version with its config and template, but not its variables", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var returned atc.ConfigVersion
+					err := json.NewDecoder(response.Body).Decode(&returned)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returned).To(Equal(atc.ConfigVersion{
+						Version:  3,
+						SavedBy:  "a-team",
+						SavedAt:  100,
+						Config:   &oldVersion.Config,
+						Template: "some-template",
+					}))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.SavedConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is malformed", func() {
+				BeforeEach(func() {
+					version = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when loading the version fails", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.SavedConfigVersion{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/rollback", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+			request, err = requestGenerator.CreateRequest(atc.RollbackConfig, rata.Params{
+				"team_name":      "a-team",
+				"pipeline_name":  "a-pipeline",
+				"config_version": "3",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set(atc.ConfigVersionHeader, "7")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(oldVersion, true, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("saves the old config as the latest version", func() {
+					Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+					savedBy, name, savedConfig, savedTemplate, from, pausedState := teamDB.SaveConfigAsArgsForCall(0)
+					Expect(savedBy).To(Equal("a-team"))
+					Expect(name).To(Equal("a-pipeline"))
+					Expect(savedConfig).To(Equal(oldVersion.Config))
+					Expect(savedTemplate).To(Equal(db.PipelineTemplate{}))
+					Expect(from).To(Equal(db.ConfigVersion(7)))
+					Expect(pausedState).To(Equal(db.PipelineNoChange))
+				})
+
+				Context("when the config has warnings", func() {
+					BeforeEach(func() {
+						configValidationWarnings = []config.Warning{
+							{Type: "some-type", Message: "some-message"},
+						}
+					})
+
+					It("returns them", func() {
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"warnings": [{"type": "some-type", "message": "some-message"}]
+						}`))
+					})
+				})
+
+				Context("when a passed constraint references a job in another pipeline", func() {
+					BeforeEach(func() {
+						oldVersion.Config.Jobs = append(oldVersion.Config.Jobs, atc.JobConfig{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{Get: "some-resource", Passed: []string{"other-pipeline/other-job"}},
+							},
+						})
+
+						teamDB.GetConfigVersionReturns(oldVersion, true, nil)
+					})
+
+					Context("when the pipeline no longer exists", func() {
+						BeforeEach(func() {
+							teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, false, nil)
+						})
+
+						It("rolls back, warning about the reference", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.SaveConfigAsCallCount()).To(Equal(1))
+
+							Expect(teamDB.GetPipelineByNameArgsForCall(0)).To(Equal("other-pipeline"))
+
+							Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+								"warnings": [
+									{"type":"pipeline", "message":"jobs.downstream-job.get.some-resource.passed references a job in an unknown pipeline ('other-pipeline/other-job')"}
+								]
+							}`))
+						})
+					})
+
+					Context("when looking up the pipeline fails", func() {
+						BeforeEach(func() {
+							teamDB.GetPipelineByNameReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+						})
+
+						It("returns 500 without rolling back", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the config is no longer valid", func() {
+					BeforeEach(func() {
+						configValidationErrorMessages = []string{"some-error"}
+					})
+
+					It("returns 400 with the errors", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+							"errors": ["some-error"]
+						}`))
+					})
+
+					It("does not save the config", func() {
+						Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when saving the config fails", func() {
+					BeforeEach(func() {
+						teamDB.SaveConfigAsReturns(db.SavedPipeline{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					teamDB.GetConfigVersionReturns(db.SavedConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not save anything", func() {
+					Expect(teamDB.SaveConfigAsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when no current config version is specified", func() {
+				BeforeEach(func() {
+					request.Header.Del(atc.ConfigVersionHeader)
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`{
+						"errors": ["no config version specified"]
+					}`))
+				})
+			})
+		})
+
+		Context("when authorized as a viewer", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, false, true)
+				userContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+})
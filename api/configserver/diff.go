@@ -0,0 +1,62 @@
+package configserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/tedsuo/rata"
+)
+
+type DiffConfigResponse struct {
+	Diff     atc.ConfigDiff   `json:"diff"`
+	Warnings []config.Warning `json:"warnings,omitempty"`
+}
+
+// DiffConfig validates a config exactly as SaveConfig would, and reports how
+// it differs from the pipeline's current config, without saving anything.
+func (s *Server) DiffConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("diff-config")
+
+	proposed, pipelineTemplate, _, err := saveConfigRequestUnmarshaler(r)
+	if err != nil {
+		s.handleUnmarshalError(w, r, err, session)
+		return
+	}
+
+	warnings, errorMessages := s.validate(proposed)
+	if len(errorMessages) > 0 {
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	if pipelineTemplate.IsTemplated() {
+		warnings = append(warnings, validateTemplate(pipelineTemplate)...)
+	}
+
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	current, _, _, err := teamDB.GetConfig(pipelineName)
+	if err != nil {
+		session.Error("failed-to-get-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	diff, err := config.Diff(current, proposed)
+	if err != nil {
+		session.Error("failed-to-diff-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(DiffConfigResponse{
+		Diff:     diff,
+		Warnings: warnings,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
 	"github.com/tedsuo/rata"
 )
 
@@ -38,10 +39,25 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+	pipelineTemplate, templated, err := teamDB.GetPipelineTemplate(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-pipeline-template", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
 
-	json.NewEncoder(w).Encode(atc.ConfigResponse{
+	configResponse := atc.ConfigResponse{
 		Config:    &config,
 		RawConfig: rawConfig,
-	})
+	}
+
+	if templated {
+		configResponse.Template = pipelineTemplate.Template
+		configResponse.UnsetVariables = template.Missing([]byte(pipelineTemplate.Template), pipelineTemplate.Variables)
+	}
+
+	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", id))
+
+	json.NewEncoder(w).Encode(configResponse)
 }
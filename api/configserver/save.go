@@ -13,8 +13,10 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 	"github.com/mitchellh/mapstructure"
 	"github.com/tedsuo/rata"
 	"gopkg.in/yaml.v2"
@@ -27,6 +29,8 @@ var (
 	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
+	ErrMalformedVariables         = errors.New("variables could not be parsed")
+	ErrVariablesWithoutTemplate   = errors.New("variables given without a template")
 )
 
 type ExtraKeysError struct {
@@ -65,42 +69,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, pausedState, err := saveConfigRequestUnmarshaler(r)
-
-	switch err {
-	case ErrStatusUnsupportedMediaType:
-		w.WriteHeader(http.StatusUnsupportedMediaType)
-		return
-	case ErrMalformedRequestPayload:
-		session.Error("malformed-request-payload", err, lager.Data{
-			"content-type": r.Header.Get("Content-Type"),
-		})
-
-		s.handleBadRequest(w, []string{"malformed config"}, session)
-		return
-	case ErrFailedToConstructDecoder:
-		session.Error("failed-to-construct-decoder", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	case ErrCouldNotDecode:
-		session.Error("could-not-decode", err)
-		s.handleBadRequest(w, []string{"failed to decode config"}, session)
-		return
-	case ErrInvalidPausedValue:
-		session.Error("invalid-paused-value", err)
-		s.handleBadRequest(w, []string{"invalid paused value"}, session)
+	config, pipelineTemplate, pausedState, err := saveConfigRequestUnmarshaler(r)
+	if err != nil {
+		s.handleUnmarshalError(w, r, err, session)
 		return
-	default:
-		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
-				s.handleBadRequest(w, []string{eke.Error()}, session)
-			} else {
-				session.Error("unexpected-error", err)
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-
-			return
-		}
 	}
 
 	warnings, errorMessages := s.validate(config)
@@ -116,7 +88,21 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	teamName := rata.Param(r, "team_name")
 
 	teamDB := s.teamDBFactory.GetTeamDB(teamName)
-	_, created, err := teamDB.SaveConfig(pipelineName, config, version, pausedState)
+
+	if pipelineTemplate.IsTemplated() {
+		warnings = append(warnings, validateTemplate(pipelineTemplate)...)
+	}
+
+	crossPipelineWarnings, err := validateCrossPipelinePassed(teamDB, config)
+	if err != nil {
+		session.Error("failed-to-validate-cross-pipeline-passed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings = append(warnings, crossPipelineWarnings...)
+
+	_, created, err := teamDB.SaveConfigAs(savedBy(r), pipelineName, config, pipelineTemplate, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -135,6 +121,80 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
 }
 
+func (s *Server) handleUnmarshalError(w http.ResponseWriter, r *http.Request, err error, session lager.Logger) {
+	switch err {
+	case ErrStatusUnsupportedMediaType:
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+
+	case ErrMalformedRequestPayload:
+		session.Error("malformed-request-payload", err, lager.Data{
+			"content-type": r.Header.Get("Content-Type"),
+		})
+
+		s.handleBadRequest(w, []string{"malformed config"}, session)
+
+	case ErrFailedToConstructDecoder:
+		session.Error("failed-to-construct-decoder", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+	case ErrCouldNotDecode:
+		session.Error("could-not-decode", err)
+		s.handleBadRequest(w, []string{"failed to decode config"}, session)
+
+	case ErrInvalidPausedValue:
+		session.Error("invalid-paused-value", err)
+		s.handleBadRequest(w, []string{"invalid paused value"}, session)
+
+	case ErrMalformedVariables:
+		session.Error("malformed-variables", err)
+		s.handleBadRequest(w, []string{"malformed variables"}, session)
+
+	case ErrVariablesWithoutTemplate:
+		session.Error("variables-without-template", err)
+		s.handleBadRequest(w, []string{"variables given without a template"}, session)
+
+	default:
+		if eke, ok := err.(ExtraKeysError); ok {
+			s.handleBadRequest(w, []string{eke.Error()}, session)
+		} else {
+			session.Error("unexpected-error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+// savedBy identifies who is saving a config, for the pipeline's config
+// history. Users whose auth provider cannot identify them are known only by
+// their team.
+func savedBy(r *http.Request) string {
+	user, found := auth.GetUser(r)
+	if found {
+		return user
+	}
+
+	team, found := auth.GetTeam(r)
+	if !found {
+		return ""
+	}
+
+	return team.Name()
+}
+
+func validateTemplate(pipelineTemplate db.PipelineTemplate) []config.Warning {
+	return config.ValidateTemplateVariables([]byte(pipelineTemplate.Template), pipelineTemplate.Variables)
+}
+
+func validateCrossPipelinePassed(teamDB db.TeamDB, pipelineConfig atc.Config) ([]config.Warning, error) {
+	return config.ValidateCrossPipelinePassed(pipelineConfig, func(pipelineName string) (atc.Config, bool, error) {
+		pipeline, found, err := teamDB.GetPipelineByName(pipelineName)
+		if err != nil {
+			return atc.Config{}, false, err
+		}
+
+		return pipeline.Config, found, nil
+	})
+}
+
 func (s *Server) handleBadRequest(w http.ResponseWriter, errorMessages []string, session lager.Logger) {
 	w.WriteHeader(http.StatusBadRequest)
 	s.writeSaveConfigResponse(w, SaveConfigResponse{
@@ -154,19 +214,20 @@ func (s *Server) writeSaveConfigResponse(w http.ResponseWriter, saveConfigRespon
 	w.Write(responseJSON)
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelinePausedState, error) {
+func requestToConfig(contentType string, requestBody io.ReadCloser, configStructure interface{}) (db.PipelineTemplate, db.PipelinePausedState, error) {
 	pausedState := db.PipelineNoChange
+	pipelineTemplate := db.PipelineTemplate{}
 
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return db.PipelineNoChange, ErrCannotParseContentType
+		return db.PipelineTemplate{}, db.PipelineNoChange, ErrCannotParseContentType
 	}
 
 	switch mediaType {
 	case "application/json":
 		err := json.NewDecoder(requestBody).Decode(configStructure)
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedRequestPayload
 		}
 
 	case "application/x-yaml":
@@ -176,11 +237,12 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 		}
 
 		if err != nil {
-			return db.PipelineNoChange, ErrMalformedRequestPayload
+			return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedRequestPayload
 		}
 
 	case "multipart/form-data":
 		multipartReader := multipart.NewReader(requestBody, params["boundary"])
+		hasVariables := false
 
 		for {
 			part, err := multipartReader.NextPart()
@@ -190,13 +252,14 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 			}
 
 			if err != nil {
-				return db.PipelineNoChange, err
+				return db.PipelineTemplate{}, db.PipelineNoChange, err
 			}
 
-			if part.FormName() == "paused" {
+			switch part.FormName() {
+			case "paused":
 				pausedValue, err := ioutil.ReadAll(part)
 				if err != nil {
-					return db.PipelineNoChange, err
+					return db.PipelineTemplate{}, db.PipelineNoChange, err
 				}
 
 				if string(pausedValue) == "true" {
@@ -204,28 +267,66 @@ func requestToConfig(contentType string, requestBody io.ReadCloser, configStruct
 				} else if string(pausedValue) == "false" {
 					pausedState = db.PipelineUnpaused
 				} else {
-					return db.PipelineNoChange, ErrInvalidPausedValue
+					return db.PipelineTemplate{}, db.PipelineNoChange, ErrInvalidPausedValue
+				}
+
+			case "template":
+				templateValue, err := ioutil.ReadAll(part)
+				if err != nil {
+					return db.PipelineTemplate{}, db.PipelineNoChange, err
 				}
-			} else {
+
+				pipelineTemplate.Template = string(templateValue)
+
+			case "variables":
+				variablesValue, err := ioutil.ReadAll(part)
+				if err != nil {
+					return db.PipelineTemplate{}, db.PipelineNoChange, err
+				}
+
+				pipelineTemplate.Variables, err = template.ParseVariables(variablesValue)
+				if err != nil {
+					return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedVariables
+				}
+
+				hasVariables = true
+
+			default:
 				partContentType := part.Header.Get("Content-type")
-				_, err := requestToConfig(partContentType, part, configStructure)
+				_, _, err := requestToConfig(partContentType, part, configStructure)
 				if err != nil {
-					return db.PipelineNoChange, ErrMalformedRequestPayload
+					return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedRequestPayload
 				}
 			}
 		}
+
+		if hasVariables && !pipelineTemplate.IsTemplated() {
+			return db.PipelineTemplate{}, db.PipelineNoChange, ErrVariablesWithoutTemplate
+		}
+
+		if pipelineTemplate.IsTemplated() {
+			evaluated, err := template.Evaluate([]byte(pipelineTemplate.Template), pipelineTemplate.Variables)
+			if err != nil {
+				return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedRequestPayload
+			}
+
+			err = yaml.Unmarshal(evaluated, configStructure)
+			if err != nil {
+				return db.PipelineTemplate{}, db.PipelineNoChange, ErrMalformedRequestPayload
+			}
+		}
 	default:
-		return db.PipelineNoChange, ErrStatusUnsupportedMediaType
+		return db.PipelineTemplate{}, db.PipelineNoChange, ErrStatusUnsupportedMediaType
 	}
 
-	return pausedState, nil
+	return pipelineTemplate, pausedState, nil
 }
 
-func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
+func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelineTemplate, db.PipelinePausedState, error) {
 	var configStructure interface{}
-	pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
+	pipelineTemplate, pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body, &configStructure)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, err
+		return atc.Config{}, db.PipelineTemplate{}, db.PipelineNoChange, err
 	}
 
 	var config atc.Config
@@ -242,16 +343,16 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 
 	decoder, err := mapstructure.NewDecoder(msConfig)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrFailedToConstructDecoder
+		return atc.Config{}, db.PipelineTemplate{}, db.PipelineNoChange, ErrFailedToConstructDecoder
 	}
 
 	if err := decoder.Decode(configStructure); err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCouldNotDecode
+		return atc.Config{}, db.PipelineTemplate{}, db.PipelineNoChange, ErrCouldNotDecode
 	}
 
 	if len(md.Unused) != 0 {
-		return atc.Config{}, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
+		return atc.Config{}, db.PipelineTemplate{}, db.PipelineNoChange, ExtraKeysError{extraKeys: md.Unused}
 	}
 
-	return config, pausedState, nil
+	return config, pipelineTemplate, pausedState, nil
 }
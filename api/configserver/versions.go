@@ -0,0 +1,143 @@
+package configserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) GetConfigVersions(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-versions")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	versions, err := teamDB.GetConfigVersions(pipelineName)
+	if err != nil {
+		logger.Error("failed-to-get-config-versions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := make([]atc.ConfigVersion, len(versions))
+	for i, version := range versions {
+		presented[i] = present.ConfigVersion(version)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presented)
+}
+
+func (s *Server) GetConfigVersion(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-config-version")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	version, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	savedVersion, found, err := teamDB.GetConfigVersion(pipelineName, db.ConfigVersion(version))
+	if err != nil {
+		logger.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(present.ConfigVersionWithConfig(savedVersion))
+}
+
+// RollbackConfig saves a config from the pipeline's history as its latest
+// version. The config is validated as though it were being set anew, as the
+// rules may have changed since it was first saved.
+func (s *Server) RollbackConfig(w http.ResponseWriter, r *http.Request) {
+	session := s.logger.Session("rollback-config")
+	pipelineName := rata.Param(r, "pipeline_name")
+	teamDB := s.teamDBFactory.GetTeamDB(rata.Param(r, "team_name"))
+
+	configVersionStr := r.Header.Get(atc.ConfigVersionHeader)
+	if len(configVersionStr) == 0 {
+		s.handleBadRequest(w, []string{"no config version specified"}, session)
+		return
+	}
+
+	var from db.ConfigVersion
+	_, err := fmt.Sscanf(configVersionStr, "%d", &from)
+	if err != nil {
+		session.Error("malformed-config-version", err)
+		s.handleBadRequest(w, []string{fmt.Sprintf("config version is malformed: %s", err)}, session)
+		return
+	}
+
+	version, err := strconv.Atoi(rata.Param(r, "config_version"))
+	if err != nil {
+		s.handleBadRequest(w, []string{fmt.Sprintf("version to roll back to is malformed: %s", err)}, session)
+		return
+	}
+
+	savedVersion, found, err := teamDB.GetConfigVersion(pipelineName, db.ConfigVersion(version))
+	if err != nil {
+		session.Error("failed-to-get-config-version", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	warnings, errorMessages := s.validate(savedVersion.Config)
+	if len(errorMessages) > 0 {
+		session.Info("ignoring-invalid-config")
+		s.handleBadRequest(w, errorMessages, session)
+		return
+	}
+
+	if savedVersion.Template.IsTemplated() {
+		warnings = append(warnings, validateTemplate(savedVersion.Template)...)
+	}
+
+	crossPipelineWarnings, err := validateCrossPipelinePassed(teamDB, savedVersion.Config)
+	if err != nil {
+		session.Error("failed-to-validate-cross-pipeline-passed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	warnings = append(warnings, crossPipelineWarnings...)
+
+	session.Info("rolling-back", lager.Data{"version": version})
+
+	_, _, err = teamDB.SaveConfigAs(savedBy(r), pipelineName, savedVersion.Config, savedVersion.Template, from, db.PipelineNoChange)
+	if err != nil {
+		session.Error("failed-to-save-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "failed to save config: %s", err)
+		return
+	}
+
+	session.Info("rolled-back")
+
+	w.WriteHeader(http.StatusOK)
+
+	s.writeSaveConfigResponse(w, SaveConfigResponse{Warnings: warnings}, session)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -25,6 +26,7 @@ import (
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/mainredirect"
@@ -64,6 +66,7 @@ func NewHandler(
 
 	schedulerFactory jobserver.SchedulerFactory,
 	scannerFactory resourceserver.ScannerFactory,
+	credentialManager creds.CredentialManager,
 
 	sink *lager.ReconfigurableSink,
 
@@ -103,7 +106,7 @@ func NewHandler(
 	)
 
 	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
-	resourceServer := resourceserver.NewServer(logger, scannerFactory)
+	resourceServer := resourceserver.NewServer(logger, scannerFactory, credentialManager)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
 
@@ -125,20 +128,28 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	auditServer := auditserver.NewServer(logger, externalURL, teamDBFactory)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
 
-		atc.GetConfig:  http.HandlerFunc(configServer.GetConfig),
-		atc.SaveConfig: http.HandlerFunc(configServer.SaveConfig),
+		atc.GetConfig:         http.HandlerFunc(configServer.GetConfig),
+		atc.SaveConfig:        http.HandlerFunc(configServer.SaveConfig),
+		atc.DiffConfig:        http.HandlerFunc(configServer.DiffConfig),
+		atc.GetConfigVersions: http.HandlerFunc(configServer.GetConfigVersions),
+		atc.GetConfigVersion:  http.HandlerFunc(configServer.GetConfigVersion),
+		atc.RollbackConfig:    http.HandlerFunc(configServer.RollbackConfig),
 
 		atc.GetBuild:            buildHandlerFactory.HandlerFor(buildServer.GetBuild),
 		atc.ListBuilds:          http.HandlerFunc(buildServer.ListBuilds),
 		atc.CreateBuild:         teamHandlerFactory.HandlerFor(buildServer.CreateBuild),
 		atc.BuildResources:      buildHandlerFactory.HandlerFor(buildServer.BuildResources),
 		atc.AbortBuild:          buildHandlerFactory.HandlerFor(buildServer.AbortBuild),
+		atc.RerunBuild:          buildHandlerFactory.HandlerFor(buildServer.RerunBuild),
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
+		atc.DecideGate:          buildHandlerFactory.HandlerFor(buildServer.DecideGate),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
 
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
@@ -162,17 +173,21 @@ func NewHandler(
 		atc.ExposePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.ExposePipeline),
 		atc.HidePipeline:     pipelineHandlerFactory.HandlerFor(pipelineServer.HidePipeline),
 		atc.GetVersionsDB:    pipelineHandlerFactory.HandlerFor(pipelineServer.GetVersionsDB),
+		atc.GetPipelineGraph: pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipelineGraph),
 		atc.RenamePipeline:   pipelineHandlerFactory.HandlerFor(pipelineServer.RenamePipeline),
 
-		atc.ListResources:   pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.GetResource:     pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
-		atc.PauseResource:   pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
-		atc.UnpauseResource: pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
-		atc.CheckResource:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.ListResources:        pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
+		atc.GetResource:          pipelineHandlerFactory.HandlerFor(resourceServer.GetResource),
+		atc.PauseResource:        pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource),
+		atc.UnpauseResource:      pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource),
+		atc.CheckResource:        pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource),
+		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
+		atc.PinResourceVersion:            pipelineHandlerFactory.HandlerFor(versionServer.PinResourceVersion),
+		atc.UnpinResourceVersion:          pipelineHandlerFactory.HandlerFor(versionServer.UnpinResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
 		atc.ListBuildsWithVersionAsOutput: pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsOutput),
 
@@ -182,6 +197,9 @@ func NewHandler(
 
 		atc.ListWorkers:    teamHandlerFactory.HandlerFor(workerServer.ListWorkers),
 		atc.RegisterWorker: http.HandlerFunc(workerServer.RegisterWorker),
+		atc.LandWorker:     http.HandlerFunc(workerServer.LandWorker),
+		atc.RetireWorker:   http.HandlerFunc(workerServer.RetireWorker),
+		atc.PruneWorker:    http.HandlerFunc(workerServer.PruneWorker),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -199,6 +217,8 @@ func NewHandler(
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+
+		atc.ListAuditEvents: http.HandlerFunc(auditServer.ListAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
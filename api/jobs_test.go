@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
@@ -1210,7 +1212,7 @@ var _ = Describe("Jobs API", func() {
 					It("triggers using the current config", func() {
 						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-						_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+						_, job, resources, resourceTypes, inputOverrides := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 						Expect(job).To(Equal(atc.JobConfig{
 							Name: "some-job",
 							Plan: atc.PlanSequence{
@@ -1226,6 +1228,7 @@ var _ = Describe("Jobs API", func() {
 						Expect(resourceTypes).To(Equal(atc.ResourceTypes{
 							{Name: "custom-resource", Type: "custom-type"},
 						}))
+						Expect(inputOverrides).To(BeEmpty())
 					})
 
 					It("returns 200 OK", func() {
@@ -1251,6 +1254,227 @@ var _ = Describe("Jobs API", func() {
 					})
 				})
 
+				Context("when input overrides are given", func() {
+					var reqBody atc.CreateJobBuildRequestBody
+
+					setRequestBody := func() {
+						payload, err := json.Marshal(reqBody)
+						Expect(err).NotTo(HaveOccurred())
+
+						request.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+						request.ContentLength = int64(len(payload))
+					}
+
+					BeforeEach(func() {
+						pipelineDB.ConfigReturns(atc.Config{
+							Jobs: []atc.JobConfig{
+								{
+									Name: "some-job",
+									Plan: atc.PlanSequence{
+										{Get: "some-input", Resource: "resource-1", Passed: []string{"upstream-job"}},
+										{Get: "other-input", Resource: "resource-2"},
+									},
+								},
+							},
+						})
+
+						pipelineDB.LoadVersionsDBReturns(&algorithm.VersionsDB{
+							ResourceVersions: []algorithm.ResourceVersion{
+								{VersionID: 10, ResourceID: 1, CheckOrder: 1},
+								{VersionID: 11, ResourceID: 1, CheckOrder: 2},
+								{VersionID: 20, ResourceID: 2, CheckOrder: 1},
+							},
+							BuildOutputs: []algorithm.BuildOutput{
+								{ResourceVersion: algorithm.ResourceVersion{VersionID: 10, ResourceID: 1, CheckOrder: 1}, BuildID: 5, JobID: 7},
+							},
+							JobIDs:      map[string]int{"upstream-job": 7, "some-job": 8},
+							ResourceIDs: map[string]int{"resource-1": 1, "resource-2": 2},
+						}, nil)
+
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(42)
+						build.NameReturns("1")
+						build.JobNameReturns("some-job")
+						build.PipelineNameReturns("a-pipeline")
+						build.TeamNameReturns("some-team")
+						build.StatusReturns(db.StatusPending)
+						build.IsManualOverrideReturns(true)
+						fakeScheduler.TriggerImmediatelyReturns(build, nil, nil)
+					})
+
+					Context("when the overrides satisfy the passed constraints", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"some-input":  {VersionedResourceID: 10},
+									"other-input": {Version: atc.Version{"ref": "abc"}},
+								},
+							}
+
+							setRequestBody()
+
+							pipelineDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{ID: 20}, true, nil)
+						})
+
+						It("triggers the build with the resolved versioned resources", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+							_, _, _, _, inputOverrides := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							Expect(inputOverrides).To(Equal(map[string]int{
+								"some-input":  10,
+								"other-input": 20,
+							}))
+						})
+
+						It("looks up the version in the input's resource", func() {
+							version, resourceName := pipelineDB.GetVersionedResourceByVersionArgsForCall(0)
+							Expect(version).To(Equal(atc.Version{"ref": "abc"}))
+							Expect(resourceName).To(Equal("resource-2"))
+						})
+
+						It("returns the build as a manual override", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"id": 42,
+								"name": "1",
+								"job_name": "some-job",
+								"status": "pending",
+								"url": "/teams/some-team/pipelines/a-pipeline/jobs/some-job/builds/1",
+								"api_url": "/api/v1/builds/42",
+								"pipeline_name": "a-pipeline",
+								"team_name": "some-team",
+								"manual_override": true
+							}`))
+						})
+					})
+
+					Context("when an override has not passed the upstream jobs", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"some-input": {VersionedResourceID: 11},
+								},
+							}
+
+							setRequestBody()
+						})
+
+						It("returns 409", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusConflict))
+						})
+
+						It("explains which constraint was not satisfied", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(ContainSubstring("version of input 'some-input' has not passed: upstream-job"))
+						})
+
+						It("does not trigger the build", func() {
+							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+						})
+
+						Context("when passed constraints are ignored", func() {
+							BeforeEach(func() {
+								reqBody.IgnorePassed = true
+								setRequestBody()
+							})
+
+							It("triggers the build with the override", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+								_, _, _, _, inputOverrides := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+								Expect(inputOverrides).To(Equal(map[string]int{"some-input": 11}))
+							})
+						})
+					})
+
+					Context("when overriding an unknown input", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"bogus-input": {VersionedResourceID: 10},
+								},
+							}
+
+							setRequestBody()
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the versioned resource belongs to another resource", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"other-input": {VersionedResourceID: 10},
+								},
+							}
+
+							setRequestBody()
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when the version is not found", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"other-input": {Version: atc.Version{"ref": "missing"}},
+								},
+							}
+
+							setRequestBody()
+
+							pipelineDB.GetVersionedResourceByVersionReturns(db.SavedVersionedResource{}, false, nil)
+						})
+
+						It("returns 400", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						})
+					})
+
+					Context("when loading the versions DB fails", func() {
+						BeforeEach(func() {
+							reqBody = atc.CreateJobBuildRequestBody{
+								Inputs: map[string]atc.InputVersionOverride{
+									"other-input": {VersionedResourceID: 20},
+								},
+							}
+
+							setRequestBody()
+
+							pipelineDB.LoadVersionsDBReturns(nil, errors.New("oh no!"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						var err error
+						request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString("{"))
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not trigger the build", func() {
+						Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when triggering the build fails", func() {
 					BeforeEach(func() {
 						fakeScheduler.TriggerImmediatelyReturns(nil, nil, errors.New("oh no!"))
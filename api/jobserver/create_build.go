@@ -3,8 +3,11 @@ package jobserver
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
 )
@@ -28,9 +31,37 @@ func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
+		var reqBody atc.CreateJobBuildRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var inputOverrides map[string]int
+		if len(reqBody.Inputs) > 0 {
+			inputOverrides, err = resolveInputOverrides(pipelineDB, job, reqBody)
+			switch err.(type) {
+			case nil:
+			case invalidInputOverrideError:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid input override: %s", err)
+				return
+			case passedConstraintError:
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "%s (set ignore_passed to override anyway)", err)
+				return
+			default:
+				logger.Error("failed-to-resolve-input-overrides", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, s.externalURL)
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, config.Resources, config.ResourceTypes)
+		build, _, err := scheduler.TriggerImmediately(logger, job, config.Resources, config.ResourceTypes, inputOverrides)
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,102 @@
+package jobserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+type invalidInputOverrideError struct {
+	message string
+}
+
+func (err invalidInputOverrideError) Error() string {
+	return err.message
+}
+
+type passedConstraintError struct {
+	input  string
+	passed []string
+}
+
+func (err passedConstraintError) Error() string {
+	return fmt.Sprintf(
+		"version of input '%s' has not passed: %s",
+		err.input,
+		strings.Join(err.passed, ", "),
+	)
+}
+
+// resolveInputOverrides turns the requested overrides into versioned
+// resource IDs, keyed by input name, making sure each of them is a version of
+// the input's resource that satisfies its passed constraints.
+func resolveInputOverrides(
+	pipelineDB db.PipelineDB,
+	job atc.JobConfig,
+	reqBody atc.CreateJobBuildRequestBody,
+) (map[string]int, error) {
+	versions, err := pipelineDB.LoadVersionsDB()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := map[string]config.JobInput{}
+	for _, input := range config.JobInputs(job) {
+		inputs[input.Name] = input
+	}
+
+	overrides := map[string]int{}
+
+	for name, override := range reqBody.Inputs {
+		input, found := inputs[name]
+		if !found {
+			return nil, invalidInputOverrideError{fmt.Sprintf("unknown input '%s'", name)}
+		}
+
+		resourceID, found := versions.ResourceIDs[input.Resource]
+		if !found {
+			return nil, invalidInputOverrideError{fmt.Sprintf("resource '%s' of input '%s' has no versions", input.Resource, name)}
+		}
+
+		versionID := override.VersionedResourceID
+
+		if override.Version != nil {
+			savedVersion, found, err := pipelineDB.GetVersionedResourceByVersion(override.Version, input.Resource)
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				return nil, invalidInputOverrideError{fmt.Sprintf("version of input '%s' not found", name)}
+			}
+
+			versionID = savedVersion.ID
+		} else if _, found := versions.FindVersionOfResource(resourceID, versionID); !found {
+			return nil, invalidInputOverrideError{fmt.Sprintf("versioned resource %d of input '%s' not found", versionID, name)}
+		}
+
+		if !reqBody.IgnorePassed && len(input.Passed) > 0 {
+			passed := algorithm.JobSet{}
+			for _, jobName := range input.Passed {
+				jobID, found := versions.JobIDs[jobName]
+				if !found {
+					return nil, passedConstraintError{input: name, passed: input.Passed}
+				}
+
+				passed[jobID] = struct{}{}
+			}
+
+			if !versions.VersionPassedJobs(resourceID, versionID, passed) {
+				return nil, passedConstraintError{input: name, passed: input.Passed}
+			}
+		}
+
+		overrides[name] = versionID
+	}
+
+	return overrides, nil
+}
@@ -1049,6 +1049,9 @@ var _ = Describe("Pipelines API", func() {
 						ResourceIDs: map[string]int{
 							"resource-127": 127,
 						},
+						PinnedVersions: map[int]int{
+							127: 73,
+						},
 						CachedAt: time.Unix(42, 0).UTC(),
 					},
 					nil,
@@ -1105,6 +1108,9 @@ var _ = Describe("Pipelines API", func() {
 				"ResourceIDs": {
 					"resource-127": 127
 				},
+				"PinnedVersions": {
+					"127": 73
+				},
 				"CachedAt": "1970-01-01T00:00:42Z"
 				}`))
 			})
@@ -1121,6 +1127,188 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/graph", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+
+			finishedBuild := new(dbfakes.FakeBuild)
+			finishedBuild.StatusReturns(db.StatusFailed)
+
+			pipelineDB.GetPipelineNameReturns("a-pipeline")
+			pipelineDB.GetDashboardReturns(db.Dashboard{
+				{
+					Job: db.SavedJob{
+						Job: db.Job{Name: "unit"},
+						Config: atc.JobConfig{
+							Name: "unit",
+							Plan: atc.PlanSequence{
+								{Get: "source", Trigger: true},
+								{Get: "toolchain"},
+							},
+						},
+					},
+					FinishedBuild: finishedBuild,
+				},
+				{
+					Job: db.SavedJob{
+						Paused: true,
+						Job:    db.Job{Name: "ship"},
+						Config: atc.JobConfig{
+							Name: "ship",
+							Plan: atc.PlanSequence{
+								{Get: "source", Passed: []string{"unit", "other-pipeline/integration"}, Trigger: true},
+								{Put: "release"},
+							},
+						},
+					},
+				},
+			}, nil, nil)
+
+			pipelineDB.GetResourcesReturns([]db.SavedResource{
+				{Paused: true, Resource: db.Resource{Name: "source"}},
+				{Resource: db.Resource{Name: "toolchain"}},
+				{Resource: db.Resource{Name: "release"}},
+			}, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/pipelines/a-pipeline/graph" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", 0, false, false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(true)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", 42, true, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the jobs and resources with the edges between them", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"nodes": [
+						{"id": "job:unit", "type": "job", "name": "unit", "status": "failed"},
+						{"id": "job:ship", "type": "job", "name": "ship", "paused": true},
+						{"id": "resource:source", "type": "resource", "name": "source", "paused": true},
+						{"id": "resource:toolchain", "type": "resource", "name": "toolchain"},
+						{"id": "resource:release", "type": "resource", "name": "release"},
+						{"id": "job:other-pipeline/integration", "type": "job", "name": "integration", "pipeline": "other-pipeline"}
+					],
+					"edges": [
+						{"source": "resource:source", "target": "job:unit", "type": "trigger"},
+						{"source": "resource:toolchain", "target": "job:unit", "type": "input"},
+						{"source": "job:unit", "target": "job:ship", "type": "passed", "resource": "source", "trigger": true},
+						{"source": "job:other-pipeline/integration", "target": "job:ship", "type": "passed", "resource": "source", "trigger": true},
+						{"source": "job:ship", "target": "resource:release", "type": "output"}
+					]
+				}`))
+			})
+
+			Context("when DOT output is requested", func() {
+				BeforeEach(func() {
+					query = "?format=dot"
+				})
+
+				It("returns text/vnd.graphviz", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/vnd.graphviz"))
+				})
+
+				It("returns the graph in DOT", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(Equal(`digraph "a-pipeline" {
+	"job:unit" [label="unit" shape=box color=red];
+	"job:ship" [label="ship" shape=box style=dashed];
+	"resource:source" [label="source" shape=ellipse style=dashed];
+	"resource:toolchain" [label="toolchain" shape=ellipse];
+	"resource:release" [label="release" shape=ellipse];
+	"job:other-pipeline/integration" [label="other-pipeline/integration" shape=box];
+	"resource:source" -> "job:unit" [label="trigger"];
+	"resource:toolchain" -> "job:unit" [label="input" style=dashed];
+	"job:unit" -> "job:ship" [label="source"];
+	"job:other-pipeline/integration" -> "job:ship" [label="source"];
+	"job:ship" -> "resource:release" [label="output"];
+}
+`))
+				})
+			})
+
+			Context("when an unknown format is requested", func() {
+				BeforeEach(func() {
+					query = "?format=svg"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the dashboard fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetDashboardReturns(nil, nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when getting the resources fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetResourcesReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 
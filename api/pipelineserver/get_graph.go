@@ -0,0 +1,105 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetPipelineGraph(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("get-pipeline-graph")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format != "" && format != "json" && format != "dot" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "unknown format: '%s'", format)
+			return
+		}
+
+		dashboard, _, err := pipelineDB.GetDashboard()
+		if err != nil {
+			logger.Error("failed-to-get-dashboard", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resources, _, err := pipelineDB.GetResources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		graph := present.PipelineGraph(dashboard, resources)
+
+		if format == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			w.WriteHeader(http.StatusOK)
+			writeDOT(w, pipelineDB.GetPipelineName(), graph)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(graph)
+	})
+}
+
+var dotStatusColors = map[string]string{
+	string(db.StatusSucceeded): "green",
+	string(db.StatusFailed):    "red",
+	string(db.StatusErrored):   "orange",
+	string(db.StatusAborted):   "brown",
+}
+
+func writeDOT(w io.Writer, pipelineName string, graph atc.PipelineGraph) {
+	fmt.Fprintf(w, "digraph %s {\n", strconv.Quote(pipelineName))
+
+	for _, node := range graph.Nodes {
+		label := node.Name
+		if node.Pipeline != "" {
+			label = node.Pipeline + "/" + node.Name
+		}
+
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(label))
+
+		if node.Type == atc.GraphNodeJob {
+			attrs += " shape=box"
+		} else {
+			attrs += " shape=ellipse"
+		}
+
+		if color, found := dotStatusColors[node.Status]; found {
+			attrs += " color=" + color
+		}
+
+		if node.Paused {
+			attrs += " style=dashed"
+		}
+
+		fmt.Fprintf(w, "\t%s [%s];\n", strconv.Quote(node.ID), attrs)
+	}
+
+	for _, edge := range graph.Edges {
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(string(edge.Type)))
+
+		if edge.Resource != "" {
+			attrs = fmt.Sprintf("label=%s", strconv.Quote(edge.Resource))
+		}
+
+		if edge.Type == atc.GraphEdgeInput || (edge.Type == atc.GraphEdgePassed && !edge.Trigger) {
+			attrs += " style=dashed"
+		}
+
+		fmt.Fprintf(w, "\t%s -> %s [%s];\n", strconv.Quote(edge.Source), strconv.Quote(edge.Target), attrs)
+	}
+
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,21 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:            event.ID,
+		TeamName:      event.TeamName,
+		Route:         event.Route,
+		Target:        event.Target,
+		Status:        event.Status,
+		ActorTeamName: event.ActorTeamName,
+		ActorUser:     event.ActorUser,
+		ActorRole:     event.ActorRole,
+		ActorIsSystem: event.ActorIsSystem,
+		CreatedAt:     event.CreatedAt.Unix(),
+	}
+}
@@ -52,6 +52,9 @@ func Build(build db.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		URL:          reqURL,
 		APIURL:       apiURL,
+
+		ManualOverride: build.IsManualOverride(),
+		RerunOf:        build.RerunOf(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func ConfigVersion(version db.SavedConfigVersion) atc.ConfigVersion {
+	return atc.ConfigVersion{
+		Version: int(version.Version),
+		SavedBy: version.SavedBy,
+		SavedAt: version.SavedAt.Unix(),
+	}
+}
+
+func ConfigVersionWithConfig(version db.SavedConfigVersion) atc.ConfigVersion {
+	presented := ConfigVersion(version)
+
+	config := version.Config
+	presented.Config = &config
+	presented.Template = version.Template.Template
+
+	return presented
+}
@@ -0,0 +1,120 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+)
+
+func PipelineGraph(dashboard db.Dashboard, resources []db.SavedResource) atc.PipelineGraph {
+	graph := atc.PipelineGraph{
+		Nodes: []atc.GraphNode{},
+		Edges: []atc.GraphEdge{},
+	}
+
+	nodes := map[string]bool{}
+	addNode := func(node atc.GraphNode) {
+		if nodes[node.ID] {
+			return
+		}
+
+		nodes[node.ID] = true
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	edges := map[atc.GraphEdge]bool{}
+	addEdge := func(edge atc.GraphEdge) {
+		if edges[edge] {
+			return
+		}
+
+		edges[edge] = true
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	for _, job := range dashboard {
+		node := atc.GraphNode{
+			ID:     atc.GraphJobID(job.Job.Name),
+			Type:   atc.GraphNodeJob,
+			Name:   job.Job.Name,
+			Paused: job.Job.Paused,
+		}
+
+		if job.FinishedBuild != nil {
+			node.Status = string(job.FinishedBuild.Status())
+		}
+
+		addNode(node)
+	}
+
+	for _, resource := range resources {
+		addNode(atc.GraphNode{
+			ID:     atc.GraphResourceID(resource.Name),
+			Type:   atc.GraphNodeResource,
+			Name:   resource.Name,
+			Paused: resource.Paused,
+		})
+	}
+
+	for _, job := range dashboard {
+		jobID := atc.GraphJobID(job.Job.Name)
+
+		for _, input := range config.JobInputs(job.Job.Config) {
+			if len(input.Passed) == 0 {
+				edgeType := atc.GraphEdgeInput
+				if input.Trigger {
+					edgeType = atc.GraphEdgeTrigger
+				}
+
+				addNode(graphResourceNode(input.Resource))
+				addEdge(atc.GraphEdge{
+					Source: atc.GraphResourceID(input.Resource),
+					Target: jobID,
+					Type:   edgeType,
+				})
+
+				continue
+			}
+
+			for _, passed := range input.Passed {
+				sourceID := atc.GraphJobID(passed)
+
+				if pipelineName, jobName, ok := config.CrossPipelineJob(passed); ok {
+					addNode(atc.GraphNode{
+						ID:       sourceID,
+						Type:     atc.GraphNodeJob,
+						Name:     jobName,
+						Pipeline: pipelineName,
+					})
+				}
+
+				addEdge(atc.GraphEdge{
+					Source:   sourceID,
+					Target:   jobID,
+					Type:     atc.GraphEdgePassed,
+					Resource: input.Resource,
+					Trigger:  input.Trigger,
+				})
+			}
+		}
+
+		for _, output := range config.JobOutputs(job.Job.Config) {
+			addNode(graphResourceNode(output.Resource))
+			addEdge(atc.GraphEdge{
+				Source: jobID,
+				Target: atc.GraphResourceID(output.Resource),
+				Type:   atc.GraphEdgeOutput,
+			})
+		}
+	}
+
+	return graph
+}
+
+func graphResourceNode(name string) atc.GraphNode {
+	return atc.GraphNode{
+		ID:   atc.GraphResourceID(name),
+		Type: atc.GraphNodeResource,
+		Name: name,
+	}
+}
@@ -47,5 +47,8 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
+
+		PinnedVersion: atc.Version(resource.PinnedVersion),
+		PinComment:    resource.PinComment,
 	}
 }
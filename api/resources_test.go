@@ -75,6 +75,9 @@ var _ = Describe("Resources API", func() {
 						Name: "resource-3",
 						Type: "type-3",
 					},
+					PinnedVersionID: 7,
+					PinnedVersion:   db.Version{"ref": "abc"},
+					PinComment:      "broken upstream",
 				}
 
 				fakePipelineDB.GetResourcesReturns([]db.SavedResource{
@@ -145,7 +148,9 @@ var _ = Describe("Resources API", func() {
 						"type": "type-3",
 						"groups": [],
 						"paused": true,
-						"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-3"
+						"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-3",
+						"pinned_version": {"ref": "abc"},
+						"pin_comment": "broken upstream"
 					}
 				]`))
 					})
@@ -187,7 +192,9 @@ var _ = Describe("Resources API", func() {
 							"type": "type-3",
 							"groups": [],
 							"paused": true,
-							"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-3"
+							"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-3",
+							"pinned_version": {"ref": "abc"},
+							"pin_comment": "broken upstream"
 						}
 					]`))
 				})
@@ -704,4 +711,194 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var fakeScanner *radarfakes.FakeScanner
+		var webhookToken string
+		var response *http.Response
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			fakePipelineDB.ConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:         "resource-name",
+						WebhookToken: "fake-token",
+					},
+				},
+			})
+
+			webhookToken = "fake-token"
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token="+webhookToken, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the webhook token matches", func() {
+			It("does not require authentication", func() {
+				Expect(authValidator.IsAuthenticatedCallCount()).To(BeZero())
+			})
+
+			It("scans from no version", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Expect(actualResourceName).To(Equal("resource-name"))
+				Expect(actualFromVersion).To(BeNil())
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("when the resource already has versions", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetLatestVersionedResourceReturns(db.SavedVersionedResource{
+						VersionedResource: db.VersionedResource{
+							Version: db.Version{"some": "version"},
+						},
+					}, true, nil)
+				})
+
+				It("scans from the latest version", func() {
+					_, _, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualFromVersion).To(Equal(atc.Version{"some": "version"}))
+				})
+			})
+
+			Context("when checking the resource fails internally", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the webhook token is wrong", func() {
+			BeforeEach(func() {
+				webhookToken = "wrong-token"
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the webhook token is a ((secret)) reference", func() {
+			BeforeEach(func() {
+				fakePipelineDB.TeamNameReturns("a-team")
+				fakePipelineDB.GetPipelineNameReturns("a-pipeline")
+				fakePipelineDB.ConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:         "resource-name",
+							WebhookToken: "((webhook-token))",
+						},
+					},
+				})
+
+				fakeCredentialManager.GetReturns("secret-token", true, nil)
+			})
+
+			Context("when the resolved token matches", func() {
+				BeforeEach(func() {
+					webhookToken = "secret-token"
+				})
+
+				It("looks up the secret for the pipeline", func() {
+					Expect(fakeCredentialManager.GetCallCount()).To(Equal(1))
+					teamName, pipelineName, secretName := fakeCredentialManager.GetArgsForCall(0)
+					Expect(teamName).To(Equal("a-team"))
+					Expect(pipelineName).To(Equal("a-pipeline"))
+					Expect(secretName).To(Equal("webhook-token"))
+				})
+
+				It("scans", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the reference itself is presented", func() {
+				BeforeEach(func() {
+					webhookToken = "((webhook-token))"
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the secret is not defined", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns(nil, false, nil)
+					webhookToken = ""
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not scan", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking up the secret fails", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns(nil, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the resource has no webhook token configured", func() {
+			BeforeEach(func() {
+				fakePipelineDB.ConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "resource-name"},
+					},
+				})
+
+				webhookToken = ""
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not scan", func() {
+				Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the resource is not in the pipeline", func() {
+			BeforeEach(func() {
+				fakePipelineDB.ConfigReturns(atc.Config{})
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
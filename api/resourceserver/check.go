@@ -42,22 +42,26 @@ func (s *Server) CheckResource(pipelineDB db.PipelineDB) http.Handler {
 		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
 
 		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
-		switch scanErr := err.(type) {
-		case resource.ErrResourceScriptFailed:
-			checkResponseBody := atc.CheckResponseBody{
-				ExitStatus: scanErr.ExitStatus,
-				Stderr:     scanErr.Stderr,
-			}
+		s.writeCheckResponse(w, err)
+	})
+}
 
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(checkResponseBody)
-		case db.ResourceNotFoundError:
-			w.WriteHeader(http.StatusNotFound)
-		case error:
-			w.WriteHeader(http.StatusInternalServerError)
-		default:
-			w.WriteHeader(http.StatusOK)
+func (s *Server) writeCheckResponse(w http.ResponseWriter, err error) {
+	switch scanErr := err.(type) {
+	case resource.ErrResourceScriptFailed:
+		checkResponseBody := atc.CheckResponseBody{
+			ExitStatus: scanErr.ExitStatus,
+			Stderr:     scanErr.Stderr,
 		}
-	})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(checkResponseBody)
+	case db.ResourceNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	case error:
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
 }
@@ -0,0 +1,69 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+// CheckResourceWebHook is reachable without authentication; instead, the
+// caller has to present the webhook_token configured on the resource, which
+// may be a ((secret)) reference so that it needn't be stored in the config. It
+// always checks from the latest known version.
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB) http.Handler {
+	logger := s.logger.Session("check-resource-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.URL.Query().Get("webhook_token")
+
+		resourceConfig, found := pipelineDB.Config().Resources.Lookup(resourceName)
+		if !found {
+			logger.Info("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		variables := creds.NewVariables(s.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+
+		expectedToken, err := variables.EvaluateString(resourceConfig.WebhookToken)
+		if err != nil {
+			if _, ok := err.(creds.UndefinedSecretError); !ok {
+				logger.Error("failed-to-evaluate-webhook-token", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			logger.Info("undefined-webhook-token", lager.Data{"resource": resourceName, "error": err.Error()})
+			expectedToken = ""
+		}
+
+		if expectedToken == "" || subtle.ConstantTimeCompare([]byte(expectedToken), []byte(webhookToken)) != 1 {
+			logger.Info("invalid-webhook-token", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var fromVersion atc.Version
+		latestVersion, found, err := pipelineDB.GetLatestVersionedResource(resourceName)
+		if err != nil {
+			logger.Info("failed-to-get-latest-versioned-resource", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if found {
+			fromVersion = atc.Version(latestVersion.Version)
+		}
+
+		scanner := s.scannerFactory.NewResourceScanner(pipelineDB)
+
+		err = scanner.ScanFromVersion(logger, resourceName, fromVersion)
+		s.writeCheckResponse(w, err)
+	})
+}
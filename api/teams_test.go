@@ -502,6 +502,77 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Describe("role bindings", func() {
+				BeforeEach(func() {
+					team = atc.Team{
+						GitHubAuth: &atc.GitHubAuth{
+							ClientID:     "Brock Samson",
+							ClientSecret: "09262-8765-001",
+							Users:        []string{"Hank Venture"},
+						},
+					}
+				})
+
+				Context("when the binding is valid", func() {
+					BeforeEach(func() {
+						team.Roles = []atc.TeamRoleBinding{
+							{Role: atc.TeamRoleViewer, GitHubUsers: []string{"Dr. Girlfriend"}},
+						}
+					})
+
+					It("responds with 201", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					})
+				})
+
+				Context("when the role is unknown", func() {
+					BeforeEach(func() {
+						team.Roles = []atc.TeamRoleBinding{
+							{Role: "henchman", GitHubUsers: []string{"Henchman 21"}},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the binding lists no identities", func() {
+					BeforeEach(func() {
+						team.Roles = []atc.TeamRoleBinding{
+							{Role: atc.TeamRoleViewer},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the binding lists identities of a provider which is not configured", func() {
+					BeforeEach(func() {
+						team.Roles = []atc.TeamRoleBinding{
+							{Role: atc.TeamRoleViewer, CFSpaces: []string{"Spider-Skull Island"}},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the requester is not an owner of their team", func() {
+				BeforeEach(func() {
+					userContextReader.GetRoleReturns(atc.TeamRoleMember, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(teamServerDB.CreateTeamCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when there's a problem finding teams", func() {
 				BeforeEach(func() {
 					teamDB.GetTeamReturns(db.SavedTeam{}, false, errors.New("a dingo ate my baby!"))
@@ -669,6 +740,23 @@ var _ = Describe("Teams API", func() {
 						})
 					})
 
+					Context("when passed role bindings", func() {
+						BeforeEach(func() {
+							team.GitHubAuth = gitHubAuth
+							team.Roles = []atc.TeamRoleBinding{
+								{Role: atc.TeamRoleOperator, GitHubUsers: []string{"Brock Samson"}},
+							}
+						})
+
+						It("updates the role bindings for that team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(teamDB.UpdateRolesCallCount()).To(Equal(1))
+							Expect(teamDB.UpdateRolesArgsForCall(0)).To(Equal([]atc.TeamRoleBinding{
+								{Role: atc.TeamRoleOperator, GitHubUsers: []string{"Brock Samson"}},
+							}))
+						})
+					})
+
 				})
 			})
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/concourse/atc/api/present"
@@ -102,6 +103,11 @@ func (s *Server) updateCredentials(team db.Team, teamDB db.TeamDB) error {
 		return err
 	}
 
+	_, err = teamDB.UpdateRoles(team.Roles)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -166,5 +172,31 @@ func (s *Server) validate(team db.Team) error {
 		}
 	}
 
+	for _, binding := range team.Roles {
+		if !binding.Role.IsValid() {
+			return fmt.Errorf("unknown role '%s'", binding.Role)
+		}
+
+		hasGitHubIdentities := len(binding.GitHubOrganizations) > 0 || len(binding.GitHubTeams) > 0 || len(binding.GitHubUsers) > 0
+		hasCFIdentities := len(binding.CFSpaces) > 0
+		hasGenericOAuthIdentities := binding.GenericOAuthScope != ""
+
+		if !hasGitHubIdentities && !hasCFIdentities && !hasGenericOAuthIdentities {
+			return fmt.Errorf("%s role binding requires at least one identity", binding.Role)
+		}
+
+		if hasGitHubIdentities && team.GitHubAuth == nil {
+			return fmt.Errorf("%s role binding lists GitHub identities but GitHub auth is not configured", binding.Role)
+		}
+
+		if hasCFIdentities && team.UAAAuth == nil {
+			return fmt.Errorf("%s role binding lists CF spaces but CF auth is not configured", binding.Role)
+		}
+
+		if hasGenericOAuthIdentities && team.GenericOAuth == nil {
+			return fmt.Errorf("%s role binding lists a Generic OAuth scope but Generic OAuth is not configured", binding.Role)
+		}
+	}
+
 	return nil
 }
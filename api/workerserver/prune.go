@@ -0,0 +1,34 @@
+package workerserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) PruneWorker(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+
+	logger := s.logger.Session("prune-worker", lager.Data{"worker-name": workerName})
+
+	found, err := s.db.PruneWorker(workerName)
+	if err == db.ErrCannotPruneRunningWorker {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-prune-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
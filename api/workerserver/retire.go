@@ -0,0 +1,27 @@
+package workerserver
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+)
+
+func (s *Server) RetireWorker(w http.ResponseWriter, r *http.Request) {
+	workerName := r.FormValue(":worker_name")
+
+	logger := s.logger.Session("retire-worker", lager.Data{"worker-name": workerName})
+
+	found, err := s.db.RetireWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-retire-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -19,19 +19,23 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/audit"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/gc/auditreaper"
 	"github.com/concourse/atc/gc/buildreaper"
 	"github.com/concourse/atc/gc/containerkeepaliver"
 	"github.com/concourse/atc/gc/dbgc"
 	"github.com/concourse/atc/gc/lostandfound"
 	"github.com/concourse/atc/lockrunner"
+	"github.com/concourse/atc/logarchive"
 	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
@@ -81,8 +85,22 @@ type ATCCommand struct {
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
+	DefaultBuildLogsToRetain     int `long:"default-build-logs-to-retain" description:"Default number of build logs to keep per job, for jobs which do not configure their own retention."`
+	DefaultDaysToRetainBuildLogs int `long:"default-days-to-retain-build-logs" description:"Default number of days to keep build logs for, for jobs which do not configure their own retention."`
+
+	BuildLogArchiveDir DirFlag `long:"build-log-archive-dir" description:"Directory to archive build logs to before they are reaped. Archived logs can still be viewed."`
+
+	AuditEventRetention time.Duration `long:"audit-event-retention" default:"2160h" description:"Length of time for which to keep audit events. Set to 0 to keep them forever."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	CredentialsDir DirFlag `long:"credentials-dir" description:"Directory containing secrets to resolve ((references)) with, laid out as TEAM/SECRET or TEAM/PIPELINE/SECRET."`
+
+	DefaultTaskCPULimit    int64 `long:"default-task-cpu-limit"    description:"Default CPU shares for task containers, for tasks which do not configure their own limits."`
+	DefaultTaskMemoryLimit int64 `long:"default-task-memory-limit" description:"Default memory limit, in bytes, for task containers, for tasks which do not configure their own limits."`
+
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-active-containers" description:"Method by which a worker is selected during container placement."`
+
 	Developer struct {
 		DevelopmentMode bool `short:"d" long:"development-mode"  description:"Lax security rules to make local development easier."`
 		Noop            bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
@@ -115,6 +133,8 @@ type ATCCommand struct {
 		RiemannHost          string `long:"riemann-host"                description:"Riemann server address to emit metrics to."`
 		RiemannPort          uint16 `long:"riemann-port" default:"5555" description:"Port of the Riemann server to emit metrics to."`
 		RiemannServicePrefix string `long:"riemann-service-prefix" default:"" description:"An optional prefix for emitted Riemann services"`
+
+		PrometheusEnabled bool `long:"prometheus-enabled" description:"Expose Prometheus metrics on /metrics via the debug listener."`
 	} `group:"Metrics & Diagnostics"`
 
 	LogDBQueries bool `long:"log-db-queries" description:"Log database queries."`
@@ -139,9 +159,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
-	if cmd.Metrics.RiemannHost != "" {
-		cmd.configureMetrics(logger)
-	}
+	cmd.configureMetrics(logger)
 
 	dbConn, err := cmd.constructDBConn(logger)
 	if err != nil {
@@ -164,23 +182,34 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	trackerFactory := resource.NewTrackerFactory()
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock())
 	pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
-	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory)
+
+	placementStrategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	workerClient := cmd.constructWorkerPool(logger, sqlDB, trackerFactory, resourceFetcherFactory, pipelineDBFactory, placementStrategy)
 
 	tracker := trackerFactory.TrackerFor(workerClient)
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, teamDBFactory)
+	credentialManager := cmd.constructCredentialManager()
+
+	engine := cmd.constructEngine(workerClient, tracker, resourceFetcher, sqlDB, teamDBFactory, placementStrategy, credentialManager)
+	logArchive := cmd.constructLogArchive()
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		engine,
+		credentialManager,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		tracker,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
+		credentialManager,
 	)
 
 	signingKey, err := cmd.loadOrGenerateSigningKey()
@@ -223,6 +252,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		drain,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialManager,
+		logArchive,
 	)
 
 	if err != nil {
@@ -358,6 +389,12 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				sqlDB,
 				pipelineDBFactory,
 				500,
+				atc.BuildLogRetention{
+					Builds: cmd.DefaultBuildLogsToRetain,
+					Days:   cmd.DefaultDaysToRetainBuildLogs,
+				},
+				clock.NewClock(),
+				logArchive,
 			),
 			"build-reaper",
 			sqlDB,
@@ -365,6 +402,20 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			30*time.Second,
 		)},
 
+		{"auditreaper", lockrunner.NewRunner(
+			logger.Session("audit-reaper-runner"),
+			auditreaper.NewAuditReaper(
+				logger.Session("audit-reaper"),
+				sqlDB,
+				cmd.AuditEventRetention,
+				clock.NewClock(),
+			),
+			"audit-reaper",
+			sqlDB,
+			clock.NewClock(),
+			time.Hour,
+		)},
+
 		{"dbgc", lockrunner.NewRunner(
 			logger.Session("dbgc"),
 			dbgc.NewDBGarbageCollector(
@@ -522,6 +573,20 @@ func (cmd *ATCCommand) validate() error {
 		)
 	}
 
+	if cmd.DefaultTaskCPULimit < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--default-task-cpu-limit must not be negative"),
+		)
+	}
+
+	if cmd.DefaultTaskMemoryLimit < 0 {
+		errs = multierror.Append(
+			errs,
+			errors.New("--default-task-memory-limit must not be negative"),
+		)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -569,6 +634,25 @@ func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSin
 }
 
 func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
+	var emitters []metric.Emitter
+
+	if cmd.Metrics.RiemannHost != "" {
+		emitters = append(emitters, metric.NewRiemannEmitter(
+			fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
+			cmd.Metrics.RiemannServicePrefix,
+		))
+	}
+
+	if cmd.Metrics.PrometheusEnabled {
+		prometheusEmitter := metric.NewPrometheusEmitter()
+		http.DefaultServeMux.Handle("/metrics", prometheusEmitter.Handler())
+		emitters = append(emitters, prometheusEmitter)
+	}
+
+	if len(emitters) == 0 {
+		return
+	}
+
 	host := cmd.Metrics.HostName
 	if host == "" {
 		host, _ = os.Hostname()
@@ -576,11 +660,10 @@ func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
 
 	metric.Initialize(
 		logger.Session("metrics"),
-		fmt.Sprintf("%s:%d", cmd.Metrics.RiemannHost, cmd.Metrics.RiemannPort),
 		host,
 		cmd.Metrics.Tags,
 		cmd.Metrics.Attributes,
-		cmd.Metrics.RiemannServicePrefix,
+		emitters...,
 	)
 }
 
@@ -629,6 +712,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 	trackerFactory resource.TrackerFactory,
 	resourceFetcherFactory resource.FetcherFactory,
 	pipelineDBFactory db.PipelineDBFactory,
+	placementStrategy worker.ContainerPlacementStrategy,
 ) worker.Client {
 	return worker.NewPool(
 		worker.NewDBWorkerProvider(
@@ -639,6 +723,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 			image.NewFactory(trackerFactory, resourceFetcherFactory),
 			pipelineDBFactory,
 		),
+		placementStrategy,
 	)
 }
 
@@ -761,12 +846,21 @@ func (cmd *ATCCommand) constructEngine(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
+	lockDB exec.LockDB,
 	teamDBFactory db.TeamDBFactory,
+	placementStrategy worker.ContainerPlacementStrategy,
+	credentialManager creds.CredentialManager,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		tracker,
 		resourceFetcher,
+		lockDB,
+		placementStrategy,
+		atc.ContainerLimits{
+			CPU:    cmd.DefaultTaskCPULimit,
+			Memory: cmd.DefaultTaskMemoryLimit,
+		},
 	)
 
 	execV2Engine := engine.NewExecEngine(
@@ -774,6 +868,7 @@ func (cmd *ATCCommand) constructEngine(
 		engine.NewBuildDelegateFactory(),
 		teamDBFactory,
 		cmd.ExternalURL.String(),
+		credentialManager,
 	)
 
 	execV1Engine := engine.NewExecV1DummyEngine()
@@ -781,6 +876,22 @@ func (cmd *ATCCommand) constructEngine(
 	return engine.NewDBEngine(engine.Engines{execV2Engine, execV1Engine})
 }
 
+func (cmd *ATCCommand) constructCredentialManager() creds.CredentialManager {
+	if cmd.CredentialsDir == "" {
+		return creds.NoopCredentialManager{}
+	}
+
+	return creds.NewFileCredentialManager(cmd.CredentialsDir.Path())
+}
+
+func (cmd *ATCCommand) constructLogArchive() logarchive.LogArchive {
+	if cmd.BuildLogArchiveDir == "" {
+		return nil
+	}
+
+	return logarchive.NewLocalArchive(cmd.BuildLogArchiveDir.Path())
+}
+
 func (cmd *ATCCommand) constructHTTPHandler(
 	webHandler http.Handler,
 	publicHandler http.Handler,
@@ -816,6 +927,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 	drain <-chan struct{},
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
+	credentialManager creds.CredentialManager,
+	logArchive logarchive.LogArchive,
 ) (http.Handler, error) {
 	authValidator := auth.JWTValidator{
 		PublicKey: &signingKey.PublicKey,
@@ -841,6 +954,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
 			checkBuildWriteAccessHandlerFactory,
+			audit.NewHandlerFactory(logger.Session("audit"), sqlDB),
 		),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
@@ -867,13 +981,14 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		config.ValidateConfig,
 		cmd.PeerURL.String(),
-		buildserver.NewEventHandler,
+		buildserver.NewEventHandlerFactory(logArchive),
 		drain,
 
 		engine,
 		workerClient,
 		radarSchedulerFactory,
 		radarScannerFactory,
+		credentialManager,
 
 		reconfigurableSink,
 
@@ -0,0 +1,77 @@
+// This file was generated by counterfeiter
+package auditfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/db"
+)
+
+type FakeAuditDB struct {
+	CreateAuditEventStub        func(event db.AuditEvent) error
+	createAuditEventMutex       sync.RWMutex
+	createAuditEventArgsForCall []struct {
+		event db.AuditEvent
+	}
+	createAuditEventReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditDB) CreateAuditEvent(event db.AuditEvent) error {
+	fake.createAuditEventMutex.Lock()
+	fake.createAuditEventArgsForCall = append(fake.createAuditEventArgsForCall, struct {
+		event db.AuditEvent
+	}{event})
+	fake.recordInvocation("CreateAuditEvent", []interface{}{event})
+	fake.createAuditEventMutex.Unlock()
+	if fake.CreateAuditEventStub != nil {
+		return fake.CreateAuditEventStub(event)
+	} else {
+		return fake.createAuditEventReturns.result1
+	}
+}
+
+func (fake *FakeAuditDB) CreateAuditEventCallCount() int {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return len(fake.createAuditEventArgsForCall)
+}
+
+func (fake *FakeAuditDB) CreateAuditEventArgsForCall(i int) db.AuditEvent {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return fake.createAuditEventArgsForCall[i].event
+}
+
+func (fake *FakeAuditDB) CreateAuditEventReturns(result1 error) {
+	fake.CreateAuditEventStub = nil
+	fake.createAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuditDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ audit.AuditDB = new(FakeAuditDB)
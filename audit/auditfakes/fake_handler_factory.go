@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package auditfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/audit"
+)
+
+type FakeHandlerFactory struct {
+	HandlerForStub        func(route string, handler http.Handler) http.Handler
+	handlerForMutex       sync.RWMutex
+	handlerForArgsForCall []struct {
+		route   string
+		handler http.Handler
+	}
+	handlerForReturns struct {
+		result1 http.Handler
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHandlerFactory) HandlerFor(route string, handler http.Handler) http.Handler {
+	fake.handlerForMutex.Lock()
+	fake.handlerForArgsForCall = append(fake.handlerForArgsForCall, struct {
+		route   string
+		handler http.Handler
+	}{route, handler})
+	fake.recordInvocation("HandlerFor", []interface{}{route, handler})
+	fake.handlerForMutex.Unlock()
+	if fake.HandlerForStub != nil {
+		return fake.HandlerForStub(route, handler)
+	} else {
+		return fake.handlerForReturns.result1
+	}
+}
+
+func (fake *FakeHandlerFactory) HandlerForCallCount() int {
+	fake.handlerForMutex.RLock()
+	defer fake.handlerForMutex.RUnlock()
+	return len(fake.handlerForArgsForCall)
+}
+
+func (fake *FakeHandlerFactory) HandlerForArgsForCall(i int) (string, http.Handler) {
+	fake.handlerForMutex.RLock()
+	defer fake.handlerForMutex.RUnlock()
+	return fake.handlerForArgsForCall[i].route, fake.handlerForArgsForCall[i].handler
+}
+
+func (fake *FakeHandlerFactory) HandlerForReturns(result1 http.Handler) {
+	fake.HandlerForStub = nil
+	fake.handlerForReturns = struct {
+		result1 http.Handler
+	}{result1}
+}
+
+func (fake *FakeHandlerFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.handlerForMutex.RLock()
+	defer fake.handlerForMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHandlerFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ audit.HandlerFactory = new(FakeHandlerFactory)
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . AuditDB
+
+type AuditDB interface {
+	CreateAuditEvent(event db.AuditEvent) error
+}
+
+//go:generate counterfeiter . HandlerFactory
+
+type HandlerFactory interface {
+	HandlerFor(route string, handler http.Handler) http.Handler
+}
+
+type handlerFactory struct {
+	logger  lager.Logger
+	auditDB AuditDB
+}
+
+func NewHandlerFactory(logger lager.Logger, auditDB AuditDB) HandlerFactory {
+	return &handlerFactory{
+		logger:  logger,
+		auditDB: auditDB,
+	}
+}
+
+func (f *handlerFactory) HandlerFor(route string, handler http.Handler) http.Handler {
+	return auditHandler{
+		logger:  f.logger,
+		auditDB: f.auditDB,
+		route:   route,
+		handler: handler,
+	}
+}
+
+type auditHandler struct {
+	logger  lager.Logger
+	auditDB AuditDB
+	route   string
+	handler http.Handler
+}
+
+func (h auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	h.handler.ServeHTTP(recorder, r)
+
+	event := db.AuditEvent{
+		Route:         h.route,
+		Target:        routeTarget(r),
+		Status:        recorder.status,
+		ActorIsSystem: auth.IsSystem(r),
+	}
+
+	actor, found := auth.GetTeam(r)
+	if found {
+		event.ActorTeamName = actor.Name()
+		event.ActorRole = actor.Role()
+	}
+
+	event.ActorUser, _ = auth.GetUser(r)
+
+	event.TeamName = r.URL.Query().Get(":team_name")
+	if event.TeamName == "" {
+		event.TeamName = event.ActorTeamName
+	}
+
+	err := h.auditDB.CreateAuditEvent(event)
+	if err != nil {
+		h.logger.Error("failed-to-record-audit-event", err, lager.Data{
+			"route":  h.route,
+			"status": recorder.status,
+		})
+	}
+}
+
+// routeTarget collects the route parameters identifying what the request
+// acted upon. Plain query parameters are left out, as they may carry secrets
+// such as webhook tokens.
+func routeTarget(r *http.Request) map[string]string {
+	var target map[string]string
+
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, ":") || key == ":team_name" || len(values) == 0 {
+			continue
+		}
+
+		if target == nil {
+			target = map[string]string{}
+		}
+
+		target[strings.TrimPrefix(key, ":")] = values[0]
+	}
+
+	return target
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,167 @@
+package audit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Handler", func() {
+	var (
+		logger                *lagertest.TestLogger
+		fakeAuditDB           *auditfakes.FakeAuditDB
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		innerStatus  int
+		innerHandler http.Handler
+		handler      http.Handler
+
+		request  *http.Request
+		response *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeAuditDB = new(auditfakes.FakeAuditDB)
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		innerStatus = http.StatusOK
+		innerHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if innerStatus != http.StatusOK {
+				w.WriteHeader(innerStatus)
+			}
+			w.Write([]byte("response"))
+		})
+
+		var err error
+		request, err = http.NewRequest("PUT", "http://example.com/api/v1/teams/some-team/pipelines/some-pipeline/pause", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		request.URL.RawQuery = url.Values{
+			":team_name":     []string{"some-team"},
+			":pipeline_name": []string{"some-pipeline"},
+			"webhook_token":  []string{"some-secret"},
+		}.Encode()
+
+		response = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		factory := audit.NewHandlerFactory(logger, fakeAuditDB)
+		handler = auth.WrapHandler(
+			factory.HandlerFor(atc.PausePipeline, innerHandler),
+			fakeValidator,
+			fakeUserContextReader,
+		)
+
+		handler.ServeHTTP(response, request)
+	})
+
+	It("serves the wrapped handler", func() {
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Body.String()).To(Equal("response"))
+	})
+
+	Context("when the request is made by a team", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns("some-team", 42, false, true)
+			fakeUserContextReader.GetRoleReturns(atc.TeamRoleOperator, true)
+		})
+
+		It("records the actor, route, target and status", func() {
+			Expect(fakeAuditDB.CreateAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditDB.CreateAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				TeamName:      "some-team",
+				Route:         atc.PausePipeline,
+				Target:        map[string]string{"pipeline_name": "some-pipeline"},
+				Status:        http.StatusOK,
+				ActorTeamName: "some-team",
+				ActorRole:     atc.TeamRoleOperator,
+			}))
+		})
+
+		Context("when the token names the user", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetUserReturns("some-user", true)
+			})
+
+			It("records the user", func() {
+				Expect(fakeAuditDB.CreateAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditDB.CreateAuditEventArgsForCall(0).ActorUser).To(Equal("some-user"))
+			})
+		})
+
+		Context("when the handler rejects the request", func() {
+			BeforeEach(func() {
+				innerStatus = http.StatusForbidden
+			})
+
+			It("records the rejection", func() {
+				Expect(response.Code).To(Equal(http.StatusForbidden))
+
+				Expect(fakeAuditDB.CreateAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditDB.CreateAuditEventArgsForCall(0).Status).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when the route does not name a team", func() {
+			BeforeEach(func() {
+				request.URL.RawQuery = url.Values{
+					":build_id": []string{"128"},
+				}.Encode()
+			})
+
+			It("records the event against the actor's team", func() {
+				Expect(fakeAuditDB.CreateAuditEventCallCount()).To(Equal(1))
+
+				event := fakeAuditDB.CreateAuditEventArgsForCall(0)
+				Expect(event.TeamName).To(Equal("some-team"))
+				Expect(event.Target).To(Equal(map[string]string{"build_id": "128"}))
+			})
+		})
+
+		Context("when recording the event fails", func() {
+			BeforeEach(func() {
+				fakeAuditDB.CreateAuditEventReturns(errors.New("nope"))
+			})
+
+			It("still serves the response", func() {
+				Expect(response.Code).To(Equal(http.StatusOK))
+				Expect(response.Body.String()).To(Equal("response"))
+			})
+
+			It("logs the failure", func() {
+				Expect(logger.LogMessages()).To(ContainElement("test.failed-to-record-audit-event"))
+			})
+		})
+	})
+
+	Context("when the request is made by the system", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetSystemReturns(true, true)
+		})
+
+		It("records the event as a system event", func() {
+			Expect(fakeAuditDB.CreateAuditEventCallCount()).To(Equal(1))
+
+			event := fakeAuditDB.CreateAuditEventArgsForCall(0)
+			Expect(event.ActorIsSystem).To(BeTrue())
+			Expect(event.ActorTeamName).To(BeEmpty())
+			Expect(event.TeamName).To(Equal("some-team"))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package atc
+
+type AuditEvent struct {
+	ID       int    `json:"id"`
+	TeamName string `json:"team_name"`
+
+	Route  string            `json:"route"`
+	Target map[string]string `json:"target,omitempty"`
+	Status int               `json:"status"`
+
+	ActorTeamName string   `json:"actor_team_name,omitempty"`
+	ActorUser     string   `json:"actor_user,omitempty"`
+	ActorRole     TeamRole `json:"actor_role,omitempty"`
+	ActorIsSystem bool     `json:"actor_is_system,omitempty"`
+
+	CreatedAt int64 `json:"created_at"`
+}
@@ -5,17 +5,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
 type FakeTokenGenerator struct {
-	GenerateTokenStub        func(expiration time.Time, teamName string, teamID int, isAdmin bool) (auth.TokenType, auth.TokenValue, error)
+	GenerateTokenStub        func(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (auth.TokenType, auth.TokenValue, error)
 	generateTokenMutex       sync.RWMutex
 	generateTokenArgsForCall []struct {
 		expiration time.Time
 		teamName   string
 		teamID     int
 		isAdmin    bool
+		role       atc.TeamRole
+		user       string
 	}
 	generateTokenReturns struct {
 		result1 auth.TokenType
@@ -26,18 +29,20 @@ type FakeTokenGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool) (auth.TokenType, auth.TokenValue, error) {
+func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, teamID int, isAdmin bool, role atc.TeamRole, user string) (auth.TokenType, auth.TokenValue, error) {
 	fake.generateTokenMutex.Lock()
 	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
 		expiration time.Time
 		teamName   string
 		teamID     int
 		isAdmin    bool
-	}{expiration, teamName, teamID, isAdmin})
-	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, teamID, isAdmin})
+		role       atc.TeamRole
+		user       string
+	}{expiration, teamName, teamID, isAdmin, role, user})
+	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, teamID, isAdmin, role, user})
 	fake.generateTokenMutex.Unlock()
 	if fake.GenerateTokenStub != nil {
-		return fake.GenerateTokenStub(expiration, teamName, teamID, isAdmin)
+		return fake.GenerateTokenStub(expiration, teamName, teamID, isAdmin, role, user)
 	} else {
 		return fake.generateTokenReturns.result1, fake.generateTokenReturns.result2, fake.generateTokenReturns.result3
 	}
@@ -49,10 +54,10 @@ func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
 	return len(fake.generateTokenArgsForCall)
 }
 
-func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, int, bool) {
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, int, bool, atc.TeamRole, string) {
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
-	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].teamID, fake.generateTokenArgsForCall[i].isAdmin
+	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].teamID, fake.generateTokenArgsForCall[i].isAdmin, fake.generateTokenArgsForCall[i].role, fake.generateTokenArgsForCall[i].user
 }
 
 func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 )
 
@@ -29,6 +30,24 @@ type FakeUserContextReader struct {
 		result1 bool
 		result2 bool
 	}
+	GetRoleStub        func(r *http.Request) (atc.TeamRole, bool)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		r *http.Request
+	}
+	getRoleReturns struct {
+		result1 atc.TeamRole
+		result2 bool
+	}
+	GetUserStub        func(r *http.Request) (string, bool)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		r *http.Request
+	}
+	getUserReturns struct {
+		result1 string
+		result2 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -103,6 +122,74 @@ func (fake *FakeUserContextReader) GetSystemReturns(result1 bool, result2 bool)
 	}{result1, result2}
 }
 
+func (fake *FakeUserContextReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	fake.getRoleMutex.Lock()
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetRole", []interface{}{r})
+	fake.getRoleMutex.Unlock()
+	if fake.GetRoleStub != nil {
+		return fake.GetRoleStub(r)
+	} else {
+		return fake.getRoleReturns.result1, fake.getRoleReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetRoleArgsForCall(i int) *http.Request {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return fake.getRoleArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetRoleReturns(result1 atc.TeamRole, result2 bool) {
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 atc.TeamRole
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeUserContextReader) GetUser(r *http.Request) (string, bool) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		r *http.Request
+	}{r})
+	fake.recordInvocation("GetUser", []interface{}{r})
+	fake.getUserMutex.Unlock()
+	if fake.GetUserStub != nil {
+		return fake.GetUserStub(r)
+	} else {
+		return fake.getUserReturns.result1, fake.getUserReturns.result2
+	}
+}
+
+func (fake *FakeUserContextReader) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeUserContextReader) GetUserArgsForCall(i int) *http.Request {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return fake.getUserArgsForCall[i].r
+}
+
+func (fake *FakeUserContextReader) GetUserReturns(result1 string, result2 bool) {
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -110,6 +197,10 @@ func (fake *FakeUserContextReader) Invocations() map[string][][]interface{} {
 	defer fake.getTeamMutex.RUnlock()
 	fake.getSystemMutex.RLock()
 	defer fake.getSystemMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
 	return fake.invocations
 }
 
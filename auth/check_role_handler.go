@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+type checkRoleHandler struct {
+	handler  http.Handler
+	role     atc.TeamRole
+	rejector Rejector
+}
+
+// CheckRoleHandler forbids requests made on behalf of a team member whose
+// role does not include the given role. It does not check authentication, so
+// it should be wrapped by a handler which does.
+func CheckRoleHandler(
+	handler http.Handler,
+	role atc.TeamRole,
+	rejector Rejector,
+) http.Handler {
+	return checkRoleHandler{
+		handler:  handler,
+		role:     role,
+		rejector: rejector,
+	}
+}
+
+func (h checkRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authTeam, authTeamFound := GetTeam(r)
+	if authTeamFound && !authTeam.Role().Includes(h.role) {
+		h.rejector.Forbidden(w, r)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,141 @@
+package auth_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/authfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRoleHandler", func() {
+	var (
+		fakeValidator         *authfakes.FakeValidator
+		fakeUserContextReader *authfakes.FakeUserContextReader
+		fakeRejector          *authfakes.FakeRejector
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	simpleHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := bytes.NewBufferString("simple ")
+
+		io.Copy(w, buffer)
+		io.Copy(w, r.Body)
+	})
+
+	BeforeEach(func() {
+		fakeValidator = new(authfakes.FakeValidator)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+		fakeRejector = new(authfakes.FakeRejector)
+
+		fakeRejector.ForbiddenStub = func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "still nope", http.StatusForbidden)
+		}
+
+		server = httptest.NewServer(auth.WrapHandler(
+			auth.CheckRoleHandler(
+				simpleHandler,
+				atc.TeamRoleOperator,
+				fakeRejector,
+			),
+			fakeValidator,
+			fakeUserContextReader,
+		))
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+		}
+	})
+
+	Context("when a request is made", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("GET", server.URL, bytes.NewBufferString("hello"))
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeValidator.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the request is made on behalf of a team", func() {
+			BeforeEach(func() {
+				fakeUserContextReader.GetTeamReturns("some-team", 42, false, true)
+			})
+
+			Context("when the role includes the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.TeamRoleMember, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(responseBody)).To(Equal("simple hello"))
+				})
+			})
+
+			Context("when the role is exactly the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.TeamRoleOperator, true)
+				})
+
+				It("proxies to the handler", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the role does not include the required role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns(atc.TeamRoleViewer, true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the token carries an unknown role", func() {
+				BeforeEach(func() {
+					fakeUserContextReader.GetRoleReturns("bogus", true)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the token carries no role", func() {
+				It("treats the request as coming from an owner", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when the request is not made on behalf of a team", func() {
+			It("proxies to the handler", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+})
@@ -1,18 +1,24 @@
 package auth
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/concourse/atc"
+)
 
 type Team interface {
 	Name() string
 	ID() int
 	IsAdmin() bool
 	IsAuthorized(teamName string) bool
+	Role() atc.TeamRole
 }
 
 type team struct {
 	name    string
 	teamID  int
 	isAdmin bool
+	role    atc.TeamRole
 }
 
 func (t *team) Name() string {
@@ -31,6 +37,10 @@ func (t *team) IsAuthorized(teamName string) bool {
 	return t.name == teamName
 }
 
+func (t *team) Role() atc.TeamRole {
+	return t.role
+}
+
 func GetTeam(r *http.Request) (Team, bool) {
 	teamName, namePresent := r.Context().Value(teamNameKey).(string)
 	teamID, teamIDPresent := r.Context().Value(teamIDKey).(int)
@@ -40,9 +50,17 @@ func GetTeam(r *http.Request) (Team, bool) {
 		return nil, false
 	}
 
+	// tokens issued before roles were introduced carry no role, and granted
+	// full access to the team
+	role, rolePresent := r.Context().Value(teamRoleKey).(atc.TeamRole)
+	if !rolePresent {
+		role = atc.TeamRoleOwner
+	}
+
 	return &team{
 		name:    teamName,
 		teamID:  teamID,
 		isAdmin: isAdmin,
+		role:    role,
 	}, true
 }
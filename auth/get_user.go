@@ -0,0 +1,12 @@
+package auth
+
+import "net/http"
+
+// GetUser returns the name of the user on whose behalf the request was made,
+// as reported by their auth provider. Tokens issued before users were
+// recorded, and tokens for providers which cannot identify their users, carry
+// no user.
+func GetUser(r *http.Request) (string, bool) {
+	user, present := r.Context().Value(userKey).(string)
+	return user, present
+}
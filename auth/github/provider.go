@@ -47,6 +47,7 @@ func NewProvider(
 	}
 
 	return gitHubProvider{
+		client: client,
 		Verifier: verifier.NewVerifierBasket(
 			NewTeamVerifier(dbTeamsToGitHubTeams(gitHubAuth.Teams), client),
 			NewOrganizationVerifier(gitHubAuth.Organizations, client),
@@ -70,6 +71,8 @@ type gitHubProvider struct {
 	// Client(context.Context, *oauth2.Token) *http.Client
 
 	verifier.Verifier
+
+	client Client
 }
 
 func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
@@ -83,6 +86,16 @@ func dbTeamsToGitHubTeams(dbteams []db.GitHubTeam) []Team {
 	return teams
 }
 
+func (p gitHubProvider) Identify(logger lager.Logger, httpClient *http.Client) (string, error) {
+	currentUser, err := p.client.CurrentUser(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-current-user", err)
+		return "", err
+	}
+
+	return currentUser, nil
+}
+
 func (gitHubProvider) PreTokenClient() (*http.Client, error) {
 	return &http.Client{
 		Transport: &http.Transport{
@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"net/http"
 
+	"github.com/concourse/atc"
 	jwt "github.com/dgrijalva/jwt-go"
 )
 
@@ -33,6 +34,46 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, int, bool, bool) {
 	return teamName, teamID, isAdmin, true
 }
 
+func (jr JWTReader) GetRole(r *http.Request) (atc.TeamRole, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	roleInterface, roleOK := claims[teamRoleClaimKey]
+	if !roleOK {
+		return "", false
+	}
+
+	role, isString := roleInterface.(string)
+	if !isString {
+		return "", false
+	}
+
+	return atc.TeamRole(role), true
+}
+
+func (jr JWTReader) GetUser(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userInterface, userOK := claims[userClaimKey]
+	if !userOK {
+		return "", false
+	}
+
+	user, isString := userInterface.(string)
+	if !isString || user == "" {
+		return "", false
+	}
+
+	return user, true
+}
+
 func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 	token, err := getJWT(r, jr.PublicKey)
 	if err != nil {
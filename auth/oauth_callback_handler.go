@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/db"
 
 	"golang.org/x/net/context"
@@ -145,7 +147,7 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	httpClient := provider.Client(ctx, token)
 
-	verified, err := provider.Verify(hLog.Session("verify"), httpClient)
+	role, verified, err := handler.verifyRole(hLog.Session("verify"), team, providerName, provider, httpClient)
 	if err != nil {
 		hLog.Error("failed-to-verify-token", err)
 		http.Error(w, "failed to verify token", http.StatusInternalServerError)
@@ -158,9 +160,18 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	user, err := identifyUser(hLog.Session("identify"), provider, httpClient)
+	if err != nil {
+		hLog.Error("failed-to-identify-user", err)
+		http.Error(w, "failed to identify user", http.StatusInternalServerError)
+		return
+	}
+
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, team.Admin)
+	isAdmin := team.Admin && role.Includes(atc.TeamRoleOwner)
+
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.ID, isAdmin, role, user)
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
@@ -196,3 +207,78 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		http.Redirect(w, r, fmt.Sprintf("http://127.0.0.1:%s/oauth/callback?token=%s", oauthState.FlyLocalPort, encodedToken), http.StatusTemporaryRedirect)
 	}
 }
+
+// identifyUser names the user on whose behalf the client acts. Users of
+// providers which cannot identify them remain anonymous.
+func identifyUser(
+	logger lager.Logger,
+	teamProvider provider.Provider,
+	client *http.Client,
+) (string, error) {
+	identifier, ok := teamProvider.(provider.Identifier)
+	if !ok {
+		return "", nil
+	}
+
+	return identifier.Identify(logger, client)
+}
+
+var rolesByPrivilege = []atc.TeamRole{
+	atc.TeamRoleOwner,
+	atc.TeamRoleMember,
+	atc.TeamRoleOperator,
+	atc.TeamRoleViewer,
+}
+
+// verifyRole determines the role of the user on whose behalf the client acts.
+// Users admitted by the team's own auth configuration are owners; anyone else
+// gets the most privileged role whose binding admits them.
+func (handler *OAuthCallbackHandler) verifyRole(
+	logger lager.Logger,
+	team db.SavedTeam,
+	providerName string,
+	teamProvider provider.Provider,
+	client *http.Client,
+) (atc.TeamRole, bool, error) {
+	verified, err := teamProvider.Verify(logger, client)
+	if err != nil {
+		return "", false, err
+	}
+
+	if verified {
+		return atc.TeamRoleOwner, true, nil
+	}
+
+	for _, role := range rolesByPrivilege {
+		for _, binding := range team.Roles {
+			if binding.Role != role {
+				continue
+			}
+
+			boundTeam := db.SavedTeam{
+				ID:   team.ID,
+				Team: team.ForRoleBinding(binding),
+			}
+
+			bindingProvider, found, err := handler.providerFactory.GetProvider(boundTeam, providerName)
+			if err != nil {
+				return "", false, err
+			}
+
+			if !found {
+				continue
+			}
+
+			verified, err := bindingProvider.Verify(logger.Session("role", lager.Data{"role": role}), client)
+			if err != nil {
+				return "", false, err
+			}
+
+			if verified {
+				return role, true, nil
+			}
+		}
+	}
+
+	return "", false, nil
+}
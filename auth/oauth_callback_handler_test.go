@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/auth/provider"
@@ -279,6 +280,68 @@ var _ = Describe("OAuthCallbackHandler", func() {
 								Expect(claims["teamID"]).To(BeNumerically("==", team.ID))
 								Expect(token.Valid).To(BeTrue())
 							})
+
+							It("grants the owner role", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["teamRole"]).To(Equal("owner"))
+							})
+
+							It("names no user, as the provider cannot identify them", func() {
+								token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+								Expect(err).ToNot(HaveOccurred())
+
+								claims := token.Claims.(jwt.MapClaims)
+								Expect(claims["user"]).To(Equal(""))
+							})
+						})
+
+						Context("when the provider can identify its users", func() {
+							var fakeIdentifier *providerfakes.FakeIdentifier
+
+							BeforeEach(func() {
+								fakeIdentifier = new(providerfakes.FakeIdentifier)
+
+								fakeProviderFactory.GetProviderStub = func(team db.SavedTeam, providerName string) (provider.Provider, bool, error) {
+									return struct {
+										*providerfakes.FakeProvider
+										*providerfakes.FakeIdentifier
+									}{fakeProvider, fakeIdentifier}, true, nil
+								}
+							})
+
+							Context("when identifying the user succeeds", func() {
+								BeforeEach(func() {
+									fakeIdentifier.IdentifyReturns("some-user", nil)
+								})
+
+								It("names the user in the token", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									Expect(fakeIdentifier.IdentifyCallCount()).To(Equal(1))
+									_, identifyClient := fakeIdentifier.IdentifyArgsForCall(0)
+									Expect(identifyClient).To(Equal(httpClient))
+
+									cookie := client.Jar.Cookies(request.URL)[0]
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["user"]).To(Equal("some-user"))
+								})
+							})
+
+							Context("when identifying the user fails", func() {
+								BeforeEach(func() {
+									fakeIdentifier.IdentifyReturns("", errors.New("nope"))
+								})
+
+								It("returns Internal Server Error", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
 						})
 
 						It("does not redirect", func() {
@@ -311,6 +374,82 @@ var _ = Describe("OAuthCallbackHandler", func() {
 						It("does not set a cookie", func() {
 							Expect(response.Cookies()).To(BeEmpty())
 						})
+
+						Context("when the team grants roles to other identities", func() {
+							var fakeViewerProvider *providerfakes.FakeProvider
+							var fakeOperatorProvider *providerfakes.FakeProvider
+
+							BeforeEach(func() {
+								fakeViewerProvider = new(providerfakes.FakeProvider)
+								fakeOperatorProvider = new(providerfakes.FakeProvider)
+
+								team.GitHubAuth = &db.GitHubAuth{
+									ClientID:     "some-client-id",
+									ClientSecret: "some-client-secret",
+									Users:        []string{"some-owner"},
+								}
+								team.Roles = []atc.TeamRoleBinding{
+									{Role: atc.TeamRoleViewer, GitHubUsers: []string{"some-viewer"}},
+									{Role: atc.TeamRoleOperator, GitHubUsers: []string{"some-operator"}},
+								}
+								fakeTeamDB.GetTeamReturns(team, true, nil)
+
+								fakeProviderFactory.GetProviderStub = func(team db.SavedTeam, providerName string) (provider.Provider, bool, error) {
+									if team.GitHubAuth == nil {
+										return nil, false, nil
+									}
+
+									switch team.GitHubAuth.Users[0] {
+									case "some-viewer":
+										return fakeViewerProvider, true, nil
+									case "some-operator":
+										return fakeOperatorProvider, true, nil
+									default:
+										return fakeProvider, true, nil
+									}
+								}
+							})
+
+							Context("when a binding admits the user", func() {
+								BeforeEach(func() {
+									fakeViewerProvider.VerifyReturns(true, nil)
+									fakeOperatorProvider.VerifyReturns(true, nil)
+								})
+
+								It("sets a token with the most privileged role admitting the user", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+									cookie := client.Jar.Cookies(request.URL)[0]
+									token, err := jwt.Parse(strings.Replace(cookie.Value, "Bearer ", "", -1), keyFunc)
+									Expect(err).ToNot(HaveOccurred())
+
+									claims := token.Claims.(jwt.MapClaims)
+									Expect(claims["teamRole"]).To(Equal("operator"))
+									Expect(claims["isAdmin"]).To(BeFalse())
+								})
+
+								It("verifies the binding with the same client", func() {
+									_, client := fakeOperatorProvider.VerifyArgsForCall(0)
+									Expect(client).To(Equal(httpClient))
+								})
+							})
+
+							Context("when no binding admits the user", func() {
+								It("returns Unauthorized", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+								})
+							})
+
+							Context("when a binding's verification fails", func() {
+								BeforeEach(func() {
+									fakeOperatorProvider.VerifyReturns(false, errors.New("nope"))
+								})
+
+								It("returns Internal Server Error", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
 					})
 
 					Context("when the token cannot be verified", func() {
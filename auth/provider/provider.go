@@ -29,3 +29,11 @@ type OAuthClient interface {
 type Verifier interface {
 	Verify(lager.Logger, *http.Client) (bool, error)
 }
+
+//go:generate counterfeiter . Identifier
+
+// Identifier is implemented by providers which can name the user on whose
+// behalf a client acts.
+type Identifier interface {
+	Identify(lager.Logger, *http.Client) (string, error)
+}
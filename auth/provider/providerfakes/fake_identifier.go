@@ -0,0 +1,82 @@
+// This file was generated by counterfeiter
+package providerfakes
+
+import (
+	"net/http"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/auth/provider"
+)
+
+type FakeIdentifier struct {
+	IdentifyStub        func(lager.Logger, *http.Client) (string, error)
+	identifyMutex       sync.RWMutex
+	identifyArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	identifyReturns struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeIdentifier) Identify(arg1 lager.Logger, arg2 *http.Client) (string, error) {
+	fake.identifyMutex.Lock()
+	fake.identifyArgsForCall = append(fake.identifyArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.recordInvocation("Identify", []interface{}{arg1, arg2})
+	fake.identifyMutex.Unlock()
+	if fake.IdentifyStub != nil {
+		return fake.IdentifyStub(arg1, arg2)
+	} else {
+		return fake.identifyReturns.result1, fake.identifyReturns.result2
+	}
+}
+
+func (fake *FakeIdentifier) IdentifyCallCount() int {
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
+	return len(fake.identifyArgsForCall)
+}
+
+func (fake *FakeIdentifier) IdentifyArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
+	return fake.identifyArgsForCall[i].arg1, fake.identifyArgsForCall[i].arg2
+}
+
+func (fake *FakeIdentifier) IdentifyReturns(result1 string, result2 error) {
+	fake.IdentifyStub = nil
+	fake.identifyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIdentifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.identifyMutex.RLock()
+	defer fake.identifyMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeIdentifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ provider.Identifier = new(FakeIdentifier)
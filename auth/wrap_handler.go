@@ -9,6 +9,8 @@ var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var teamIDKey = "teamID"
 var isAdminKey = "isAdmin"
+var teamRoleKey = "teamRole"
+var userKey = "user"
 var isSystemKey = "system"
 
 func WrapHandler(
@@ -38,6 +40,16 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	role, found := h.userContextReader.GetRole(r)
+	if found {
+		ctx = context.WithValue(ctx, teamRoleKey, role)
+	}
+
+	user, found := h.userContextReader.GetUser(r)
+	if found {
+		ctx = context.WithValue(ctx, userKey, user)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
@@ -23,6 +23,9 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+
+	ManualOverride bool `json:"manual_override,omitempty"`
+	RerunOf        int  `json:"rerun_of,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -14,9 +15,21 @@ const DefaultTeamName = "main"
 type Tags []string
 
 type ConfigResponse struct {
-	Config    *Config   `json:"config"`
-	Errors    []string  `json:"errors"`
-	RawConfig RawConfig `json:"raw_config"`
+	Config         *Config   `json:"config"`
+	Errors         []string  `json:"errors"`
+	RawConfig      RawConfig `json:"raw_config"`
+	Template       string    `json:"template,omitempty"`
+	UnsetVariables []string  `json:"unset_variables,omitempty"`
+}
+
+// ConfigVersion describes a config that was saved for a pipeline. The config
+// itself is only included when a single version is requested.
+type ConfigVersion struct {
+	Version  int     `json:"version"`
+	SavedBy  string  `json:"saved_by,omitempty"`
+	SavedAt  int64   `json:"saved_at"`
+	Config   *Config `json:"config,omitempty"`
+	Template string  `json:"template,omitempty"`
 }
 
 type Config struct {
@@ -56,6 +69,8 @@ type ResourceConfig struct {
 	Type       string `yaml:"type" json:"type" mapstructure:"type"`
 	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type ResourceType struct {
@@ -103,6 +118,10 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+
+	Schedule *ScheduleConfig `yaml:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
@@ -110,6 +129,51 @@ type JobConfig struct {
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 }
 
+// BuildLogRetention limits how long the events of a job's builds are kept
+// around. When both Builds and Days are set, a build's logs are reaped as soon
+// as either of them says so, unless the build is one of the MinimumBuilds
+// most recent ones.
+type BuildLogRetention struct {
+	Builds        int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days          int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
+	MinimumBuilds int `yaml:"minimum_builds,omitempty" json:"minimum_builds,omitempty" mapstructure:"minimum_builds"`
+}
+
+func (retention BuildLogRetention) Limited() bool {
+	return retention.Builds > 0 || retention.Days > 0
+}
+
+// ScheduleConfig triggers a job's builds at the times matched by a cron
+// expression, evaluated in Timezone (UTC if empty).
+type ScheduleConfig struct {
+	Cron     string `yaml:"cron" json:"cron" mapstructure:"cron"`
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty" mapstructure:"timezone"`
+}
+
+// Location returns the time zone the schedule is evaluated in.
+func (config ScheduleConfig) Location() (*time.Location, error) {
+	if config.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(config.Timezone)
+}
+
+// LogRetention returns the job's build log retention policy, falling back to
+// build_logs_to_retain. The second return value is false if the job does not
+// configure any retention at all.
+func (config JobConfig) LogRetention() (BuildLogRetention, bool) {
+	if config.BuildLogRetention != nil {
+		return *config.BuildLogRetention, true
+	}
+
+	if config.BuildLogsToRetain != 0 {
+		return BuildLogRetention{Builds: config.BuildLogsToRetain}, true
+	}
+
+	return BuildLogRetention{}, false
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
@@ -256,6 +320,10 @@ type PlanConfig struct {
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan, running the steps in parallel with
+	// bounded concurrency
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -280,6 +348,10 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a Gate plan
+	// name of 'gate', e.g. approve-deploy
+	Gate string `yaml:"gate,omitempty" json:"gate,omitempty" mapstructure:"gate"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -317,9 +389,47 @@ type PlanConfig struct {
 	// repeat the step up to N times, until it works
 	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
+	// used with attempts to wait between attempts, backing off exponentially
+	Backoff *RetryBackoff `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+
+	// used with attempts to only retry some failures of the step
+	RetryOn *RetryConditions `yaml:"retry_on,omitempty" json:"retry_on,omitempty" mapstructure:"retry_on"`
+
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
+// GateDecision is the body of a request approving or rejecting a build
+// waiting at a gate.
+type GateDecision struct {
+	// required, so that a request which forgets it does not reject the build
+	Approved *bool `json:"approved"`
+}
+
+type RetryBackoff struct {
+	// how long to wait before the second attempt; required
+	Initial string `yaml:"initial,omitempty" json:"initial,omitempty" mapstructure:"initial"`
+	// how much longer to wait before each following attempt; defaults to 2
+	Multiplier float64 `yaml:"multiplier,omitempty" json:"multiplier,omitempty" mapstructure:"multiplier"`
+	// the longest to wait before any attempt
+	Max string `yaml:"max,omitempty" json:"max,omitempty" mapstructure:"max"`
+}
+
+type RetryConditions struct {
+	// retry when the step errors, e.g. when a worker could not be reached
+	Errors bool `yaml:"errors,omitempty" json:"errors,omitempty" mapstructure:"errors"`
+	// retry when a task exits with one of these statuses
+	ExitStatuses []int `yaml:"exit_statuses,omitempty" json:"exit_statuses,omitempty" mapstructure:"exit_statuses"`
+}
+
+type InParallelConfig struct {
+	// the steps to run in parallel
+	Steps PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+	// the maximum number of steps to run at once; unlimited if zero
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	// interrupt the remaining steps once one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
 func (config PlanConfig) Name() string {
 	if config.RawName != "" {
 		return config.RawName
@@ -337,6 +447,10 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.Gate != "" {
+		return config.Gate
+	}
+
 	return ""
 }
 
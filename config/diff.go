@@ -0,0 +1,226 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/concourse/atc"
+)
+
+type namedEntity struct {
+	name  string
+	value interface{}
+}
+
+// Diff compares two configs, reporting the groups, resources, resource types
+// and jobs that were added, removed or changed going from old to new. Fields
+// of changed entities are compared through their JSON form, so that the paths
+// reported match the keys used in pipeline configs.
+func Diff(old atc.Config, new atc.Config) (atc.ConfigDiff, error) {
+	var diff atc.ConfigDiff
+	var err error
+
+	diff.Groups, err = diffEntities(groupEntities(old.Groups), groupEntities(new.Groups))
+	if err != nil {
+		return atc.ConfigDiff{}, err
+	}
+
+	diff.Resources, err = diffEntities(resourceEntities(old.Resources), resourceEntities(new.Resources))
+	if err != nil {
+		return atc.ConfigDiff{}, err
+	}
+
+	diff.ResourceTypes, err = diffEntities(resourceTypeEntities(old.ResourceTypes), resourceTypeEntities(new.ResourceTypes))
+	if err != nil {
+		return atc.ConfigDiff{}, err
+	}
+
+	diff.Jobs, err = diffEntities(jobEntities(old.Jobs), jobEntities(new.Jobs))
+	if err != nil {
+		return atc.ConfigDiff{}, err
+	}
+
+	return diff, nil
+}
+
+func groupEntities(groups atc.GroupConfigs) []namedEntity {
+	entities := make([]namedEntity, len(groups))
+	for i, group := range groups {
+		entities[i] = namedEntity{name: group.Name, value: group}
+	}
+
+	return entities
+}
+
+func resourceEntities(resources atc.ResourceConfigs) []namedEntity {
+	entities := make([]namedEntity, len(resources))
+	for i, resource := range resources {
+		entities[i] = namedEntity{name: resource.Name, value: resource}
+	}
+
+	return entities
+}
+
+func resourceTypeEntities(resourceTypes atc.ResourceTypes) []namedEntity {
+	entities := make([]namedEntity, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		entities[i] = namedEntity{name: resourceType.Name, value: resourceType}
+	}
+
+	return entities
+}
+
+func jobEntities(jobs atc.JobConfigs) []namedEntity {
+	entities := make([]namedEntity, len(jobs))
+	for i, job := range jobs {
+		entities[i] = namedEntity{name: job.Name, value: job}
+	}
+
+	return entities
+}
+
+func diffEntities(old []namedEntity, new []namedEntity) ([]atc.EntityDiff, error) {
+	diffs := []atc.EntityDiff{}
+
+	oldByName := map[string]interface{}{}
+	for _, entity := range old {
+		oldByName[entity.name] = entity.value
+	}
+
+	newByName := map[string]interface{}{}
+	for _, entity := range new {
+		newByName[entity.name] = entity.value
+
+		oldValue, found := oldByName[entity.name]
+		if !found {
+			diffs = append(diffs, atc.EntityDiff{
+				Name:   entity.name,
+				Action: atc.DiffActionAdded,
+			})
+
+			continue
+		}
+
+		oldFields, err := jsonValue(oldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		newFields, err := jsonValue(entity.value)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := diffValues("", oldFields, newFields)
+		if len(fields) > 0 {
+			diffs = append(diffs, atc.EntityDiff{
+				Name:   entity.name,
+				Action: atc.DiffActionChanged,
+				Fields: fields,
+			})
+		}
+	}
+
+	for _, entity := range old {
+		if _, found := newByName[entity.name]; !found {
+			diffs = append(diffs, atc.EntityDiff{
+				Name:   entity.name,
+				Action: atc.DiffActionRemoved,
+			})
+		}
+	}
+
+	if len(diffs) == 0 {
+		return nil, nil
+	}
+
+	return diffs, nil
+}
+
+func jsonValue(value interface{}) (interface{}, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(payload, &generic)
+	if err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+func diffValues(path string, old interface{}, new interface{}) []atc.FieldDiff {
+	switch oldValue := old.(type) {
+	case map[string]interface{}:
+		newValue, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for key := range oldValue {
+			keys = append(keys, key)
+		}
+
+		for key := range newValue {
+			if _, found := oldValue[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		fields := []atc.FieldDiff{}
+		for _, key := range keys {
+			fields = append(fields, diffValues(fieldPath(path, key), oldValue[key], newValue[key])...)
+		}
+
+		return fields
+
+	case []interface{}:
+		newValue, ok := new.([]interface{})
+		if !ok {
+			break
+		}
+
+		length := len(oldValue)
+		if len(newValue) > length {
+			length = len(newValue)
+		}
+
+		fields := []atc.FieldDiff{}
+		for i := 0; i < length; i++ {
+			var oldElem, newElem interface{}
+			if i < len(oldValue) {
+				oldElem = oldValue[i]
+			}
+
+			if i < len(newValue) {
+				newElem = newValue[i]
+			}
+
+			fields = append(fields, diffValues(fmt.Sprintf("%s[%d]", path, i), oldElem, newElem)...)
+		}
+
+		return fields
+	}
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+
+	return []atc.FieldDiff{{Path: path, Old: old, New: new}}
+}
+
+func fieldPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,157 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		oldConfig atc.Config
+		newConfig atc.Config
+
+		diff atc.ConfigDiff
+	)
+
+	BeforeEach(func() {
+		oldConfig = atc.Config{
+			Groups: atc.GroupConfigs{
+				{Name: "some-group", Jobs: []string{"some-job"}},
+			},
+
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "some-uri", "branch": "master"},
+				},
+				{
+					Name: "some-other-resource",
+					Type: "time",
+				},
+			},
+
+			ResourceTypes: atc.ResourceTypes{
+				{Name: "some-type", Type: "docker-image", Source: atc.Source{"repository": "some-repo"}},
+			},
+
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource"},
+						{Task: "some-task", TaskConfigPath: "some/path.yml"},
+					},
+				},
+			},
+		}
+
+		newConfig = atc.Config{
+			Groups: oldConfig.Groups,
+
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "some-other-uri", "branch": "master"},
+				},
+				{
+					Name: "some-new-resource",
+					Type: "s3",
+				},
+			},
+
+			ResourceTypes: oldConfig.ResourceTypes,
+
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource", Trigger: true},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		diff, err = Diff(oldConfig, newConfig)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("reports added, removed and changed resources", func() {
+		Expect(diff.Resources).To(Equal([]atc.EntityDiff{
+			{
+				Name:   "some-resource",
+				Action: atc.DiffActionChanged,
+				Fields: []atc.FieldDiff{
+					{Path: "source.uri", Old: "some-uri", New: "some-other-uri"},
+				},
+			},
+			{
+				Name:   "some-new-resource",
+				Action: atc.DiffActionAdded,
+			},
+			{
+				Name:   "some-other-resource",
+				Action: atc.DiffActionRemoved,
+			},
+		}))
+	})
+
+	It("reports changes within lists by index", func() {
+		Expect(diff.Jobs).To(Equal([]atc.EntityDiff{
+			{
+				Name:   "some-job",
+				Action: atc.DiffActionChanged,
+				Fields: []atc.FieldDiff{
+					{Path: "plan[0].trigger", Old: nil, New: true},
+					{
+						Path: "plan[1]",
+						Old:  map[string]interface{}{"task": "some-task", "file": "some/path.yml"},
+						New:  nil,
+					},
+				},
+			},
+		}))
+	})
+
+	It("does not report unchanged entities", func() {
+		Expect(diff.Groups).To(BeEmpty())
+		Expect(diff.ResourceTypes).To(BeEmpty())
+	})
+
+	Context("when the configs are the same", func() {
+		BeforeEach(func() {
+			newConfig = oldConfig
+		})
+
+		It("returns an empty diff", func() {
+			Expect(diff.IsEmpty()).To(BeTrue())
+		})
+	})
+
+	Context("when there is no old config", func() {
+		BeforeEach(func() {
+			oldConfig = atc.Config{}
+		})
+
+		It("reports everything as added", func() {
+			Expect(diff.Groups).To(Equal([]atc.EntityDiff{
+				{Name: "some-group", Action: atc.DiffActionAdded},
+			}))
+
+			Expect(diff.ResourceTypes).To(Equal([]atc.EntityDiff{
+				{Name: "some-type", Action: atc.DiffActionAdded},
+			}))
+
+			Expect(diff.Jobs).To(Equal([]atc.EntityDiff{
+				{Name: "some-job", Action: atc.DiffActionAdded},
+			}))
+		})
+	})
+})
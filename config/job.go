@@ -1,6 +1,10 @@
 package config
 
-import "github.com/concourse/atc"
+import (
+	"strings"
+
+	"github.com/concourse/atc"
+)
 
 // these are expressly tucked away so as to avoid accidental use in public API
 // endpoints as that could leak credentials
@@ -20,6 +24,17 @@ type JobOutput struct {
 	Resource string
 }
 
+// CrossPipelineJob splits a passed constraint of the form "pipeline/job",
+// which refers to a job in another pipeline belonging to the same team.
+func CrossPipelineJob(passed string) (string, string, bool) {
+	segs := strings.SplitN(passed, "/", 2)
+	if len(segs) != 2 || segs[0] == "" || segs[1] == "" {
+		return "", "", false
+	}
+
+	return segs[0], segs[1], true
+}
+
 func JobInputs(config atc.JobConfig) []JobInput {
 	return collectInputs(atc.PlanConfig{
 		Do:      &config.Plan,
@@ -69,6 +84,12 @@ func collectInputs(plan atc.PlanConfig) []JobInput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			inputs = append(inputs, collectInputs(p)...)
+		}
+	}
+
 	if plan.Get != "" {
 		get := plan.Get
 
@@ -122,6 +143,12 @@ func collectOutputs(plan atc.PlanConfig) []JobOutput {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			outputs = append(outputs, collectOutputs(p)...)
+		}
+	}
+
 	if plan.Put != "" {
 		put := plan.Put
 
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc"
+)
+
+// PipelineLookup finds the config of another pipeline belonging to the team.
+type PipelineLookup func(pipelineName string) (atc.Config, bool, error)
+
+// ValidateCrossPipelinePassed warns about passed constraints referring to jobs
+// in other pipelines which don't exist, or which don't interact with a
+// resource of the same name. These can't be caught by Validate, as the other
+// pipelines aren't part of the config, and they are not errors, as the other
+// pipeline may simply not have been configured yet.
+func ValidateCrossPipelinePassed(c atc.Config, lookupPipeline PipelineLookup) ([]Warning, error) {
+	warnings := []Warning{}
+
+	type lookup struct {
+		config atc.Config
+		found  bool
+	}
+
+	pipelines := map[string]lookup{}
+
+	for _, job := range c.Jobs {
+		for _, input := range JobInputs(job) {
+			for _, passed := range input.Passed {
+				if _, found := c.Jobs.Lookup(passed); found {
+					continue
+				}
+
+				pipelineName, jobName, crossPipeline := CrossPipelineJob(passed)
+				if !crossPipeline {
+					continue
+				}
+
+				pipeline, looked := pipelines[pipelineName]
+				if !looked {
+					var err error
+					pipeline.config, pipeline.found, err = lookupPipeline(pipelineName)
+					if err != nil {
+						return nil, err
+					}
+
+					pipelines[pipelineName] = pipeline
+				}
+
+				identifier := fmt.Sprintf("jobs.%s.get.%s", job.Name, input.Name)
+
+				if !pipeline.found {
+					warnings = append(warnings, Warning{
+						Type:    "pipeline",
+						Message: fmt.Sprintf("%s.passed references a job in an unknown pipeline ('%s')", identifier, passed),
+					})
+					continue
+				}
+
+				passedJob, found := pipeline.config.Jobs.Lookup(jobName)
+				if !found {
+					warnings = append(warnings, Warning{
+						Type:    "pipeline",
+						Message: fmt.Sprintf("%s.passed references an unknown job ('%s')", identifier, passed),
+					})
+					continue
+				}
+
+				if !interactsWith(passedJob, input.Resource) {
+					warnings = append(warnings, Warning{
+						Type:    "pipeline",
+						Message: fmt.Sprintf("%s.passed references a job ('%s') which doesn't interact with the resource ('%s')", identifier, passed, input.Resource),
+					})
+				}
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+func interactsWith(job atc.JobConfig, resourceName string) bool {
+	for _, jobInput := range JobInputs(job) {
+		if jobInput.Resource == resourceName {
+			return true
+		}
+	}
+
+	for _, jobOutput := range JobOutputs(job) {
+		if jobOutput.Resource == resourceName {
+			return true
+		}
+	}
+
+	return false
+}
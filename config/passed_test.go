@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateCrossPipelinePassed", func() {
+	var (
+		config    atc.Config
+		pipelines map[string]atc.Config
+		lookupErr error
+
+		lookedUp []string
+
+		warnings    []Warning
+		validateErr error
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{Get: "some-resource"},
+					},
+				},
+				{
+					Name: "downstream-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:    "some-resource",
+							Passed: []string{"some-job", "other-pipeline/other-job"},
+						},
+						{
+							Get:      "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"other-pipeline/other-job"},
+						},
+					},
+				},
+			},
+		}
+
+		pipelines = map[string]atc.Config{
+			"other-pipeline": {
+				Jobs: atc.JobConfigs{
+					{
+						Name: "other-job",
+						Plan: atc.PlanSequence{
+							{Put: "some-resource"},
+						},
+					},
+				},
+			},
+		}
+
+		lookupErr = nil
+		lookedUp = nil
+	})
+
+	JustBeforeEach(func() {
+		warnings, validateErr = ValidateCrossPipelinePassed(config, func(pipelineName string) (atc.Config, bool, error) {
+			lookedUp = append(lookedUp, pipelineName)
+			pipeline, found := pipelines[pipelineName]
+			return pipeline, found, lookupErr
+		})
+	})
+
+	It("returns no warnings when the jobs exist and interact with the resource", func() {
+		Expect(validateErr).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("looks up each pipeline once", func() {
+		Expect(lookedUp).To(Equal([]string{"other-pipeline"}))
+	})
+
+	Context("when the pipeline does not exist", func() {
+		BeforeEach(func() {
+			delete(pipelines, "other-pipeline")
+		})
+
+		It("warns about each reference", func() {
+			Expect(validateErr).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				Warning{
+					Type:    "pipeline",
+					Message: "jobs.downstream-job.get.some-resource.passed references a job in an unknown pipeline ('other-pipeline/other-job')",
+				},
+				Warning{
+					Type:    "pipeline",
+					Message: "jobs.downstream-job.get.some-input.passed references a job in an unknown pipeline ('other-pipeline/other-job')",
+				},
+			))
+		})
+	})
+
+	Context("when the job does not exist", func() {
+		BeforeEach(func() {
+			config.Jobs[1].Plan = atc.PlanSequence{
+				{
+					Get:    "some-resource",
+					Passed: []string{"other-pipeline/bogus-job"},
+				},
+			}
+		})
+
+		It("warns about the reference", func() {
+			Expect(validateErr).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "pipeline",
+				Message: "jobs.downstream-job.get.some-resource.passed references an unknown job ('other-pipeline/bogus-job')",
+			}))
+		})
+	})
+
+	Context("when the job does not interact with the resource", func() {
+		BeforeEach(func() {
+			config.Jobs[1].Plan = atc.PlanSequence{
+				{
+					Get:    "some-other-resource",
+					Passed: []string{"other-pipeline/other-job"},
+				},
+			}
+		})
+
+		It("warns about the reference", func() {
+			Expect(validateErr).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "pipeline",
+				Message: "jobs.downstream-job.get.some-other-resource.passed references a job ('other-pipeline/other-job') which doesn't interact with the resource ('some-other-resource')",
+			}))
+		})
+	})
+
+	Context("when looking up the pipeline fails", func() {
+		BeforeEach(func() {
+			lookupErr = errors.New("nope")
+		})
+
+		It("returns the error", func() {
+			Expect(validateErr).To(MatchError("nope"))
+		})
+	})
+})
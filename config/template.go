@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/concourse/atc/template"
+)
+
+// ValidateTemplateVariables warns about placeholders in a pipeline template
+// which have no value, and about variables which are never referenced.
+func ValidateTemplateVariables(content []byte, variables template.Variables) []Warning {
+	warnings := []Warning{}
+
+	for _, name := range template.Missing(content, variables) {
+		warnings = append(warnings, Warning{
+			Type:    "template",
+			Message: fmt.Sprintf("template references variable '%s' which is not set; it will be interpolated as null", name),
+		})
+	}
+
+	for _, name := range template.Unused(content, variables) {
+		warnings = append(warnings, Warning{
+			Type:    "template",
+			Message: fmt.Sprintf("variable '%s' is not referenced by the template", name),
+		})
+	}
+
+	return warnings
+}
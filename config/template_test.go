@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	. "github.com/concourse/atc/config"
+	"github.com/concourse/atc/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateTemplateVariables", func() {
+	var (
+		content   []byte
+		variables template.Variables
+
+		warnings []Warning
+	)
+
+	BeforeEach(func() {
+		content = []byte(`
+resources:
+- name: some-resource
+  source:
+    uri: {{uri}}
+    branch: {{branch}}
+`)
+		variables = template.Variables{
+			"uri":    "some-uri",
+			"branch": "master",
+		}
+	})
+
+	JustBeforeEach(func() {
+		warnings = ValidateTemplateVariables(content, variables)
+	})
+
+	It("returns no warnings when every variable is set and used", func() {
+		Expect(warnings).To(BeEmpty())
+	})
+
+	Context("when a referenced variable is not set", func() {
+		BeforeEach(func() {
+			delete(variables, "branch")
+		})
+
+		It("warns that it is missing", func() {
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "template",
+				Message: "template references variable 'branch' which is not set; it will be interpolated as null",
+			}))
+		})
+	})
+
+	Context("when a variable is never referenced", func() {
+		BeforeEach(func() {
+			variables["private_key"] = "some-key"
+		})
+
+		It("warns that it is unused", func() {
+			Expect(warnings).To(ConsistOf(Warning{
+				Type:    "template",
+				Message: "variable 'private_key' is not referenced by the template",
+			}))
+		})
+	})
+})
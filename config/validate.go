@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/cron"
 )
 
 func formatErr(groupName string, err error) string {
@@ -218,6 +219,14 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildLogRetention != nil {
+			errorMessages = append(errorMessages, validateBuildLogRetention(identifier, job)...)
+		}
+
+		if job.Schedule != nil {
+			errorMessages = append(errorMessages, validateSchedule(identifier, *job.Schedule)...)
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -226,6 +235,49 @@ func validateJobs(c atc.Config) ([]Warning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateBuildLogRetention(identifier string, job atc.JobConfig) []string {
+	errorMessages := []string{}
+	retention := job.BuildLogRetention
+
+	if job.BuildLogsToRetain != 0 {
+		errorMessages = append(errorMessages, identifier+" specifies both build_logs_to_retain and build_log_retention")
+	}
+
+	if retention.Builds < 0 {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has negative build_log_retention.builds: %d", retention.Builds))
+	}
+
+	if retention.Days < 0 {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has negative build_log_retention.days: %d", retention.Days))
+	}
+
+	if retention.MinimumBuilds < 0 {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has negative build_log_retention.minimum_builds: %d", retention.MinimumBuilds))
+	}
+
+	if retention.Builds > 0 && retention.MinimumBuilds > retention.Builds {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has build_log_retention.minimum_builds (%d) greater than build_log_retention.builds (%d)", retention.MinimumBuilds, retention.Builds))
+	}
+
+	return errorMessages
+}
+
+func validateSchedule(identifier string, schedule atc.ScheduleConfig) []string {
+	errorMessages := []string{}
+
+	if schedule.Cron == "" {
+		errorMessages = append(errorMessages, identifier+" has a schedule with no cron expression")
+	} else if _, err := cron.Parse(schedule.Cron); err != nil {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an invalid schedule.cron '%s': %s", schedule.Cron, err))
+	}
+
+	if _, err := schedule.Location(); err != nil {
+		errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown schedule.timezone: '%s'", schedule.Timezone))
+	}
+
+	return errorMessages
+}
+
 type foundTypes struct {
 	identifier string
 	found      map[string]bool
@@ -273,6 +325,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("task")
 	}
 
+	if plan.Gate != "" {
+		foundTypes.Find("gate")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -281,6 +337,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -309,6 +369,19 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
+		if plan.InParallel.Limit < 0 {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.limit", identifier)
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid limit (%d)", plan.InParallel.Limit))
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
@@ -345,6 +418,12 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
+				// jobs in other pipelines are checked against the team's
+				// pipelines when saving, see ValidateCrossPipelinePassed
+				if _, _, crossPipeline := CrossPipelineJob(job); crossPipeline {
+					continue
+				}
+
 				errorMessages = append(
 					errorMessages,
 					fmt.Sprintf(
@@ -440,6 +519,14 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 			plan, identifier)...,
 		)
 
+	case plan.Gate != "":
+		identifier = fmt.Sprintf("%s.gate.%s", identifier, plan.Gate)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "file"},
+			plan, identifier)...,
+		)
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		planWarnings, planErrMessages := validatePlan(c, subIdentifier, *plan.Try)
@@ -481,6 +568,43 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) ([]Warni
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.Backoff != nil {
+		subIdentifier := fmt.Sprintf("%s.backoff", identifier)
+
+		if plan.Attempts == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" is specified without any attempts")
+		}
+
+		// every wait is a multiple of the initial one, so without it there
+		// would be no backoff at all
+		if plan.Backoff.Initial == "" {
+			errorMessages = append(errorMessages, subIdentifier+".initial must be specified")
+		} else {
+			initial, err := time.ParseDuration(plan.Backoff.Initial)
+			if err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".initial refers to a duration that could not be parsed ('%s')", plan.Backoff.Initial))
+			} else if initial <= 0 {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".initial must be positive ('%s')", plan.Backoff.Initial))
+			}
+		}
+
+		if plan.Backoff.Max != "" {
+			_, err := time.ParseDuration(plan.Backoff.Max)
+			if err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".max refers to a duration that could not be parsed ('%s')", plan.Backoff.Max))
+			}
+		}
+
+		if plan.Backoff.Multiplier != 0 && plan.Backoff.Multiplier < 1 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".multiplier must be at least 1 (%g)", plan.Backoff.Multiplier))
+		}
+	}
+
+	if plan.RetryOn != nil && plan.Attempts == 0 {
+		subIdentifier := fmt.Sprintf("%s.retry_on", identifier)
+		errorMessages = append(errorMessages, subIdentifier+" is specified without any attempts")
+	}
+
 	return warnings, errorMessages
 }
 
@@ -424,6 +424,91 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a negative build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{Days: -1}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has negative build_log_retention.days: -1"))
+			})
+		})
+
+		Context("when a job specifies both build_logs_to_retain and build_log_retention", func() {
+			BeforeEach(func() {
+				job.BuildLogsToRetain = 10
+				job.BuildLogRetention = &atc.BuildLogRetention{Days: 90}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job specifies both build_logs_to_retain and build_log_retention"))
+			})
+		})
+
+		Context("when a job's build_log_retention keeps a minimum of more builds than its maximum", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{Builds: 5, MinimumBuilds: 10}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has build_log_retention.minimum_builds (10) greater than build_log_retention.builds (5)"))
+			})
+		})
+
+		Context("when a job has a valid schedule", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{Cron: "0 2 * * mon-fri", Timezone: "America/New_York"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a job's schedule has an invalid cron expression", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{Cron: "0 25 * * *"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an invalid schedule.cron '0 25 * * *'"))
+			})
+		})
+
+		Context("when a job's schedule has no cron expression", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{Timezone: "UTC"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has a schedule with no cron expression"))
+			})
+		})
+
+		Context("when a job's schedule has an unknown timezone", func() {
+			BeforeEach(func() {
+				job.Schedule = &atc.ScheduleConfig{Cron: "@daily", Timezone: "Mars/Olympus_Mons"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an unknown schedule.timezone: 'Mars/Olympus_Mons'"))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -557,6 +642,24 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a gate plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Gate:       "lol",
+						Resource:   "some-resource",
+						Privileged: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].gate.lol has invalid fields specified (resource, privileged)"))
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -893,6 +996,50 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an in_parallel", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Put:      "custom-name",
+									Resource: "some-missing-resource",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[0].put.custom-name refers to a resource that does not exist ('some-missing-resource')"))
+				})
+			})
+
+			Context("when an in_parallel plan has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Put: "some-resource",
+								},
+							},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit has an invalid limit (-1)"))
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -909,6 +1056,98 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has a backoff", func() {
+				var backoff *atc.RetryBackoff
+
+				BeforeEach(func() {
+					backoff = &atc.RetryBackoff{
+						Initial:    "10s",
+						Multiplier: 2,
+						Max:        "1m",
+					}
+				})
+
+				JustBeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:      "some-resource",
+						Attempts: 3,
+						Backoff:  backoff,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+
+					configWarnings, errorMessages = ValidateConfig(config)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+
+				Context("when its durations cannot be parsed", func() {
+					BeforeEach(func() {
+						backoff.Initial = "nope"
+						backoff.Max = "nah"
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff.initial refers to a duration that could not be parsed ('nope')"))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff.max refers to a duration that could not be parsed ('nah')"))
+					})
+				})
+
+				Context("when it has no initial wait", func() {
+					BeforeEach(func() {
+						backoff.Initial = ""
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff.initial must be specified"))
+					})
+				})
+
+				Context("when its initial wait is zero", func() {
+					BeforeEach(func() {
+						backoff.Initial = "0s"
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff.initial must be positive ('0s')"))
+					})
+				})
+
+				Context("when its multiplier is less than 1", func() {
+					BeforeEach(func() {
+						backoff.Multiplier = 0.5
+					})
+
+					It("returns an error", func() {
+						Expect(errorMessages).To(HaveLen(1))
+						Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff.multiplier must be at least 1 (0.5)"))
+					})
+				})
+			})
+
+			Context("when a plan has a backoff and retry conditions without attempts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:     "some-resource",
+						Backoff: &atc.RetryBackoff{Initial: "1s"},
+						RetryOn: &atc.RetryConditions{Errors: true},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.backoff is specified without any attempts"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.retry_on is specified without any attempts"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -941,6 +1180,37 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"some-other-pipeline/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job in another pipeline without naming the pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"/some-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references an unknown job ('/some-job')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
@@ -0,0 +1,39 @@
+package atc
+
+type DiffAction string
+
+const (
+	DiffActionAdded   DiffAction = "added"
+	DiffActionRemoved DiffAction = "removed"
+	DiffActionChanged DiffAction = "changed"
+)
+
+// ConfigDiff describes what would change were a config to replace the one
+// currently saved for a pipeline. Entities are matched up by name.
+type ConfigDiff struct {
+	Groups        []EntityDiff `json:"groups,omitempty"`
+	Resources     []EntityDiff `json:"resources,omitempty"`
+	ResourceTypes []EntityDiff `json:"resource_types,omitempty"`
+	Jobs          []EntityDiff `json:"jobs,omitempty"`
+}
+
+func (diff ConfigDiff) IsEmpty() bool {
+	return len(diff.Groups) == 0 &&
+		len(diff.Resources) == 0 &&
+		len(diff.ResourceTypes) == 0 &&
+		len(diff.Jobs) == 0
+}
+
+type EntityDiff struct {
+	Name   string      `json:"name"`
+	Action DiffAction  `json:"action"`
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff is a single changed value within an entity. The path is relative
+// to the entity, e.g. "source.uri" or "plan[1].params.version".
+type FieldDiff struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
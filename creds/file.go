@@ -0,0 +1,67 @@
+package creds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fileCredentialManager struct {
+	dir string
+}
+
+// NewFileCredentialManager returns a CredentialManager backed by a directory
+// tree, with one file per secret. Secrets are looked up in
+// <dir>/<team>/<pipeline>/<secret> first, falling back to
+// <dir>/<team>/<secret> so that secrets can be shared by a team's pipelines.
+// One-off builds have no pipeline, so they only see the team's secrets. A
+// single trailing newline is stripped from the file's contents.
+func NewFileCredentialManager(dir string) CredentialManager {
+	return &fileCredentialManager{
+		dir: dir,
+	}
+}
+
+func (manager *fileCredentialManager) Get(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+	segments := []string{teamName, secretName}
+	if pipelineName != "" {
+		segments = append(segments, pipelineName)
+	}
+
+	for _, segment := range segments {
+		if !validPathSegment(segment) {
+			return nil, false, fmt.Errorf("invalid secret path segment: %q", segment)
+		}
+	}
+
+	lookupPaths := []string{}
+	if pipelineName != "" {
+		lookupPaths = append(lookupPaths, filepath.Join(manager.dir, teamName, pipelineName, secretName))
+	}
+
+	lookupPaths = append(lookupPaths, filepath.Join(manager.dir, teamName, secretName))
+
+	for _, path := range lookupPaths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, false, err
+		}
+
+		return strings.TrimSuffix(string(contents), "\n"), true, nil
+	}
+
+	return nil, false, nil
+}
+
+func validPathSegment(segment string) bool {
+	return segment != "" &&
+		segment != "." &&
+		segment != ".." &&
+		!strings.ContainsAny(segment, `/\`)
+}
@@ -0,0 +1,82 @@
+package creds_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileCredentialManager", func() {
+	var (
+		dir     string
+		manager creds.CredentialManager
+	)
+
+	writeSecret := func(contents string, path ...string) {
+		secretPath := filepath.Join(append([]string{dir}, path...)...)
+
+		err := os.MkdirAll(filepath.Dir(secretPath), 0755)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(secretPath, []byte(contents), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "creds")
+		Expect(err).NotTo(HaveOccurred())
+
+		manager = creds.NewFileCredentialManager(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("prefers secrets scoped to the pipeline", func() {
+		writeSecret("team-value\n", "some-team", "password")
+		writeSecret("pipeline-value\n", "some-team", "some-pipeline", "password")
+
+		value, found, err := manager.Get("some-team", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("pipeline-value"))
+	})
+
+	It("falls back to secrets scoped to the team", func() {
+		writeSecret("team-value\n", "some-team", "password")
+
+		value, found, err := manager.Get("some-team", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-value"))
+	})
+
+	It("only looks up secrets scoped to the team when there is no pipeline", func() {
+		writeSecret("team-value\n", "some-team", "password")
+
+		value, found, err := manager.Get("some-team", "", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-value"))
+	})
+
+	It("does not find other teams' secrets", func() {
+		writeSecret("other-value\n", "other-team", "password")
+
+		_, found, err := manager.Get("some-team", "some-pipeline", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("refuses to traverse outside of the directory", func() {
+		_, _, err := manager.Get("some-team", "..", "password")
+		Expect(err).To(HaveOccurred())
+	})
+})
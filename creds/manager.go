@@ -0,0 +1,28 @@
+package creds
+
+import "fmt"
+
+//go:generate counterfeiter . CredentialManager
+
+// CredentialManager looks up the secrets referenced by a pipeline's config as
+// ((secret-name)). Secrets are scoped to a team, and optionally narrowed down
+// to one of the team's pipelines.
+type CredentialManager interface {
+	Get(teamName string, pipelineName string, secretName string) (interface{}, bool, error)
+}
+
+type UndefinedSecretError struct {
+	Name string
+}
+
+func (err UndefinedSecretError) Error() string {
+	return fmt.Sprintf("undefined secret: %s", err.Name)
+}
+
+// NoopCredentialManager is used when no credential manager is configured; it
+// never finds anything, so any ((secret)) reference fails to resolve.
+type NoopCredentialManager struct{}
+
+func (NoopCredentialManager) Get(string, string, string) (interface{}, bool, error) {
+	return nil, false, nil
+}
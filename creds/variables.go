@@ -0,0 +1,242 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/concourse/atc"
+)
+
+var secretRegex = regexp.MustCompile(`\(\(([-\w\p{L}.]+)\)\)`)
+
+// Variables resolves ((secret)) references on behalf of a single pipeline.
+// Nothing is looked up until one of the Evaluate methods is called, and the
+// values passed in are never modified.
+type Variables struct {
+	manager      CredentialManager
+	teamName     string
+	pipelineName string
+}
+
+func NewVariables(manager CredentialManager, teamName string, pipelineName string) Variables {
+	return Variables{
+		manager:      manager,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+func (variables Variables) EvaluateSource(source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated), nil
+}
+
+func (variables Variables) EvaluateParams(params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	evaluated, err := variables.evaluateMap(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated), nil
+}
+
+func (variables Variables) EvaluateResourceTypes(resourceTypes atc.ResourceTypes) (atc.ResourceTypes, error) {
+	if resourceTypes == nil {
+		return nil, nil
+	}
+
+	evaluated := make(atc.ResourceTypes, len(resourceTypes))
+	for i, resourceType := range resourceTypes {
+		source, err := variables.EvaluateSource(resourceType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceType.Source = source
+		evaluated[i] = resourceType
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) EvaluateTaskConfig(config *atc.TaskConfig) (*atc.TaskConfig, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	evaluated := *config
+
+	if config.ImageResource != nil {
+		source, err := variables.EvaluateSource(config.ImageResource.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated.ImageResource = &atc.ImageResource{
+			Type:   config.ImageResource.Type,
+			Source: source,
+		}
+	}
+
+	if config.Params != nil {
+		evaluated.Params = make(map[string]string, len(config.Params))
+		for name, value := range config.Params {
+			resolved, err := variables.evaluateString(value)
+			if err != nil {
+				return nil, err
+			}
+
+			param, err := stringify(resolved)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated.Params[name] = param
+		}
+	}
+
+	return &evaluated, nil
+}
+
+func (variables Variables) EvaluateString(value string) (string, error) {
+	resolved, err := variables.evaluateString(value)
+	if err != nil {
+		return "", err
+	}
+
+	return stringify(resolved)
+}
+
+func (variables Variables) evaluateMap(m map[string]interface{}) (map[string]interface{}, error) {
+	evaluated := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		resolved, err := variables.evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[key] = resolved
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) evaluate(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return variables.evaluateString(v)
+
+	case map[string]interface{}:
+		return variables.evaluateMap(v)
+
+	case map[interface{}]interface{}:
+		evaluated := make(map[interface{}]interface{}, len(v))
+		for key, value := range v {
+			resolved, err := variables.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = resolved
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+		for i, value := range v {
+			resolved, err := variables.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = resolved
+		}
+
+		return evaluated, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// evaluateString replaces a string consisting of a single reference with the
+// secret itself, which may be structured. References embedded in a longer
+// string are interpolated as text.
+func (variables Variables) evaluateString(value string) (interface{}, error) {
+	matches := secretRegex.FindAllStringSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return value, nil
+	}
+
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(value) {
+		return variables.lookup(value[matches[0][2]:matches[0][3]])
+	}
+
+	var lookupErr error
+	evaluated := secretRegex.ReplaceAllStringFunc(value, func(reference string) string {
+		if lookupErr != nil {
+			return reference
+		}
+
+		secret, err := variables.lookup(secretRegex.FindStringSubmatch(reference)[1])
+		if err != nil {
+			lookupErr = err
+			return reference
+		}
+
+		text, err := stringify(secret)
+		if err != nil {
+			lookupErr = err
+			return reference
+		}
+
+		return text
+	})
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	return evaluated, nil
+}
+
+func (variables Variables) lookup(name string) (interface{}, error) {
+	secret, found, err := variables.manager.Get(variables.teamName, variables.pipelineName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, UndefinedSecretError{Name: name}
+	}
+
+	return secret, nil
+}
+
+// stringify renders a secret as text. Structured secrets are rendered as JSON
+// so that they can still be parsed by whatever consumes them.
+func stringify(secret interface{}) (string, error) {
+	if text, ok := secret.(string); ok {
+		return text, nil
+	}
+
+	payload, err := json.Marshal(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
@@ -0,0 +1,252 @@
+// Package cron parses standard five-field cron expressions and computes the
+// times at which they fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// when both the day of the month and the day of the week are restricted,
+	// either of them matching is enough
+	domRestricted bool
+	dowRestricted bool
+}
+
+type field struct {
+	name  string
+	min   uint
+	max   uint
+	names map[string]uint
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+
+	// 7 is accepted as sunday, and folded into 0 once parsed
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression of the form "minute hour day-of-month month
+// day-of-week", or one of the @yearly, @monthly, @weekly, @daily and @hourly
+// descriptors.
+func Parse(expr string) (Schedule, error) {
+	if expanded, found := descriptors[strings.TrimSpace(expr)]; found {
+		expr = expanded
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var schedule Schedule
+	var err error
+
+	schedule.minute, err = minuteField.parse(fields[0])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.hour, err = hourField.parse(fields[1])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.dom, err = domField.parse(fields[2])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.month, err = monthField.parse(fields[3])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	schedule.dow, err = dowField.parse(fields[4])
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = (schedule.dow | 1) &^ (1 << 7)
+	}
+
+	schedule.domRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dowRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return schedule, nil
+}
+
+func (f field) parse(expr string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr := part
+		step := uint(1)
+
+		if i := strings.Index(part, "/"); i != -1 {
+			rangeExpr = part[:i]
+
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, fmt.Errorf("invalid step in %s field: '%s'", f.name, part)
+			}
+
+			step = uint(n)
+		}
+
+		var start, end uint
+		var err error
+
+		switch {
+		case rangeExpr == "*":
+			start, end = f.min, f.max
+
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+
+			start, err = f.value(bounds[0])
+			if err != nil {
+				return 0, err
+			}
+
+			end, err = f.value(bounds[1])
+			if err != nil {
+				return 0, err
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("invalid range in %s field: '%s'", f.name, rangeExpr)
+			}
+
+		default:
+			start, err = f.value(rangeExpr)
+			if err != nil {
+				return 0, err
+			}
+
+			end = start
+
+			// "5/15" means every 15 starting at 5
+			if step > 1 {
+				end = f.max
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func (f field) value(expr string) (uint, error) {
+	if v, found := f.names[strings.ToLower(expr)]; found {
+		return v, nil
+	}
+
+	n, err := strconv.ParseUint(expr, 10, 8)
+	if err != nil || uint(n) < f.min || uint(n) > f.max {
+		return 0, fmt.Errorf("invalid value in %s field: '%s'", f.name, expr)
+	}
+
+	return uint(n), nil
+}
+
+// Next returns the first time after t at which the schedule fires, in t's
+// location. It returns the zero time if the schedule never fires, e.g. for
+// "0 0 30 2 *".
+//
+// Times are matched against the wall clock, so around daylight saving time
+// changes a time that is skipped never fires, and one that happens twice fires
+// both times.
+func (schedule Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// every day of the next few years is plenty to find a match, including
+	// leap days
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if schedule.month&(1<<uint(t.Month())) == 0 {
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if !schedule.dayMatches(t) {
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		// hours and minutes are stepped through as elapsed time rather than by
+		// wall clock, as a wall clock time skipped by a daylight saving time
+		// change is normalized back to before the change
+		if schedule.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+
+		if schedule.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// forward returns next if it is after t, and otherwise the next hour after t.
+// Midnight doesn't exist on days when daylight saving time starts at midnight,
+// in which case the time returned for it may be the day before.
+func forward(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+func (schedule Schedule) dayMatches(t time.Time) bool {
+	domMatches := schedule.dom&(1<<uint(t.Day())) != 0
+	dowMatches := schedule.dow&(1<<uint(t.Weekday())) != 0
+
+	if schedule.domRestricted && schedule.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
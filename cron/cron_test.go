@@ -0,0 +1,109 @@
+package cron_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/cron"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cron", func() {
+	// a wednesday
+	from := time.Date(2017, time.March, 1, 10, 30, 15, 0, time.UTC)
+
+	DescribeTable("computing the next time",
+		func(expr string, expected time.Time) {
+			schedule, err := cron.Parse(expr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(from)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2017, time.March, 1, 10, 31, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2017, time.March, 1, 10, 45, 0, 0, time.UTC)),
+		Entry("a step from a start", "5/20 * * * *", time.Date(2017, time.March, 1, 10, 45, 0, 0, time.UTC)),
+		Entry("nightly", "0 2 * * *", time.Date(2017, time.March, 2, 2, 0, 0, 0, time.UTC)),
+		Entry("later today", "0 14 * * *", time.Date(2017, time.March, 1, 14, 0, 0, 0, time.UTC)),
+		Entry("a list of hours", "0 6,12,18 * * *", time.Date(2017, time.March, 1, 12, 0, 0, 0, time.UTC)),
+		Entry("a range of weekdays", "0 9 * * mon-fri", time.Date(2017, time.March, 2, 9, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 9 * * 7", time.Date(2017, time.March, 5, 9, 0, 0, 0, time.UTC)),
+		Entry("a month name", "0 0 1 jun *", time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("either day field when both are restricted", "0 0 15 * fri", time.Date(2017, time.March, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("a leap day", "0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("@daily", "@daily", time.Date(2017, time.March, 2, 0, 0, 0, 0, time.UTC)),
+		Entry("@hourly", "@hourly", time.Date(2017, time.March, 1, 11, 0, 0, 0, time.UTC)),
+	)
+
+	It("computes the next time in the given time's location", func() {
+		newYork, err := time.LoadLocation("America/New_York")
+		Expect(err).NotTo(HaveOccurred())
+
+		schedule, err := cron.Parse("0 2 * * *")
+		Expect(err).NotTo(HaveOccurred())
+
+		next := schedule.Next(from.In(newYork))
+		Expect(next).To(Equal(time.Date(2017, time.March, 2, 2, 0, 0, 0, newYork)))
+		Expect(next.UTC()).To(Equal(time.Date(2017, time.March, 2, 7, 0, 0, 0, time.UTC)))
+	})
+
+	Context("around daylight saving time changes", func() {
+		var newYork *time.Location
+
+		BeforeEach(func() {
+			var err error
+			newYork, err = time.LoadLocation("America/New_York")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("skips a time that does not exist when the clocks spring forward", func() {
+			schedule, err := cron.Parse("30 2 * * *")
+			Expect(err).NotTo(HaveOccurred())
+
+			next := schedule.Next(time.Date(2017, time.March, 11, 2, 30, 0, 0, newYork))
+			Expect(next).To(Equal(time.Date(2017, time.March, 13, 2, 30, 0, 0, newYork)))
+		})
+
+		It("moves past the hour that is skipped", func() {
+			schedule, err := cron.Parse("0 * * * *")
+			Expect(err).NotTo(HaveOccurred())
+
+			next := schedule.Next(time.Date(2017, time.March, 12, 1, 30, 0, 0, newYork))
+			Expect(next).To(Equal(time.Date(2017, time.March, 12, 3, 0, 0, 0, newYork)))
+			Expect(next.UTC()).To(Equal(time.Date(2017, time.March, 12, 7, 0, 0, 0, time.UTC)))
+		})
+
+		It("fires at both occurrences of a time repeated when the clocks fall back", func() {
+			schedule, err := cron.Parse("30 1 * * *")
+			Expect(err).NotTo(HaveOccurred())
+
+			first := schedule.Next(time.Date(2017, time.November, 5, 0, 0, 0, 0, newYork))
+			Expect(first.UTC()).To(Equal(time.Date(2017, time.November, 5, 5, 30, 0, 0, time.UTC)))
+
+			second := schedule.Next(first)
+			Expect(second.UTC()).To(Equal(time.Date(2017, time.November, 5, 6, 30, 0, 0, time.UTC)))
+
+			third := schedule.Next(second)
+			Expect(third).To(Equal(time.Date(2017, time.November, 6, 1, 30, 0, 0, newYork)))
+		})
+	})
+
+	It("returns the zero time for a schedule that never fires", func() {
+		schedule, err := cron.Parse("0 0 30 2 *")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schedule.Next(from)).To(BeZero())
+	})
+
+	DescribeTable("invalid expressions",
+		func(expr string) {
+			_, err := cron.Parse(expr)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "* * * *"),
+		Entry("too many fields", "* * * * * *"),
+		Entry("an out of range value", "60 * * * *"),
+		Entry("an unknown name", "0 0 * * funday"),
+		Entry("a backwards range", "0 0 * * 5-1"),
+		Entry("a zero step", "*/0 * * * *"),
+		Entry("garbage", "bogus"),
+	)
+})
@@ -819,4 +819,135 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("resolves to the version the resource is pinned to", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{Name: "resource-x", Resource: "resource-x"},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("uses the version the resource is pinned to over every version", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Every: true},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("uses the version the resource is pinned to over the job's pinned version", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{"resource-x": "rxv1"},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Pinned: "rxv3"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
+	Entry("resolves to the pinned version of the resource when it has passed the constraint", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "some-job", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "some-job", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "some-job", BuildID: 3, Resource: "resource-x", Version: "rxv3", CheckOrder: 3},
+			},
+
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"some-job"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv2",
+			},
+		},
+	}),
+
+	Entry("does not resolve a version when the pinned version of the resource has not passed the constraint", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "some-job", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+
+			PinnedVersions: map[string]string{"resource-x": "rxv2"},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"some-job"},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
 )
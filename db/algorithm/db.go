@@ -8,6 +8,7 @@ type VersionsDB struct {
 	BuildInputs      []BuildInput
 	JobIDs           map[string]int
 	ResourceIDs      map[string]int
+	PinnedVersions   map[int]int
 	CachedAt         time.Time
 }
 
@@ -30,6 +31,13 @@ type BuildInput struct {
 	InputName string
 }
 
+// PinnedVersionOfResource returns the version the resource has been pinned
+// to, if any.
+func (db VersionsDB) PinnedVersionOfResource(resourceID int) (int, bool) {
+	versionID, found := db.PinnedVersions[resourceID]
+	return versionID, found
+}
+
 func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputName string) bool {
 	for _, buildInput := range db.BuildInputs {
 		if buildInput.VersionID == versionID &&
@@ -119,3 +127,24 @@ func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet)
 
 	return candidates
 }
+
+// VersionPassedJobs returns true if the version has made it through a
+// succeeded build of every one of the jobs.
+func (db VersionsDB) VersionPassedJobs(resourceID int, versionID int, passed JobSet) bool {
+	for jobID, _ := range passed {
+		found := false
+
+		for _, output := range db.BuildOutputs {
+			if output.ResourceID == resourceID && output.VersionID == versionID && output.JobID == jobID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -19,15 +19,24 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 	for _, inputConfig := range configs {
 		versionCandidates := VersionCandidates{}
 
+		useEveryVersion := inputConfig.UseEveryVersion
+		pinnedVersionID := inputConfig.PinnedVersionID
+
+		// a version pinned on the resource overrides the job's own config
+		if resourcePinnedVersionID, pinned := db.PinnedVersionOfResource(inputConfig.ResourceID); pinned {
+			useEveryVersion = false
+			pinnedVersionID = resourcePinnedVersionID
+		}
+
 		if len(inputConfig.Passed) == 0 {
-			if inputConfig.UseEveryVersion {
+			if useEveryVersion {
 				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
 
-				if inputConfig.PinnedVersionID != 0 {
-					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+				if pinnedVersionID != 0 {
+					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, pinnedVersionID)
 				} else {
 					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
 				}
@@ -48,6 +57,10 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				inputConfig.Passed,
 			)
 
+			if pinnedVersionID != 0 {
+				versionCandidates = versionCandidates.ForVersion(pinnedVersionID)
+			}
+
 			if versionCandidates.IsEmpty() {
 				return nil, false
 			}
@@ -62,8 +75,8 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 		inputCandidates = append(inputCandidates, InputVersionCandidates{
 			Input:                 inputConfig.Name,
 			Passed:                inputConfig.Passed,
-			UseEveryVersion:       inputConfig.UseEveryVersion,
-			PinnedVersionID:       inputConfig.PinnedVersionID,
+			UseEveryVersion:       useEveryVersion,
+			PinnedVersionID:       pinnedVersionID,
 			VersionCandidates:     versionCandidates,
 			ExistingBuildResolver: existingBuildResolver,
 		})
@@ -14,6 +14,9 @@ type DB struct {
 	BuildInputs  []DBRow
 	BuildOutputs []DBRow
 	Resources    []DBRow
+
+	// resource name to the version it is pinned to
+	PinnedVersions map[string]string
 }
 
 type DBRow struct {
@@ -138,6 +141,14 @@ func (example Example) Run() {
 				JobID:           jobIDs.ID(row.Job),
 			})
 		}
+
+		for resource, version := range example.DB.PinnedVersions {
+			if db.PinnedVersions == nil {
+				db.PinnedVersions = map[int]int{}
+			}
+
+			db.PinnedVersions[resourceIDs.ID(resource)] = versionIDs.ID(version)
+		}
 	}
 
 	inputConfigs := make(algorithm.InputConfigs, len(example.Inputs))
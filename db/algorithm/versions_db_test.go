@@ -0,0 +1,53 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionsDB", func() {
+	var versionsDB *algorithm.VersionsDB
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         32,
+					JobID:           12,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+			},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12},
+			ResourceIDs: map[string]int{"r1": 21},
+		}
+	})
+
+	Describe("VersionPassedJobs", func() {
+		It("returns true if the version was output by every job", func() {
+			Expect(versionsDB.VersionPassedJobs(21, 1, algorithm.JobSet{11: {}, 12: {}})).To(BeTrue())
+		})
+
+		It("returns false if the version was not output by one of the jobs", func() {
+			Expect(versionsDB.VersionPassedJobs(21, 2, algorithm.JobSet{11: {}, 12: {}})).To(BeFalse())
+		})
+
+		It("returns true when there are no jobs to pass", func() {
+			Expect(versionsDB.VersionPassedJobs(21, 2, algorithm.JobSet{})).To(BeTrue())
+		})
+	})
+})
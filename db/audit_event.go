@@ -0,0 +1,26 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// AuditEvent records a mutating API request. TeamName is the team the request
+// targeted, which is not necessarily the team of the actor. It is kept even if
+// the team is later deleted.
+type AuditEvent struct {
+	ID       int
+	TeamName string
+
+	Route  string
+	Target map[string]string
+	Status int
+
+	ActorTeamName string
+	ActorUser     string
+	ActorRole     atc.TeamRole
+	ActorIsSystem bool
+
+	CreatedAt time.Time
+}
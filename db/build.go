@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
 )
 
 type Status string
@@ -23,8 +24,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, manually_triggered, manual_override, rerun_of, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.manual_override, b.rerun_of, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -45,16 +46,20 @@ type Build interface {
 	IsScheduled() bool
 	IsRunning() bool
 	IsManuallyTriggered() bool
+	IsManualOverride() bool
+	RerunOf() int
 
 	Reload() (bool, error)
 
 	Events(from uint) (EventSource, error)
+	EventCount() (uint, error)
 	SaveEvent(event atc.Event) error
 
 	GetVersionedResources() (SavedVersionedResources, error)
 	GetResources() ([]BuildInput, []BuildOutput, error)
 
 	Start(string, string) (bool, error)
+	Rerun() (Build, error)
 	Finish(status Status) error
 	MarkAsFailed(cause error) error
 	Abort() error
@@ -72,6 +77,10 @@ type Build interface {
 	SaveImageResourceVersion(planID atc.PlanID, identifier ResourceCacheIdentifier) error
 	GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, error)
 
+	WaitForGate(planID atc.PlanID, name string) error
+	DecideGate(planID atc.PlanID, approved bool, decidedBy string) (bool, error)
+	GetGate(planID atc.PlanID) (BuildGate, bool, error)
+
 	GetConfig() (atc.Config, ConfigVersion, error)
 
 	GetPipeline() (SavedPipeline, error)
@@ -90,6 +99,8 @@ type build struct {
 	teamID       int
 
 	isManuallyTriggered bool
+	isManualOverride    bool
+	rerunOf             int
 
 	engine         string
 	engineMetadata string
@@ -132,6 +143,18 @@ func (b *build) IsManuallyTriggered() bool {
 	return b.isManuallyTriggered
 }
 
+// IsManualOverride returns true if the build was triggered with some of its
+// input versions chosen by hand, rather than by the input mapper.
+func (b *build) IsManualOverride() bool {
+	return b.isManualOverride
+}
+
+// RerunOf returns the ID of the build that this build reruns, or 0 if it is
+// not a rerun.
+func (b *build) RerunOf() int {
+	return b.rerunOf
+}
+
 func (b *build) Engine() string {
 	return b.engine
 }
@@ -216,20 +239,29 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newSQLDBBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
 	), nil
 }
 
+func (b *build) EventCount() (uint, error) {
+	var count uint
+	err := b.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM `+b.eventsTable()+`
+		WHERE build_id = $1
+	`, b.id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (b *build) Start(engine, metadata string) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -833,6 +865,132 @@ func (b *build) GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, e
 	return identifiers, nil
 }
 
+// Rerun creates a new pending build of the same job which will use exactly
+// the same input versions as this build, bypassing the input mapper.
+func (b *build) Rerun() (Build, error) {
+	if b.IsOneOff() || !b.scheduled {
+		return nil, ErrCannotRerunBuild
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	buildName, jobID, err := getNewBuildNameForJob(tx, b.jobName, b.pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	buildFactory := newBuildFactory(b.conn, b.bus, b.lockFactory)
+	rerun, _, err := buildFactory.ScanBuild(tx.QueryRow(`
+		INSERT INTO builds (name, job_id, team_id, status, manually_triggered, rerun_of)
+		VALUES ($1, $2, $3, 'pending', TRUE, $4)
+		RETURNING `+buildColumns+`,
+			(SELECT name FROM jobs WHERE id = $2),
+			(SELECT id FROM pipelines WHERE id = $5),
+			(SELECT name FROM pipelines WHERE id = $5),
+			(SELECT name FROM teams WHERE id = $3)
+	`, buildName, jobID, b.teamID, b.id, b.pipelineID))
+	if err != nil {
+		return nil, err
+	}
+
+	// the inputs are copied so that the rerun stays reproducible even if the
+	// original build is reaped
+	_, err = tx.Exec(`
+		INSERT INTO build_input_overrides (build_id, input_name, versioned_resource_id)
+		SELECT $1, name, versioned_resource_id
+		FROM build_inputs
+		WHERE build_id = $2
+	`, rerun.ID(), b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = createBuildEventSeq(tx, rerun.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return rerun, nil
+}
+
+func (b *build) WaitForGate(planID atc.PlanID, name string) error {
+	_, err := b.conn.Exec(`
+		INSERT INTO build_gates (build_id, plan_id, name)
+		VALUES ($1, $2, $3)
+	`, b.id, string(planID), name)
+
+	// the gate may already be waiting if the build is being resumed
+	return swallowUniqueViolation(err)
+}
+
+func (b *build) DecideGate(planID atc.PlanID, approved bool, decidedBy string) (bool, error) {
+	result, err := b.conn.Exec(`
+		UPDATE build_gates g
+		SET approved = $1, decided_by = $2, decided_at = now()
+		FROM builds b
+		WHERE b.id = g.build_id
+		AND b.status = 'started'
+		AND g.build_id = $3
+		AND g.plan_id = $4
+		AND g.approved IS NULL
+	`, approved, decidedBy, b.id, string(planID))
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (b *build) GetGate(planID atc.PlanID) (BuildGate, bool, error) {
+	var (
+		gate      BuildGate
+		gatePlan  string
+		approved  sql.NullBool
+		decidedBy sql.NullString
+		decidedAt pq.NullTime
+	)
+
+	err := b.conn.QueryRow(`
+		SELECT plan_id, name, approved, decided_by, created_at, decided_at
+		FROM build_gates
+		WHERE build_id = $1
+		AND plan_id = $2
+	`, b.id, string(planID)).Scan(&gatePlan, &gate.Name, &approved, &decidedBy, &gate.CreatedAt, &decidedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildGate{}, false, nil
+		}
+
+		return BuildGate{}, false, err
+	}
+
+	gate.PlanID = atc.PlanID(gatePlan)
+
+	if approved.Valid {
+		gate.Approved = &approved.Bool
+	}
+
+	gate.DecidedBy = decidedBy.String
+	gate.DecidedAt = decidedAt.Time
+
+	return gate, true, nil
+}
+
 func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (Lock, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -950,15 +1108,10 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	_, err = tx.Exec(fmt.Sprintf(`
 		INSERT INTO %s (event_id, build_id, type, version, payload)
 		VALUES (nextval('%s'), $1, $2, $3, $4)
-	`, table, buildEventSeq(b.id)), b.id, string(event.EventType()), string(event.Version()), payload)
+	`, b.eventsTable(), buildEventSeq(b.id)), b.id, string(event.EventType()), string(event.Version()), payload)
 	if err != nil {
 		return err
 	}
@@ -966,6 +1119,14 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	return nil
 }
 
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
@@ -33,8 +33,10 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var reapTime pq.NullTime
 	var teamName string
 	var isManuallyTriggered bool
+	var isManualOverride bool
+	var rerunOf sql.NullInt64
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &isManualOverride, &rerunOf, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -53,6 +55,7 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		status:              Status(status),
 		scheduled:           scheduled,
 		isManuallyTriggered: isManuallyTriggered,
+		isManualOverride:    isManualOverride,
 
 		engine:         engine.String,
 		engineMetadata: engineMetadata.String,
@@ -74,5 +77,9 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		build.teamID = int(teamID.Int64)
 	}
 
+	if rerunOf.Valid {
+		build.rerunOf = int(rerunOf.Int64)
+	}
+
 	return build, true, nil
 }
@@ -0,0 +1,20 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// BuildGate is a gate step of a build waiting for, or having received, a
+// decision. Approved is nil until the gate is decided.
+type BuildGate struct {
+	PlanID atc.PlanID
+	Name   string
+
+	Approved  *bool
+	DecidedBy string
+
+	CreatedAt time.Time
+	DecidedAt time.Time
+}
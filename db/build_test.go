@@ -195,6 +195,23 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("EventCount", func() {
+		It("counts the events saved for the build", func() {
+			build, err := teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.EventCount()).To(BeZero())
+
+			err = build.SaveEvent(event.Log{Payload: "some "})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.EventCount()).To(Equal(uint(2)))
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := teamDB.CreateOneOffBuild()
@@ -461,6 +478,170 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("Rerun", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build has been scheduled", func() {
+			BeforeEach(func() {
+				_, err := pipelineDB.SaveInput(build.ID(), db.BuildInput{
+					Name: "some-input",
+					VersionedResource: db.VersionedResource{
+						Resource:   "some-resource",
+						Type:       "some-type",
+						Version:    db.Version{"some": "version"},
+						PipelineID: pipeline.ID,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				scheduled, err := pipelineDB.UpdateBuildToScheduled(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("creates a pending build of the same job that links back to the original", func() {
+				rerun, err := build.Rerun()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rerun.ID()).NotTo(Equal(build.ID()))
+				Expect(rerun.Name()).To(Equal("2"))
+				Expect(rerun.JobName()).To(Equal("some-job"))
+				Expect(rerun.PipelineName()).To(Equal("some-pipeline"))
+				Expect(rerun.TeamName()).To(Equal(atc.DefaultTeamName))
+				Expect(rerun.Status()).To(Equal(db.StatusPending))
+				Expect(rerun.IsManuallyTriggered()).To(BeTrue())
+				Expect(rerun.IsScheduled()).To(BeFalse())
+				Expect(rerun.RerunOf()).To(Equal(build.ID()))
+			})
+
+			It("pins the rerun to the inputs of the original build", func() {
+				rerun, err := build.Rerun()
+				Expect(err).NotTo(HaveOccurred())
+
+				inputs, err := pipelineDB.GetBuildInputOverrides(rerun.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inputs).To(HaveLen(1))
+				Expect(inputs[0].Name).To(Equal("some-input"))
+				Expect(inputs[0].VersionedResource.Resource).To(Equal("some-resource"))
+				Expect(inputs[0].VersionedResource.Version).To(Equal(db.Version{"some": "version"}))
+			})
+		})
+
+		Context("when the build has not been scheduled", func() {
+			It("returns ErrCannotRerunBuild", func() {
+				_, err := build.Rerun()
+				Expect(err).To(Equal(db.ErrCannotRerunBuild))
+			})
+		})
+
+		Context("when the build is a one-off build", func() {
+			It("returns ErrCannotRerunBuild", func() {
+				oneOffBuild, err := teamDB.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = oneOffBuild.Rerun()
+				Expect(err).To(Equal(db.ErrCannotRerunBuild))
+			})
+		})
+	})
+
+	Describe("gates", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start("engine", "metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Context("when the build is not waiting at the gate", func() {
+			It("is not found", func() {
+				_, found, err := build.GetGate("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("cannot be decided", func() {
+				decided, err := build.DecideGate("some-plan-id", true, "some-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+			})
+		})
+
+		Context("when the build is waiting at the gate", func() {
+			BeforeEach(func() {
+				err := build.WaitForGate("some-plan-id", "some-gate")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is undecided", func() {
+				gate, found, err := build.GetGate("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(gate.PlanID).To(Equal(atc.PlanID("some-plan-id")))
+				Expect(gate.Name).To(Equal("some-gate"))
+				Expect(gate.Approved).To(BeNil())
+				Expect(gate.DecidedBy).To(BeEmpty())
+			})
+
+			It("can wait at the same gate again", func() {
+				err := build.WaitForGate("some-plan-id", "some-gate")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("can be decided once", func() {
+				decided, err := build.DecideGate("some-plan-id", false, "some-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeTrue())
+
+				gate, found, err := build.GetGate("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(gate.Approved).NotTo(BeNil())
+				Expect(*gate.Approved).To(BeFalse())
+				Expect(gate.DecidedBy).To(Equal("some-team"))
+				Expect(gate.DecidedAt).NotTo(BeZero())
+
+				decided, err = build.DecideGate("some-plan-id", true, "some-other-team")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+
+				gate, found, err = build.GetGate("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(*gate.Approved).To(BeFalse())
+			})
+
+			Context("when the build has finished", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.StatusAborted)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("cannot be decided", func() {
+					decided, err := build.DecideGate("some-plan-id", true, "some-team")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("GetBuildPreparation", func() {
 		var (
 			build             db.Build
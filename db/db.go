@@ -87,9 +87,15 @@ type DB interface {
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
+	CreateAuditEvent(event AuditEvent) error
+	DeleteAuditEventsBefore(before time.Time) (int, error)
+
 	Workers() ([]SavedWorker, error) // auto-expires workers based on ttl
 	GetWorker(workerName string) (SavedWorker, bool, error)
 	SaveWorker(WorkerInfo, time.Duration) (SavedWorker, error)
+	LandWorker(workerName string) (bool, error)
+	RetireWorker(workerName string) (bool, error)
+	PruneWorker(workerName string) (bool, error)
 
 	GetContainer(string) (SavedContainer, bool, error)
 	CreateContainer(container Container, ttl time.Duration, maxLifetime time.Duration, volumeHandles []string) (SavedContainer, error)
@@ -149,6 +155,7 @@ type SavedWorker struct {
 
 	TeamName  string
 	ExpiresIn time.Duration
+	State     WorkerState
 }
 
 type WorkerInfo struct {
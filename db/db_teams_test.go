@@ -291,6 +291,26 @@ var _ = Describe("SQL DB Teams", func() {
 
 			Expect(savedTeam.GenericOAuth).To(Equal(expectedTeam.GenericOAuth))
 		})
+
+		It("saves a team to the db with role bindings", func() {
+			expectedTeam := db.Team{
+				Name: "avengers",
+				Roles: []atc.TeamRoleBinding{
+					{
+						Role:        atc.TeamRoleViewer,
+						GitHubUsers: []string{"nick-fury"},
+					},
+				},
+			}
+			expectedSavedTeam, err := database.CreateTeam(expectedTeam)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expectedSavedTeam.Team).To(Equal(expectedTeam))
+
+			savedTeam, found, err := teamDBFactory.GetTeamDB("avengers").GetTeam()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTeam).To(Equal(expectedSavedTeam))
+		})
 	})
 
 	Describe("DeleteTeamByName", func() {
@@ -588,6 +588,72 @@ var _ = Describe("Keeping track of volumes", func() {
 				Expect(handles).To(ConsistOf([]string{"my-import-handle", "my-other-import-handle"}))
 			})
 		})
+
+		Describe("task cache volumes", func() {
+			var taskCacheVolume db.Volume
+			var taskCacheIdentifier db.VolumeIdentifier
+
+			BeforeEach(func() {
+				taskCacheIdentifier = db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						WorkerName: insertedWorker.Name,
+						PipelineID: 42,
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some/cache",
+					},
+				}
+				taskCacheVolume = db.Volume{
+					WorkerName: insertedWorker.Name,
+					TTL:        5 * time.Minute,
+					Handle:     "my-task-cache-handle",
+					Identifier: taskCacheIdentifier,
+				}
+
+				err := database.InsertVolume(taskCacheVolume)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("can be retrieved", func() {
+				savedTaskCacheVolumes, err := database.GetVolumesByIdentifier(taskCacheIdentifier)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(savedTaskCacheVolumes)).To(Equal(1))
+
+				savedTaskCacheVolume := savedTaskCacheVolumes[0]
+				Expect(savedTaskCacheVolume.WorkerName).To(Equal(taskCacheVolume.WorkerName))
+				Expect(savedTaskCacheVolume.Handle).To(Equal(taskCacheVolume.Handle))
+				Expect(savedTaskCacheVolume.Volume.Identifier).To(Equal(taskCacheIdentifier))
+				Expect(savedTaskCacheVolume.ExpiresIn).To(BeNumerically("~", taskCacheVolume.TTL, time.Second))
+			})
+
+			It("is not retrieved for a different path of the same task", func() {
+				savedTaskCacheVolumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						WorkerName: insertedWorker.Name,
+						PipelineID: 42,
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some/other/cache",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTaskCacheVolumes).To(BeEmpty())
+			})
+
+			It("is not retrieved for a different worker", func() {
+				savedTaskCacheVolumes, err := database.GetVolumesByIdentifier(db.VolumeIdentifier{
+					TaskCache: &db.TaskCacheIdentifier{
+						WorkerName: "some-other-worker",
+						PipelineID: 42,
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some/cache",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTaskCacheVolumes).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("GetVolumesForOneOffBuildImageResources", func() {
@@ -16,7 +16,7 @@ var _ = Describe("Keeping track of workers", func() {
 	var dbConn db.Conn
 	var listener *pq.Listener
 
-	var database db.DB
+	var database *db.SQLDB
 
 	var team db.SavedTeam
 	BeforeEach(func() {
@@ -77,6 +77,7 @@ var _ = Describe("Keeping track of workers", func() {
 		expectedSavedWorkerA := db.SavedWorker{
 			WorkerInfo: infoA,
 			ExpiresIn:  0,
+			State:      db.WorkerStateRunning,
 		}
 
 		By("persisting workers with no TTLs")
@@ -250,6 +251,124 @@ var _ = Describe("Keeping track of workers", func() {
 		Consistently(workerFound, ttl/2).Should(BeTrue())
 		Eventually(workerFound, 2*ttl).Should(BeFalse())
 	})
+
+	Describe("worker lifecycle", func() {
+		var info db.WorkerInfo
+
+		BeforeEach(func() {
+			info = db.WorkerInfo{
+				Name:       "some-worker",
+				GardenAddr: "1.2.3.4:7777",
+				Platform:   "linux",
+				StartTime:  1461864115,
+			}
+
+			_, err := database.SaveWorker(info, time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		workerState := func() db.WorkerState {
+			savedWorker, found, err := database.GetWorker(info.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			return savedWorker.State
+		}
+
+		It("starts out running", func() {
+			Expect(workerState()).To(Equal(db.WorkerStateRunning))
+		})
+
+		Describe("landing", func() {
+			It("lands the worker once it has no builds in flight", func() {
+				found, err := database.LandWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(workerState()).To(Equal(db.WorkerStateLanding))
+
+				By("remaining landing across heartbeats")
+				_, err = database.SaveWorker(info, time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workerState()).To(Equal(db.WorkerStateLanding))
+
+				err = database.LandFinishedLandingWorkers()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+				By("remaining landed until the worker restarts")
+				_, err = database.SaveWorker(info, time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workerState()).To(Equal(db.WorkerStateLanded))
+
+				info.StartTime++
+				_, err = database.SaveWorker(info, time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(workerState()).To(Equal(db.WorkerStateRunning))
+			})
+
+			It("returns false when the worker does not exist", func() {
+				found, err := database.LandWorker("bogus-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Describe("retiring", func() {
+			It("removes the worker once it has no builds in flight", func() {
+				found, err := database.RetireWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(workerState()).To(Equal(db.WorkerStateRetiring))
+
+				err = database.DeleteFinishedRetiringWorkers()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err = database.GetWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Describe("stalling", func() {
+			BeforeEach(func() {
+				_, err := database.SaveWorker(info, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+
+				time.Sleep(2 * time.Second)
+
+				err = database.ReapExpiredWorkers()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("marks the worker as stalled rather than removing it", func() {
+				Expect(workerState()).To(Equal(db.WorkerStateStalled))
+			})
+
+			It("can be pruned", func() {
+				found, err := database.PruneWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = database.GetWorker(info.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Describe("pruning", func() {
+			It("refuses to prune a running worker", func() {
+				found, err := database.PruneWorker(info.Name)
+				Expect(err).To(Equal(db.ErrCannotPruneRunningWorker))
+				Expect(found).To(BeTrue())
+				Expect(workerState()).To(Equal(db.WorkerStateRunning))
+			})
+
+			It("returns false when the worker does not exist", func() {
+				found, err := database.PruneWorker("bogus-worker")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
 })
 
 func getWorkerInfos(savedWorkers []db.SavedWorker, err error) []db.WorkerInfo {
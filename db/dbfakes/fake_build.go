@@ -262,6 +262,62 @@ type FakeBuild struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
+	WaitForGateStub        func(planID atc.PlanID, name string) error
+	waitForGateMutex       sync.RWMutex
+	waitForGateArgsForCall []struct {
+		planID atc.PlanID
+		name   string
+	}
+	waitForGateReturns struct {
+		result1 error
+	}
+	DecideGateStub        func(planID atc.PlanID, approved bool, decidedBy string) (bool, error)
+	decideGateMutex       sync.RWMutex
+	decideGateArgsForCall []struct {
+		planID    atc.PlanID
+		approved  bool
+		decidedBy string
+	}
+	decideGateReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetGateStub        func(planID atc.PlanID) (db.BuildGate, bool, error)
+	getGateMutex       sync.RWMutex
+	getGateArgsForCall []struct {
+		planID atc.PlanID
+	}
+	getGateReturns struct {
+		result1 db.BuildGate
+		result2 bool
+		result3 error
+	}
+	EventCountStub        func() (uint, error)
+	eventCountMutex       sync.RWMutex
+	eventCountArgsForCall []struct{}
+	eventCountReturns     struct {
+		result1 uint
+		result2 error
+	}
+	IsManualOverrideStub        func() bool
+	isManualOverrideMutex       sync.RWMutex
+	isManualOverrideArgsForCall []struct{}
+	isManualOverrideReturns     struct {
+		result1 bool
+	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct{}
+	rerunOfReturns     struct {
+		result1 int
+	}
+	RerunStub        func() (db.Build, error)
+	rerunMutex       sync.RWMutex
+	rerunArgsForCall []struct{}
+	rerunReturns     struct {
+		result1 db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1242,6 +1298,213 @@ func (fake *FakeBuild) GetPipelineReturns(result1 db.SavedPipeline, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) WaitForGate(planID atc.PlanID, name string) error {
+	fake.waitForGateMutex.Lock()
+	fake.waitForGateArgsForCall = append(fake.waitForGateArgsForCall, struct {
+		planID atc.PlanID
+		name   string
+	}{planID, name})
+	fake.recordInvocation("WaitForGate", []interface{}{planID, name})
+	fake.waitForGateMutex.Unlock()
+	if fake.WaitForGateStub != nil {
+		return fake.WaitForGateStub(planID, name)
+	} else {
+		return fake.waitForGateReturns.result1
+	}
+}
+
+func (fake *FakeBuild) WaitForGateCallCount() int {
+	fake.waitForGateMutex.RLock()
+	defer fake.waitForGateMutex.RUnlock()
+	return len(fake.waitForGateArgsForCall)
+}
+
+func (fake *FakeBuild) WaitForGateArgsForCall(i int) (atc.PlanID, string) {
+	fake.waitForGateMutex.RLock()
+	defer fake.waitForGateMutex.RUnlock()
+	return fake.waitForGateArgsForCall[i].planID, fake.waitForGateArgsForCall[i].name
+}
+
+func (fake *FakeBuild) WaitForGateReturns(result1 error) {
+	fake.WaitForGateStub = nil
+	fake.waitForGateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) DecideGate(planID atc.PlanID, approved bool, decidedBy string) (bool, error) {
+	fake.decideGateMutex.Lock()
+	fake.decideGateArgsForCall = append(fake.decideGateArgsForCall, struct {
+		planID    atc.PlanID
+		approved  bool
+		decidedBy string
+	}{planID, approved, decidedBy})
+	fake.recordInvocation("DecideGate", []interface{}{planID, approved, decidedBy})
+	fake.decideGateMutex.Unlock()
+	if fake.DecideGateStub != nil {
+		return fake.DecideGateStub(planID, approved, decidedBy)
+	} else {
+		return fake.decideGateReturns.result1, fake.decideGateReturns.result2
+	}
+}
+
+func (fake *FakeBuild) DecideGateCallCount() int {
+	fake.decideGateMutex.RLock()
+	defer fake.decideGateMutex.RUnlock()
+	return len(fake.decideGateArgsForCall)
+}
+
+func (fake *FakeBuild) DecideGateArgsForCall(i int) (atc.PlanID, bool, string) {
+	fake.decideGateMutex.RLock()
+	defer fake.decideGateMutex.RUnlock()
+	return fake.decideGateArgsForCall[i].planID, fake.decideGateArgsForCall[i].approved, fake.decideGateArgsForCall[i].decidedBy
+}
+
+func (fake *FakeBuild) DecideGateReturns(result1 bool, result2 error) {
+	fake.DecideGateStub = nil
+	fake.decideGateReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetGate(planID atc.PlanID) (db.BuildGate, bool, error) {
+	fake.getGateMutex.Lock()
+	fake.getGateArgsForCall = append(fake.getGateArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("GetGate", []interface{}{planID})
+	fake.getGateMutex.Unlock()
+	if fake.GetGateStub != nil {
+		return fake.GetGateStub(planID)
+	} else {
+		return fake.getGateReturns.result1, fake.getGateReturns.result2, fake.getGateReturns.result3
+	}
+}
+
+func (fake *FakeBuild) GetGateCallCount() int {
+	fake.getGateMutex.RLock()
+	defer fake.getGateMutex.RUnlock()
+	return len(fake.getGateArgsForCall)
+}
+
+func (fake *FakeBuild) GetGateArgsForCall(i int) atc.PlanID {
+	fake.getGateMutex.RLock()
+	defer fake.getGateMutex.RUnlock()
+	return fake.getGateArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) GetGateReturns(result1 db.BuildGate, result2 bool, result3 error) {
+	fake.GetGateStub = nil
+	fake.getGateReturns = struct {
+		result1 db.BuildGate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) EventCount() (uint, error) {
+	fake.eventCountMutex.Lock()
+	fake.eventCountArgsForCall = append(fake.eventCountArgsForCall, struct{}{})
+	fake.recordInvocation("EventCount", []interface{}{})
+	fake.eventCountMutex.Unlock()
+	if fake.EventCountStub != nil {
+		return fake.EventCountStub()
+	} else {
+		return fake.eventCountReturns.result1, fake.eventCountReturns.result2
+	}
+}
+
+func (fake *FakeBuild) EventCountCallCount() int {
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
+	return len(fake.eventCountArgsForCall)
+}
+
+func (fake *FakeBuild) EventCountReturns(result1 uint, result2 error) {
+	fake.EventCountStub = nil
+	fake.eventCountReturns = struct {
+		result1 uint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) IsManualOverride() bool {
+	fake.isManualOverrideMutex.Lock()
+	fake.isManualOverrideArgsForCall = append(fake.isManualOverrideArgsForCall, struct{}{})
+	fake.recordInvocation("IsManualOverride", []interface{}{})
+	fake.isManualOverrideMutex.Unlock()
+	if fake.IsManualOverrideStub != nil {
+		return fake.IsManualOverrideStub()
+	} else {
+		return fake.isManualOverrideReturns.result1
+	}
+}
+
+func (fake *FakeBuild) IsManualOverrideCallCount() int {
+	fake.isManualOverrideMutex.RLock()
+	defer fake.isManualOverrideMutex.RUnlock()
+	return len(fake.isManualOverrideArgsForCall)
+}
+
+func (fake *FakeBuild) IsManualOverrideReturns(result1 bool) {
+	fake.IsManualOverrideStub = nil
+	fake.isManualOverrideReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct{}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	} else {
+		return fake.rerunOfReturns.result1
+	}
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) Rerun() (db.Build, error) {
+	fake.rerunMutex.Lock()
+	fake.rerunArgsForCall = append(fake.rerunArgsForCall, struct{}{})
+	fake.recordInvocation("Rerun", []interface{}{})
+	fake.rerunMutex.Unlock()
+	if fake.RerunStub != nil {
+		return fake.RerunStub()
+	} else {
+		return fake.rerunReturns.result1, fake.rerunReturns.result2
+	}
+}
+
+func (fake *FakeBuild) RerunCallCount() int {
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
+	return len(fake.rerunArgsForCall)
+}
+
+func (fake *FakeBuild) RerunReturns(result1 db.Build, result2 error) {
+	fake.RerunStub = nil
+	fake.rerunReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1315,6 +1578,20 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.getPipelineMutex.RLock()
 	defer fake.getPipelineMutex.RUnlock()
+	fake.waitForGateMutex.RLock()
+	defer fake.waitForGateMutex.RUnlock()
+	fake.decideGateMutex.RLock()
+	defer fake.decideGateMutex.RUnlock()
+	fake.getGateMutex.RLock()
+	defer fake.getGateMutex.RUnlock()
+	fake.eventCountMutex.RLock()
+	defer fake.eventCountMutex.RUnlock()
+	fake.isManualOverrideMutex.RLock()
+	defer fake.isManualOverrideMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	fake.rerunMutex.RLock()
+	defer fake.rerunMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -544,6 +544,81 @@ type FakePipelineDB struct {
 	hideReturns     struct {
 		result1 error
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
+	PinResourceVersionStub        func(resourceName string, versionedResourceID int, comment string) (bool, error)
+	pinResourceVersionMutex       sync.RWMutex
+	pinResourceVersionArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}
+	pinResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	UnpinResourceVersionStub        func(resourceName string, versionedResourceID int) (bool, error)
+	unpinResourceVersionMutex       sync.RWMutex
+	unpinResourceVersionArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	unpinResourceVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	EnsureScheduledBuildExistsStub        func(jobName string, scheduledAt time.Time) (bool, error)
+	ensureScheduledBuildExistsMutex       sync.RWMutex
+	ensureScheduledBuildExistsArgsForCall []struct {
+		jobName     string
+		scheduledAt time.Time
+	}
+	ensureScheduledBuildExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetJobLastScheduledStub        func(jobName string) (time.Time, bool, error)
+	getJobLastScheduledMutex       sync.RWMutex
+	getJobLastScheduledArgsForCall []struct {
+		jobName string
+	}
+	getJobLastScheduledReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	InitJobLastScheduledStub        func(jobName string, startedAt time.Time) error
+	initJobLastScheduledMutex       sync.RWMutex
+	initJobLastScheduledArgsForCall []struct {
+		jobName   string
+		startedAt time.Time
+	}
+	initJobLastScheduledReturns struct {
+		result1 error
+	}
+	CreateJobBuildWithInputOverridesStub        func(job string, overrides map[string]int) (db.Build, error)
+	createJobBuildWithInputOverridesMutex       sync.RWMutex
+	createJobBuildWithInputOverridesArgsForCall []struct {
+		job       string
+		overrides map[string]int
+	}
+	createJobBuildWithInputOverridesReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	GetBuildInputOverridesStub        func(buildID int) ([]db.BuildInput, error)
+	getBuildInputOverridesMutex       sync.RWMutex
+	getBuildInputOverridesArgsForCall []struct {
+		buildID int
+	}
+	getBuildInputOverridesReturns struct {
+		result1 []db.BuildInput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2536,6 +2611,275 @@ func (fake *FakePipelineDB) HideReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakePipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error) {
+	fake.pinResourceVersionMutex.Lock()
+	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+		comment             string
+	}{resourceName, versionedResourceID, comment})
+	fake.recordInvocation("PinResourceVersion", []interface{}{resourceName, versionedResourceID, comment})
+	fake.pinResourceVersionMutex.Unlock()
+	if fake.PinResourceVersionStub != nil {
+		return fake.PinResourceVersionStub(resourceName, versionedResourceID, comment)
+	} else {
+		return fake.pinResourceVersionReturns.result1, fake.pinResourceVersionReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceVersionCallCount() int {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return len(fake.pinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceVersionArgsForCall(i int) (string, int, string) {
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	return fake.pinResourceVersionArgsForCall[i].resourceName, fake.pinResourceVersionArgsForCall[i].versionedResourceID, fake.pinResourceVersionArgsForCall[i].comment
+}
+
+func (fake *FakePipelineDB) PinResourceVersionReturns(result1 bool, result2 error) {
+	fake.PinResourceVersionStub = nil
+	fake.pinResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersion(resourceName string, versionedResourceID int) (bool, error) {
+	fake.unpinResourceVersionMutex.Lock()
+	fake.unpinResourceVersionArgsForCall = append(fake.unpinResourceVersionArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.recordInvocation("UnpinResourceVersion", []interface{}{resourceName, versionedResourceID})
+	fake.unpinResourceVersionMutex.Unlock()
+	if fake.UnpinResourceVersionStub != nil {
+		return fake.UnpinResourceVersionStub(resourceName, versionedResourceID)
+	} else {
+		return fake.unpinResourceVersionReturns.result1, fake.unpinResourceVersionReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionCallCount() int {
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
+	return len(fake.unpinResourceVersionArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionArgsForCall(i int) (string, int) {
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
+	return fake.unpinResourceVersionArgsForCall[i].resourceName, fake.unpinResourceVersionArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) UnpinResourceVersionReturns(result1 bool, result2 error) {
+	fake.UnpinResourceVersionStub = nil
+	fake.unpinResourceVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) EnsureScheduledBuildExists(jobName string, scheduledAt time.Time) (bool, error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	fake.ensureScheduledBuildExistsArgsForCall = append(fake.ensureScheduledBuildExistsArgsForCall, struct {
+		jobName     string
+		scheduledAt time.Time
+	}{jobName, scheduledAt})
+	fake.recordInvocation("EnsureScheduledBuildExists", []interface{}{jobName, scheduledAt})
+	fake.ensureScheduledBuildExistsMutex.Unlock()
+	if fake.EnsureScheduledBuildExistsStub != nil {
+		return fake.EnsureScheduledBuildExistsStub(jobName, scheduledAt)
+	} else {
+		return fake.ensureScheduledBuildExistsReturns.result1, fake.ensureScheduledBuildExistsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) EnsureScheduledBuildExistsCallCount() int {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return len(fake.ensureScheduledBuildExistsArgsForCall)
+}
+
+func (fake *FakePipelineDB) EnsureScheduledBuildExistsArgsForCall(i int) (string, time.Time) {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return fake.ensureScheduledBuildExistsArgsForCall[i].jobName, fake.ensureScheduledBuildExistsArgsForCall[i].scheduledAt
+}
+
+func (fake *FakePipelineDB) EnsureScheduledBuildExistsReturns(result1 bool, result2 error) {
+	fake.EnsureScheduledBuildExistsStub = nil
+	fake.ensureScheduledBuildExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduled(jobName string) (time.Time, bool, error) {
+	fake.getJobLastScheduledMutex.Lock()
+	fake.getJobLastScheduledArgsForCall = append(fake.getJobLastScheduledArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetJobLastScheduled", []interface{}{jobName})
+	fake.getJobLastScheduledMutex.Unlock()
+	if fake.GetJobLastScheduledStub != nil {
+		return fake.GetJobLastScheduledStub(jobName)
+	} else {
+		return fake.getJobLastScheduledReturns.result1, fake.getJobLastScheduledReturns.result2, fake.getJobLastScheduledReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledCallCount() int {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return len(fake.getJobLastScheduledArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledArgsForCall(i int) string {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return fake.getJobLastScheduledArgsForCall[i].jobName
+}
+
+func (fake *FakePipelineDB) GetJobLastScheduledReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobLastScheduledStub = nil
+	fake.getJobLastScheduledReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) InitJobLastScheduled(jobName string, startedAt time.Time) error {
+	fake.initJobLastScheduledMutex.Lock()
+	fake.initJobLastScheduledArgsForCall = append(fake.initJobLastScheduledArgsForCall, struct {
+		jobName   string
+		startedAt time.Time
+	}{jobName, startedAt})
+	fake.recordInvocation("InitJobLastScheduled", []interface{}{jobName, startedAt})
+	fake.initJobLastScheduledMutex.Unlock()
+	if fake.InitJobLastScheduledStub != nil {
+		return fake.InitJobLastScheduledStub(jobName, startedAt)
+	} else {
+		return fake.initJobLastScheduledReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) InitJobLastScheduledCallCount() int {
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	return len(fake.initJobLastScheduledArgsForCall)
+}
+
+func (fake *FakePipelineDB) InitJobLastScheduledArgsForCall(i int) (string, time.Time) {
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	return fake.initJobLastScheduledArgsForCall[i].jobName, fake.initJobLastScheduledArgsForCall[i].startedAt
+}
+
+func (fake *FakePipelineDB) InitJobLastScheduledReturns(result1 error) {
+	fake.InitJobLastScheduledStub = nil
+	fake.initJobLastScheduledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputOverrides(job string, overrides map[string]int) (db.Build, error) {
+	fake.createJobBuildWithInputOverridesMutex.Lock()
+	fake.createJobBuildWithInputOverridesArgsForCall = append(fake.createJobBuildWithInputOverridesArgsForCall, struct {
+		job       string
+		overrides map[string]int
+	}{job, overrides})
+	fake.recordInvocation("CreateJobBuildWithInputOverrides", []interface{}{job, overrides})
+	fake.createJobBuildWithInputOverridesMutex.Unlock()
+	if fake.CreateJobBuildWithInputOverridesStub != nil {
+		return fake.CreateJobBuildWithInputOverridesStub(job, overrides)
+	} else {
+		return fake.createJobBuildWithInputOverridesReturns.result1, fake.createJobBuildWithInputOverridesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputOverridesCallCount() int {
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
+	return len(fake.createJobBuildWithInputOverridesArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputOverridesArgsForCall(i int) (string, map[string]int) {
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
+	return fake.createJobBuildWithInputOverridesArgsForCall[i].job, fake.createJobBuildWithInputOverridesArgsForCall[i].overrides
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputOverridesReturns(result1 db.Build, result2 error) {
+	fake.CreateJobBuildWithInputOverridesStub = nil
+	fake.createJobBuildWithInputOverridesReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetBuildInputOverrides(buildID int) ([]db.BuildInput, error) {
+	fake.getBuildInputOverridesMutex.Lock()
+	fake.getBuildInputOverridesArgsForCall = append(fake.getBuildInputOverridesArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildInputOverrides", []interface{}{buildID})
+	fake.getBuildInputOverridesMutex.Unlock()
+	if fake.GetBuildInputOverridesStub != nil {
+		return fake.GetBuildInputOverridesStub(buildID)
+	} else {
+		return fake.getBuildInputOverridesReturns.result1, fake.getBuildInputOverridesReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetBuildInputOverridesCallCount() int {
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
+	return len(fake.getBuildInputOverridesArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetBuildInputOverridesArgsForCall(i int) int {
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
+	return fake.getBuildInputOverridesArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) GetBuildInputOverridesReturns(result1 []db.BuildInput, result2 error) {
+	fake.GetBuildInputOverridesStub = nil
+	fake.getBuildInputOverridesReturns = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2663,6 +3007,22 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	fake.pinResourceVersionMutex.RLock()
+	defer fake.pinResourceVersionMutex.RUnlock()
+	fake.unpinResourceVersionMutex.RLock()
+	defer fake.unpinResourceVersionMutex.RUnlock()
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
 	return fake.invocations
 }
 
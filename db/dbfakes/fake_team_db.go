@@ -166,6 +166,70 @@ type FakeTeamDB struct {
 		result1 []db.SavedVolume
 		result2 error
 	}
+	GetPipelineTemplateStub        func(pipelineName string) (db.PipelineTemplate, bool, error)
+	getPipelineTemplateMutex       sync.RWMutex
+	getPipelineTemplateArgsForCall []struct {
+		pipelineName string
+	}
+	getPipelineTemplateReturns struct {
+		result1 db.PipelineTemplate
+		result2 bool
+		result3 error
+	}
+	UpdateRolesStub        func(roles []atc.TeamRoleBinding) (db.SavedTeam, error)
+	updateRolesMutex       sync.RWMutex
+	updateRolesArgsForCall []struct {
+		roles []atc.TeamRoleBinding
+	}
+	updateRolesReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	GetAuditEventsStub        func(page db.Page) ([]db.AuditEvent, db.Pagination, error)
+	getAuditEventsMutex       sync.RWMutex
+	getAuditEventsArgsForCall []struct {
+		page db.Page
+	}
+	getAuditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	SaveConfigAsStub        func(savedBy string, pipelineName string, config atc.Config, pipelineTemplate db.PipelineTemplate, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error)
+	saveConfigAsMutex       sync.RWMutex
+	saveConfigAsArgsForCall []struct {
+		savedBy          string
+		pipelineName     string
+		config           atc.Config
+		pipelineTemplate db.PipelineTemplate
+		from             db.ConfigVersion
+		pausedState      db.PipelinePausedState
+	}
+	saveConfigAsReturns struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}
+	GetConfigVersionsStub        func(pipelineName string) ([]db.SavedConfigVersion, error)
+	getConfigVersionsMutex       sync.RWMutex
+	getConfigVersionsArgsForCall []struct {
+		pipelineName string
+	}
+	getConfigVersionsReturns struct {
+		result1 []db.SavedConfigVersion
+		result2 error
+	}
+	GetConfigVersionStub        func(pipelineName string, version db.ConfigVersion) (db.SavedConfigVersion, bool, error)
+	getConfigVersionMutex       sync.RWMutex
+	getConfigVersionArgsForCall []struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}
+	getConfigVersionReturns struct {
+		result1 db.SavedConfigVersion
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -740,6 +804,225 @@ func (fake *FakeTeamDB) GetVolumesReturns(result1 []db.SavedVolume, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeTeamDB) GetPipelineTemplate(pipelineName string) (db.PipelineTemplate, bool, error) {
+	fake.getPipelineTemplateMutex.Lock()
+	fake.getPipelineTemplateArgsForCall = append(fake.getPipelineTemplateArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetPipelineTemplate", []interface{}{pipelineName})
+	fake.getPipelineTemplateMutex.Unlock()
+	if fake.GetPipelineTemplateStub != nil {
+		return fake.GetPipelineTemplateStub(pipelineName)
+	} else {
+		return fake.getPipelineTemplateReturns.result1, fake.getPipelineTemplateReturns.result2, fake.getPipelineTemplateReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetPipelineTemplateCallCount() int {
+	fake.getPipelineTemplateMutex.RLock()
+	defer fake.getPipelineTemplateMutex.RUnlock()
+	return len(fake.getPipelineTemplateArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetPipelineTemplateArgsForCall(i int) string {
+	fake.getPipelineTemplateMutex.RLock()
+	defer fake.getPipelineTemplateMutex.RUnlock()
+	return fake.getPipelineTemplateArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetPipelineTemplateReturns(result1 db.PipelineTemplate, result2 bool, result3 error) {
+	fake.GetPipelineTemplateStub = nil
+	fake.getPipelineTemplateReturns = struct {
+		result1 db.PipelineTemplate
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) UpdateRoles(roles []atc.TeamRoleBinding) (db.SavedTeam, error) {
+	var rolesCopy []atc.TeamRoleBinding
+	if roles != nil {
+		rolesCopy = make([]atc.TeamRoleBinding, len(roles))
+		copy(rolesCopy, roles)
+	}
+	fake.updateRolesMutex.Lock()
+	fake.updateRolesArgsForCall = append(fake.updateRolesArgsForCall, struct {
+		roles []atc.TeamRoleBinding
+	}{rolesCopy})
+	fake.recordInvocation("UpdateRoles", []interface{}{rolesCopy})
+	fake.updateRolesMutex.Unlock()
+	if fake.UpdateRolesStub != nil {
+		return fake.UpdateRolesStub(roles)
+	} else {
+		return fake.updateRolesReturns.result1, fake.updateRolesReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) UpdateRolesCallCount() int {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return len(fake.updateRolesArgsForCall)
+}
+
+func (fake *FakeTeamDB) UpdateRolesArgsForCall(i int) []atc.TeamRoleBinding {
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	return fake.updateRolesArgsForCall[i].roles
+}
+
+func (fake *FakeTeamDB) UpdateRolesReturns(result1 db.SavedTeam, result2 error) {
+	fake.UpdateRolesStub = nil
+	fake.updateRolesReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetAuditEvents(page db.Page) ([]db.AuditEvent, db.Pagination, error) {
+	fake.getAuditEventsMutex.Lock()
+	fake.getAuditEventsArgsForCall = append(fake.getAuditEventsArgsForCall, struct {
+		page db.Page
+	}{page})
+	fake.recordInvocation("GetAuditEvents", []interface{}{page})
+	fake.getAuditEventsMutex.Unlock()
+	if fake.GetAuditEventsStub != nil {
+		return fake.GetAuditEventsStub(page)
+	} else {
+		return fake.getAuditEventsReturns.result1, fake.getAuditEventsReturns.result2, fake.getAuditEventsReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetAuditEventsCallCount() int {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return len(fake.getAuditEventsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetAuditEventsArgsForCall(i int) db.Page {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return fake.getAuditEventsArgsForCall[i].page
+}
+
+func (fake *FakeTeamDB) GetAuditEventsReturns(result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.GetAuditEventsStub = nil
+	fake.getAuditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) SaveConfigAs(savedBy string, pipelineName string, config atc.Config, pipelineTemplate db.PipelineTemplate, from db.ConfigVersion, pausedState db.PipelinePausedState) (db.SavedPipeline, bool, error) {
+	fake.saveConfigAsMutex.Lock()
+	fake.saveConfigAsArgsForCall = append(fake.saveConfigAsArgsForCall, struct {
+		savedBy          string
+		pipelineName     string
+		config           atc.Config
+		pipelineTemplate db.PipelineTemplate
+		from             db.ConfigVersion
+		pausedState      db.PipelinePausedState
+	}{savedBy, pipelineName, config, pipelineTemplate, from, pausedState})
+	fake.recordInvocation("SaveConfigAs", []interface{}{savedBy, pipelineName, config, pipelineTemplate, from, pausedState})
+	fake.saveConfigAsMutex.Unlock()
+	if fake.SaveConfigAsStub != nil {
+		return fake.SaveConfigAsStub(savedBy, pipelineName, config, pipelineTemplate, from, pausedState)
+	} else {
+		return fake.saveConfigAsReturns.result1, fake.saveConfigAsReturns.result2, fake.saveConfigAsReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) SaveConfigAsCallCount() int {
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	return len(fake.saveConfigAsArgsForCall)
+}
+
+func (fake *FakeTeamDB) SaveConfigAsArgsForCall(i int) (string, string, atc.Config, db.PipelineTemplate, db.ConfigVersion, db.PipelinePausedState) {
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	return fake.saveConfigAsArgsForCall[i].savedBy, fake.saveConfigAsArgsForCall[i].pipelineName, fake.saveConfigAsArgsForCall[i].config, fake.saveConfigAsArgsForCall[i].pipelineTemplate, fake.saveConfigAsArgsForCall[i].from, fake.saveConfigAsArgsForCall[i].pausedState
+}
+
+func (fake *FakeTeamDB) SaveConfigAsReturns(result1 db.SavedPipeline, result2 bool, result3 error) {
+	fake.SaveConfigAsStub = nil
+	fake.saveConfigAsReturns = struct {
+		result1 db.SavedPipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeamDB) GetConfigVersions(pipelineName string) ([]db.SavedConfigVersion, error) {
+	fake.getConfigVersionsMutex.Lock()
+	fake.getConfigVersionsArgsForCall = append(fake.getConfigVersionsArgsForCall, struct {
+		pipelineName string
+	}{pipelineName})
+	fake.recordInvocation("GetConfigVersions", []interface{}{pipelineName})
+	fake.getConfigVersionsMutex.Unlock()
+	if fake.GetConfigVersionsStub != nil {
+		return fake.GetConfigVersionsStub(pipelineName)
+	} else {
+		return fake.getConfigVersionsReturns.result1, fake.getConfigVersionsReturns.result2
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsCallCount() int {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return len(fake.getConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsArgsForCall(i int) string {
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	return fake.getConfigVersionsArgsForCall[i].pipelineName
+}
+
+func (fake *FakeTeamDB) GetConfigVersionsReturns(result1 []db.SavedConfigVersion, result2 error) {
+	fake.GetConfigVersionsStub = nil
+	fake.getConfigVersionsReturns = struct {
+		result1 []db.SavedConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamDB) GetConfigVersion(pipelineName string, version db.ConfigVersion) (db.SavedConfigVersion, bool, error) {
+	fake.getConfigVersionMutex.Lock()
+	fake.getConfigVersionArgsForCall = append(fake.getConfigVersionArgsForCall, struct {
+		pipelineName string
+		version      db.ConfigVersion
+	}{pipelineName, version})
+	fake.recordInvocation("GetConfigVersion", []interface{}{pipelineName, version})
+	fake.getConfigVersionMutex.Unlock()
+	if fake.GetConfigVersionStub != nil {
+		return fake.GetConfigVersionStub(pipelineName, version)
+	} else {
+		return fake.getConfigVersionReturns.result1, fake.getConfigVersionReturns.result2, fake.getConfigVersionReturns.result3
+	}
+}
+
+func (fake *FakeTeamDB) GetConfigVersionCallCount() int {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return len(fake.getConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeamDB) GetConfigVersionArgsForCall(i int) (string, db.ConfigVersion) {
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
+	return fake.getConfigVersionArgsForCall[i].pipelineName, fake.getConfigVersionArgsForCall[i].version
+}
+
+func (fake *FakeTeamDB) GetConfigVersionReturns(result1 db.SavedConfigVersion, result2 bool, result3 error) {
+	fake.GetConfigVersionStub = nil
+	fake.getConfigVersionReturns = struct {
+		result1 db.SavedConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -779,6 +1062,18 @@ func (fake *FakeTeamDB) Invocations() map[string][][]interface{} {
 	defer fake.findContainersByDescriptorsMutex.RUnlock()
 	fake.getVolumesMutex.RLock()
 	defer fake.getVolumesMutex.RUnlock()
+	fake.getPipelineTemplateMutex.RLock()
+	defer fake.getPipelineTemplateMutex.RUnlock()
+	fake.updateRolesMutex.RLock()
+	defer fake.updateRolesMutex.RUnlock()
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	fake.saveConfigAsMutex.RLock()
+	defer fake.saveConfigAsMutex.RUnlock()
+	fake.getConfigVersionsMutex.RLock()
+	defer fake.getConfigVersionsMutex.RUnlock()
+	fake.getConfigVersionMutex.RLock()
+	defer fake.getConfigVersionMutex.RUnlock()
 	return fake.invocations
 }
 
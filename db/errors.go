@@ -3,3 +3,5 @@ package db
 import "errors"
 
 var ErrMultipleContainersFound = errors.New("multiple containers found for given identifier")
+var ErrCannotPruneRunningWorker = errors.New("worker is running and cannot be pruned")
+var ErrCannotRerunBuild = errors.New("only builds of jobs whose inputs have been determined can be rerun")
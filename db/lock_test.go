@@ -455,6 +455,101 @@ var _ = Describe("Locks", func() {
 		})
 	})
 
+	Describe("EnsureScheduledBuildExists", func() {
+		var slot time.Time
+
+		BeforeEach(func() {
+			slot = time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+			err := pipelineDB.InitJobLastScheduled("some-job", slot.Add(-90*time.Minute))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a pending build and records the slot", func() {
+			triggered, err := pipelineDB.EnsureScheduledBuildExists("some-job", slot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggered).To(BeTrue())
+
+			pendingBuildsForJob, err := pipelineDB.GetPendingBuildsForJob("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuildsForJob).To(HaveLen(1))
+
+			lastScheduled, found, err := pipelineDB.GetJobLastScheduled("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(lastScheduled.Equal(slot)).To(BeTrue())
+		})
+
+		It("does not fire the same slot twice", func() {
+			triggered, err := pipelineDB.EnsureScheduledBuildExists("some-job", slot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggered).To(BeTrue())
+
+			builds, err := pipelineDB.GetPendingBuildsForJob("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+
+			started, err := builds[0].Start("some-engine", "some-metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			triggered, err = pipelineDB.EnsureScheduledBuildExists("some-job", slot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggered).To(BeFalse())
+
+			builds, err = pipelineDB.GetPendingBuildsForJob("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(0))
+		})
+
+		It("does not fire a slot older than the last one", func() {
+			triggered, err := pipelineDB.EnsureScheduledBuildExists("some-job", slot.Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggered).To(BeTrue())
+
+			triggered, err = pipelineDB.EnsureScheduledBuildExists("some-job", slot.Add(-2*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(triggered).To(BeFalse())
+		})
+
+		Context("when a pending build already exists", func() {
+			BeforeEach(func() {
+				err := pipelineDB.EnsurePendingBuildExists("some-job")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the slot without creating another build", func() {
+				triggered, err := pipelineDB.EnsureScheduledBuildExists("some-job", slot)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(triggered).To(BeTrue())
+
+				builds, err := pipelineDB.GetPendingBuildsForJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("InitJobLastScheduled", func() {
+		It("only records the first start", func() {
+			_, found, err := pipelineDB.GetJobLastScheduled("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			first := time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)
+			err = pipelineDB.InitJobLastScheduled("some-job", first)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineDB.InitJobLastScheduled("some-job", first.Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			lastScheduled, found, err := pipelineDB.GetJobLastScheduled("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(lastScheduled.Equal(first)).To(BeTrue())
+		})
+	})
+
 	Describe("AcquireResourceCheckingLock", func() {
 		var someResource db.SavedResource
 
@@ -0,0 +1,27 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateAuditEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE audit_events (
+			id serial PRIMARY KEY,
+			team_id integer REFERENCES teams (id) ON DELETE CASCADE,
+			route text NOT NULL,
+			actor_team_name text NOT NULL DEFAULT '',
+			actor_role text NOT NULL DEFAULT '',
+			actor_is_system boolean NOT NULL DEFAULT false,
+			target json NOT NULL DEFAULT '{}',
+			status integer NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX audit_events_team_id_idx ON audit_events (team_id)
+	`)
+	return err
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreatePipelineConfigVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE pipeline_config_versions (
+			id serial PRIMARY KEY,
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			version integer NOT NULL,
+			config text NOT NULL,
+			template text,
+			variables json NOT NULL DEFAULT '{}',
+			saved_by text NOT NULL DEFAULT '',
+			saved_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (pipeline_id, version)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, template, variables)
+		SELECT id, version, config, template, variables
+		FROM pipelines
+	`)
+	return err
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateBuildGates(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_gates (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			name text NOT NULL,
+			approved boolean,
+			decided_by text,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			decided_at timestamp with time zone,
+			UNIQUE (build_id, plan_id)
+		)
+	`)
+	return err
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddTaskCachesToVolumes(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN task_cache_pipeline_id integer,
+		ADD COLUMN task_cache_job_name text,
+		ADD COLUMN task_cache_step_name text,
+		ADD COLUMN task_cache_path text
+	`)
+	return err
+}
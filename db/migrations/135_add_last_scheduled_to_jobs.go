@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddLastScheduledToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN last_scheduled timestamp with time zone
+	`)
+	return err
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddInputOverridesToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN manual_override boolean NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE build_input_overrides (
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			input_name text NOT NULL,
+			versioned_resource_id integer NOT NULL REFERENCES versioned_resources (id) ON DELETE CASCADE,
+			UNIQUE (build_id, input_name)
+		)
+	`)
+	return err
+}
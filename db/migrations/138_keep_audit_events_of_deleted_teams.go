@@ -0,0 +1,43 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func KeepAuditEventsOfDeletedTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE audit_events
+		ADD COLUMN team_name text NOT NULL DEFAULT '',
+		ADD COLUMN actor_user text NOT NULL DEFAULT ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE audit_events a
+		SET team_name = t.name
+		FROM teams t
+		WHERE a.team_id = t.id
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE audit_events DROP CONSTRAINT audit_events_team_id_fkey
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE audit_events ADD CONSTRAINT audit_events_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX audit_events_created_at_idx ON audit_events (created_at)
+	`)
+	return err
+}
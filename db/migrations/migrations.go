@@ -127,4 +127,16 @@ var Migrations = []migration.Migrator{
 	CascadeTeamDeletes,
 	CascadeTeamDeletesOnPipes,
 	RemoveResourceCheckingFromJobsAndAddManualyTriggeredToBuilds,
+	AddTemplateAndVariablesToPipelines,
+	AddRolesToTeams,
+	CreateAuditEvents,
+	CreatePipelineConfigVersions,
+	CreateBuildGates,
+	AddTaskCachesToVolumes,
+	AddStateToWorkers,
+	AddPinnedVersionToResources,
+	AddLastScheduledToJobs,
+	AddInputOverridesToBuilds,
+	AddRerunOfToBuilds,
+	KeepAuditEventsOfDeletedTeams,
 }
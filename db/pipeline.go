@@ -1,6 +1,9 @@
 package db
 
-import "github.com/concourse/atc"
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
+)
 
 type Pipeline struct {
 	Name    string
@@ -17,3 +20,14 @@ type SavedPipeline struct {
 
 	Pipeline
 }
+
+// PipelineTemplate is the raw, uninterpolated form of a pipeline's config,
+// along with the variables to substitute into it.
+type PipelineTemplate struct {
+	Template  string
+	Variables template.Variables
+}
+
+func (t PipelineTemplate) IsTemplated() bool {
+	return t.Template != ""
+}
@@ -10,7 +10,9 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -21,6 +23,7 @@ type PipelineDB interface {
 	GetPipelineID() int
 	ScopedName(string) string
 	TeamID() int
+	TeamName() string
 	Config() atc.Config
 	ConfigVersion() ConfigVersion
 
@@ -49,6 +52,8 @@ type PipelineDB interface {
 	GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
+	PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error)
+	UnpinResourceVersion(resourceName string, versionedResourceID int) (bool, error)
 	SetResourceCheckError(resource SavedResource, err error) error
 	AcquireResourceCheckingLock(logger lager.Logger, resource SavedResource, length time.Duration, immediate bool) (Lock, bool, error)
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, length time.Duration, immediate bool) (Lock, bool, error)
@@ -67,7 +72,12 @@ type PipelineDB interface {
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateJobBuildWithInputOverrides(job string, overrides map[string]int) (Build, error)
+	GetBuildInputOverrides(buildID int) ([]BuildInput, error)
 	EnsurePendingBuildExists(jobName string) error
+	EnsureScheduledBuildExists(jobName string, scheduledAt time.Time) (bool, error)
+	GetJobLastScheduled(jobName string) (time.Time, bool, error)
+	InitJobLastScheduled(jobName string, startedAt time.Time) error
 	GetPendingBuildsForJob(jobName string) ([]Build, error)
 	GetAllPendingBuilds() (map[string][]Build, error)
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
@@ -153,6 +163,10 @@ func (pdb *pipelineDB) TeamID() int {
 	return pdb.SavedPipeline.TeamID
 }
 
+func (pdb *pipelineDB) TeamName() string {
+	return pdb.SavedPipeline.TeamName
+}
+
 func (pdb *pipelineDB) Config() atc.Config {
 	return pdb.SavedPipeline.Config
 }
@@ -261,10 +275,11 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE pipeline_id = $1
-				AND active = true
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.pipeline_id = $1
+				AND r.active = true
 		`, pdb.ID)
 
 	if err != nil {
@@ -602,20 +617,25 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-				AND active = true
+			SELECT `+resourceColumns+`
+			FROM resources r
+			LEFT OUTER JOIN versioned_resources pv ON pv.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+				AND r.active = true
 		`, name, pdb.ID))
 }
 
+const resourceColumns = "r.id, r.name, r.config, r.check_error, r.paused, r.pinned_version_id, pv.version, r.pin_comment"
+
 func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error) {
 	var checkErr sql.NullString
 	var resource SavedResource
 	var configBlob []byte
+	var pinnedVersionID sql.NullInt64
+	var pinnedVersion, pinComment sql.NullString
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion, &pinComment)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -637,6 +657,17 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, false, err
+		}
+
+		resource.PinComment = pinComment.String
+	}
+
 	return resource, true, nil
 }
 
@@ -734,6 +765,63 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	return tx.Commit()
 }
 
+func (pdb *pipelineDB) PinResourceVersion(resourceName string, versionedResourceID int, comment string) (bool, error) {
+	return pdb.updatePinnedVersion(`
+		UPDATE resources r
+		SET pinned_version_id = $1, pin_comment = $2
+		FROM versioned_resources v
+		WHERE v.id = $1
+			AND v.resource_id = r.id
+			AND r.name = $3
+			AND r.pipeline_id = $4
+	`, versionedResourceID, comment, resourceName, pdb.ID)
+}
+
+func (pdb *pipelineDB) UnpinResourceVersion(resourceName string, versionedResourceID int) (bool, error) {
+	return pdb.updatePinnedVersion(`
+		UPDATE resources
+		SET pinned_version_id = NULL, pin_comment = NULL
+		WHERE pinned_version_id = $1
+			AND name = $2
+			AND pipeline_id = $3
+	`, versionedResourceID, resourceName, pdb.ID)
+}
+
+func (pdb *pipelineDB) updatePinnedVersion(query string, versionedResourceID int, args ...interface{}) (bool, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, append([]interface{}{versionedResourceID}, args...)...)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	// bump the version so that the cached versions DB is invalidated
+	_, err = tx.Exec(`
+		UPDATE versioned_resources
+		SET modified_time = now()
+		WHERE id = $1
+	`, versionedResourceID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
 func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1154,6 +1242,54 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 
 	defer tx.Rollback()
 
+	build, err := pdb.createJobBuild(tx, jobName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// CreateJobBuildWithInputOverrides creates a manually triggered build which
+// will use the given versioned resources, keyed by input name, in place of
+// the versions chosen by the input mapper.
+func (pdb *pipelineDB) CreateJobBuildWithInputOverrides(jobName string, overrides map[string]int) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	build, err := pdb.createJobBuild(tx, jobName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for inputName, versionedResourceID := range overrides {
+		_, err := tx.Exec(`
+			INSERT INTO build_input_overrides (build_id, input_name, versioned_resource_id)
+			VALUES ($1, $2, $3)
+		`, build.ID(), inputName, versionedResourceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+func (pdb *pipelineDB) createJobBuild(tx Tx, jobName string, manualOverride bool) (Build, error) {
 	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, pdb.ID)
 	if err != nil {
 		return nil, err
@@ -1162,14 +1298,14 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	// We had to resort to sub-selects here because you can't paramaterize a
 	// RETURNING statement in lib/pq... sorry
 	build, _, err := pdb.buildFactory.ScanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, job_id, team_id, status, manually_triggered)
-		VALUES ($1, $2, $3, 'pending', TRUE)
+		INSERT INTO builds (name, job_id, team_id, status, manually_triggered, manual_override)
+		VALUES ($1, $2, $3, 'pending', TRUE, $5)
 		RETURNING `+buildColumns+`,
 			(SELECT name FROM jobs WHERE id = $2),
 			(SELECT id FROM pipelines WHERE id = $4),
 			(SELECT name FROM pipelines WHERE id = $4),
 			(SELECT name FROM teams WHERE id = $3)
-	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID))
+	`, buildName, jobID, pdb.SavedPipeline.TeamID, pdb.ID, manualOverride))
 	if err != nil {
 		return nil, err
 	}
@@ -1179,12 +1315,77 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	return build, nil
+}
+
+// GetBuildInputOverrides returns the inputs that were chosen by hand when
+// the build was triggered.
+func (pdb *pipelineDB) GetBuildInputOverrides(buildID int) ([]BuildInput, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT o.input_name, r.name, v.type, v.version, v.metadata,
+			NOT EXISTS (
+				SELECT 1
+				FROM build_inputs i, builds ib
+				WHERE i.build_id = ib.id
+				AND ib.job_id = b.job_id
+				AND i.versioned_resource_id = o.versioned_resource_id
+				AND i.name = o.input_name
+			)
+		FROM build_input_overrides o
+		JOIN builds b ON b.id = o.build_id
+		JOIN versioned_resources v ON v.id = o.versioned_resource_id
+		JOIN resources r ON r.id = v.resource_id
+		WHERE o.build_id = $1
+		ORDER BY o.input_name
+	`, buildID)
 	if err != nil {
 		return nil, err
 	}
 
-	return build, nil
+	defer rows.Close()
+
+	buildInputs := []BuildInput{}
+	for rows.Next() {
+		var (
+			inputName       string
+			resourceName    string
+			resourceType    string
+			versionBlob     string
+			metadataBlob    string
+			firstOccurrence bool
+			version         Version
+			metadata        []MetadataField
+		)
+
+		err := rows.Scan(&inputName, &resourceName, &resourceType, &versionBlob, &metadataBlob, &firstOccurrence)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(versionBlob), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(metadataBlob), &metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		buildInputs = append(buildInputs, BuildInput{
+			Name: inputName,
+			VersionedResource: VersionedResource{
+				Resource:   resourceName,
+				Type:       resourceType,
+				Version:    version,
+				Metadata:   metadata,
+				PipelineID: pdb.ID,
+			},
+			FirstOccurrence: firstOccurrence,
+		})
+	}
+
+	return buildInputs, nil
 }
 
 func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
@@ -1195,11 +1396,88 @@ func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 
 	defer tx.Rollback()
 
-	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, pdb.ID)
+	created, err := pdb.ensurePendingBuildExists(tx, jobName)
 	if err != nil {
 		return err
 	}
 
+	if !created {
+		// roll back so that the build number isn't consumed
+		return nil
+	}
+
+	return tx.Commit()
+}
+
+// EnsureScheduledBuildExists claims the given schedule slot for the job and
+// makes sure a pending build exists for it. Claiming only succeeds if the slot
+// is later than the last one claimed, so that a slot is only ever fired once
+// regardless of how many ATCs evaluate it. It returns false if the slot had
+// already been claimed.
+func (pdb *pipelineDB) EnsureScheduledBuildExists(jobName string, scheduledAt time.Time) (bool, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE jobs
+		SET last_scheduled = $1
+		WHERE name = $2
+			AND pipeline_id = $3
+			AND (last_scheduled IS NULL OR last_scheduled < $1)
+	`, scheduledAt, jobName, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	var pendingExists bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM builds b, jobs j
+			WHERE b.job_id = j.id
+				AND j.name = $1
+				AND j.pipeline_id = $2
+				AND b.status = 'pending'
+		)
+	`, jobName, pdb.ID).Scan(&pendingExists)
+	if err != nil {
+		return false, err
+	}
+
+	if !pendingExists {
+		_, err = pdb.ensurePendingBuildExists(tx, jobName)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (pdb *pipelineDB) ensurePendingBuildExists(tx Tx, jobName string) (bool, error) {
+	buildName, jobID, err := getNewBuildNameForJob(tx, jobName, pdb.ID)
+	if err != nil {
+		return false, err
+	}
+
 	rows, err := tx.Query(`
 		INSERT INTO builds (name, job_id, team_id, status)
 		SELECT $1, $2, $3, 'pending'
@@ -1208,29 +1486,65 @@ func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 		RETURNING id
 	`, buildName, jobID, pdb.SavedPipeline.TeamID)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	defer rows.Close()
 
-	if rows.Next() {
-		var buildID int
-		err := rows.Scan(&buildID)
-		if err != nil {
-			return err
-		}
+	if !rows.Next() {
+		return false, nil
+	}
 
-		rows.Close()
+	var buildID int
+	err = rows.Scan(&buildID)
+	if err != nil {
+		return false, err
+	}
 
-		err = createBuildEventSeq(tx, buildID)
-		if err != nil {
-			return err
+	rows.Close()
+
+	err = createBuildEventSeq(tx, buildID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (pdb *pipelineDB) GetJobLastScheduled(jobName string) (time.Time, bool, error) {
+	var lastScheduled pq.NullTime
+	err := pdb.conn.QueryRow(`
+		SELECT last_scheduled
+		FROM jobs
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, jobName, pdb.ID).Scan(&lastScheduled)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, false, nil
 		}
 
-		return tx.Commit()
+		return time.Time{}, false, err
 	}
 
-	return nil
+	if !lastScheduled.Valid {
+		return time.Time{}, false, nil
+	}
+
+	return lastScheduled.Time, true, nil
+}
+
+// InitJobLastScheduled records the time from which a job's schedule starts
+// counting, unless it has already been recorded.
+func (pdb *pipelineDB) InitJobLastScheduled(jobName string, startedAt time.Time) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE jobs
+		SET last_scheduled = $1
+		WHERE name = $2
+			AND pipeline_id = $3
+			AND last_scheduled IS NULL
+	`, startedAt, jobName, pdb.ID)
+	return err
 }
 
 func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
@@ -1469,7 +1783,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT `+qualifiedBuildColumns+`
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1572,6 +1886,7 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 		refs[i] = fmt.Sprintf("$%d", i+2)
 	}
 
+	// builds parked at an undecided gate do not count as running
 	rows, err := pdb.conn.Query(`
 		SELECT DISTINCT `+qualifiedBuildColumns+`
 		FROM builds b
@@ -1586,6 +1901,12 @@ func (pdb *pipelineDB) GetRunningBuildsBySerialGroup(jobName string, serialGroup
 				(b.scheduled = true AND b.status = 'pending')
 			)
 			AND j.pipeline_id = $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM build_gates g
+				WHERE g.build_id = b.id
+				AND g.approved IS NULL
+			)
 	`, args...)
 
 	if err != nil {
@@ -1642,7 +1963,7 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
+func (pdb *pipelineDB) getLatestModifiedTime(pipelineID int) (time.Time, error) {
 	var max_modified_time time.Time
 
 	err := pdb.conn.QueryRow(`
@@ -1673,17 +1994,79 @@ func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
 			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
 			WHERE r.pipeline_id = $1
 		) vr
-	`, pdb.ID).Scan(&max_modified_time)
+	`, pipelineID).Scan(&max_modified_time)
 
 	return max_modified_time, err
 }
 
+// crossPipelinePassedJobs returns the passed constraints of the pipeline's
+// jobs which refer to jobs in other pipelines, as "pipeline/job".
+func (pdb *pipelineDB) crossPipelinePassedJobs() []string {
+	seen := map[string]bool{}
+	passedJobs := []string{}
+
+	for _, job := range pdb.Config().Jobs {
+		for _, input := range config.JobInputs(job) {
+			for _, passed := range input.Passed {
+				if seen[passed] {
+					continue
+				}
+
+				if _, found := pdb.Config().Jobs.Lookup(passed); found {
+					continue
+				}
+
+				if _, _, crossPipeline := config.CrossPipelineJob(passed); !crossPipeline {
+					continue
+				}
+
+				seen[passed] = true
+				passedJobs = append(passedJobs, passed)
+			}
+		}
+	}
+
+	return passedJobs
+}
+
 func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
+	crossPipelineJobs := pdb.crossPipelinePassedJobs()
+
+	latestModifiedTime, err := pdb.getLatestModifiedTime(pdb.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	// builds of jobs in other pipelines must invalidate the cache too, as
+	// they may satisfy passed constraints of this pipeline's inputs
+	for _, passed := range crossPipelineJobs {
+		pipelineName, _, _ := config.CrossPipelineJob(passed)
+
+		var pipelineID int
+		err := pdb.conn.QueryRow(`
+			SELECT id
+			FROM pipelines
+			WHERE name = $1
+			AND team_id = $2
+		`, pipelineName, pdb.SavedPipeline.TeamID).Scan(&pipelineID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return nil, err
+		}
+
+		modifiedTime, err := pdb.getLatestModifiedTime(pipelineID)
+		if err != nil {
+			return nil, err
+		}
+
+		if modifiedTime.After(latestModifiedTime) {
+			latestModifiedTime = modifiedTime
+		}
+	}
+
 	if pdb.versionsDB != nil && pdb.versionsDB.CachedAt.Equal(latestModifiedTime) {
 		return pdb.versionsDB, nil
 	}
@@ -1694,6 +2077,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 		ResourceVersions: []algorithm.ResourceVersion{},
 		JobIDs:           map[string]int{},
 		ResourceIDs:      map[string]int{},
+		PinnedVersions:   map[int]int{},
 		CachedAt:         latestModifiedTime,
 	}
 
@@ -1792,7 +2176,7 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	}
 
 	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
+    SELECT r.name, r.id, r.pinned_version_id
     FROM resources r
     WHERE r.pipeline_id = $1
   `, pdb.ID)
@@ -1803,12 +2187,24 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	for rows.Next() {
 		var name string
 		var id int
-		err := rows.Scan(&name, &id)
+		var pinnedVersionID sql.NullInt64
+		err := rows.Scan(&name, &id, &pinnedVersionID)
 		if err != nil {
 			return nil, err
 		}
 
 		db.ResourceIDs[name] = id
+
+		if pinnedVersionID.Valid {
+			db.PinnedVersions[id] = int(pinnedVersionID.Int64)
+		}
+	}
+
+	for _, passed := range crossPipelineJobs {
+		err := pdb.loadCrossPipelineBuildOutputs(db, passed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	pdb.versionsDB = db
@@ -1816,6 +2212,78 @@ func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	return db, nil
 }
 
+// loadCrossPipelineBuildOutputs adds the outputs of a job in another pipeline
+// to the versions DB, so that they can satisfy passed constraints referring to
+// it as "pipeline/job".
+//
+// Versions are matched up with this pipeline's versions of the resource of the
+// same name, type and source, so that unrelated resources which happen to share
+// a name don't satisfy each other's constraints. The sources are compared as
+// the JSON they were saved as, which has its keys in a consistent order. Since the build IDs and job IDs are unique across
+// pipelines, the algorithm can treat the job like any other. Only outputs of
+// succeeded builds are loaded, which never change, so this does not require
+// holding the other pipeline's scheduling lock.
+func (pdb *pipelineDB) loadCrossPipelineBuildOutputs(db *algorithm.VersionsDB, passed string) error {
+	pipelineName, jobName, _ := config.CrossPipelineJob(passed)
+
+	var jobID int
+	err := pdb.conn.QueryRow(`
+		SELECT j.id
+		FROM jobs j, pipelines p
+		WHERE p.id = j.pipeline_id
+		AND p.name = $1
+		AND p.team_id = $2
+		AND j.name = $3
+	`, pipelineName, pdb.SavedPipeline.TeamID, jobName).Scan(&jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	db.JobIDs[passed] = jobID
+
+	rows, err := pdb.conn.Query(`
+		SELECT lv.id, lv.check_order, lr.id, o.build_id
+		FROM build_outputs o, builds b, versioned_resources v, resources r, versioned_resources lv, resources lr
+		WHERE v.id = o.versioned_resource_id
+		AND b.id = o.build_id
+		AND r.id = v.resource_id
+		AND b.job_id = $1
+		AND b.status = 'succeeded'
+		AND v.enabled
+		AND lr.pipeline_id = $2
+		AND lr.name = r.name
+		AND lr.config->>'type' = r.config->>'type'
+		AND (lr.config->'source')::text = (r.config->'source')::text
+		AND lv.resource_id = lr.id
+		AND lv.type = v.type
+		AND lv.version = v.version
+		AND lv.enabled
+	`, jobID, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		output := algorithm.BuildOutput{JobID: jobID}
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID)
+		if err != nil {
+			return err
+		}
+
+		output.ResourceVersion.CheckOrder = output.CheckOrder
+
+		db.BuildOutputs = append(db.BuildOutputs, output)
+	}
+
+	return nil
+}
+
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
 	var versionBytes, metadataBytes string
 
@@ -1035,6 +1035,167 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Context("when a job's input is passed through a job in another pipeline", func() {
+			var downstreamPipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				downstreamPipeline, _, err := teamDB.SaveConfig("downstream-pipeline", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "some-type",
+							Source: atc.Source{"source-config": "some-value"},
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: []string{"other-pipeline-name/a-job"},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamPipelineDB = pipelineDBFactory.Build(downstreamPipeline)
+			})
+
+			It("includes the successful outputs of the other pipeline's job for the same versions", func() {
+				resourceConfig := atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"source-config": "some-value"},
+				}
+
+				err := otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = downstreamPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamVR, found, err := downstreamPipelineDB.GetLatestVersionedResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamResource, _, err := downstreamPipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamJob, found, err := otherPipelineDB.GetJob("a-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+				Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/a-job", upstreamJob.ID))
+
+				By("not including outputs of failed builds")
+				failedBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = failedBuild.SaveOutput(upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = failedBuild.Finish(db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+
+				By("including outputs of successful builds, invalidating the cache")
+				succeededBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = succeededBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = succeededBuild.SaveOutput(upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(ConsistOf(
+					algorithm.BuildOutput{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  downstreamVR.ID,
+							ResourceID: downstreamResource.ID,
+							CheckOrder: downstreamVR.CheckOrder,
+						},
+						JobID:   upstreamJob.ID,
+						BuildID: succeededBuild.ID(),
+					},
+				))
+			})
+
+			Context("when the resource of the same name has a different source", func() {
+				BeforeEach(func() {
+					_, _, err := teamDB.SaveConfig("downstream-pipeline", atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   "some-type",
+								Source: atc.Source{"source-config": "some-other-value"},
+							},
+						},
+						Jobs: atc.JobConfigs{
+							{
+								Name: "downstream-job",
+								Plan: atc.PlanSequence{
+									{
+										Get:    "some-resource",
+										Passed: []string{"other-pipeline-name/a-job"},
+									},
+								},
+							},
+						},
+					}, downstreamPipelineDB.ConfigVersion(), db.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not match up its versions with the other pipeline's outputs", func() {
+					err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "some-value"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					upstreamVR, found, err := otherPipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"source-config": "some-other-value"},
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					succeededBuild, err := otherPipelineDB.CreateJobBuild("a-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = succeededBuild.SaveOutput(upstreamVR.VersionedResource, false)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = succeededBuild.Finish(db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
+					versions, err := downstreamPipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versions.BuildOutputs).To(BeEmpty())
+				})
+			})
+		})
+
 		Describe("GetVersionedResourceByVersion", func() {
 			var savedVersion2 db.SavedVersionedResource
 			BeforeEach(func() {
@@ -1295,6 +1456,78 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("pinning resource versions", func() {
+			var savedVR db.SavedVersionedResource
+
+			BeforeEach(func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   resource.Name,
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				savedVR, found, err = pipelineDB.GetLatestVersionedResource(resource.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("starts out unpinned", func() {
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.PinnedVersionID).To(BeZero())
+				Expect(unpinnedResource.PinnedVersion).To(BeNil())
+			})
+
+			It("can be pinned and unpinned, invalidating the versions DB", func() {
+				versionsDB, err := pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersions).To(BeEmpty())
+
+				found, err := pipelineDB.PinResourceVersion(resourceName, savedVR.ID, "broken upstream")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				pinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinnedResource.PinnedVersionID).To(Equal(savedVR.ID))
+				Expect(pinnedResource.PinnedVersion).To(Equal(db.Version{"version": "1"}))
+				Expect(pinnedResource.PinComment).To(Equal("broken upstream"))
+
+				otherPipelineResource, _, err := otherPipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(otherPipelineResource.PinnedVersionID).To(BeZero())
+
+				versionsDB, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersions).To(Equal(map[int]int{resource.ID: savedVR.ID}))
+
+				found, err = pipelineDB.UnpinResourceVersion(resourceName, savedVR.ID+1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				found, err = pipelineDB.UnpinResourceVersion(resourceName, savedVR.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				unpinnedResource, _, err := pipelineDB.GetResource(resourceName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unpinnedResource.PinnedVersionID).To(BeZero())
+				Expect(unpinnedResource.PinComment).To(BeEmpty())
+
+				versionsDB, err = pipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB.PinnedVersions).To(BeEmpty())
+			})
+
+			It("does not pin a version of another resource", func() {
+				found, err := pipelineDB.PinResourceVersion(otherResource.Name, savedVR.ID, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the resource or version is bogus", func() {
 				err := pipelineDB.EnableVersionedResource(42)
@@ -2094,6 +2327,81 @@ var _ = Describe("PipelineDB", func() {
 				Expect(build.IsScheduled()).To(BeFalse())
 				Expect(build.TeamName()).To(Equal("some-team"))
 				Expect(build.IsManuallyTriggered()).To(BeTrue())
+				Expect(build.IsManualOverride()).To(BeFalse())
+			})
+		})
+
+		Describe("CreateJobBuildWithInputOverrides", func() {
+			var build db.Build
+			var savedVersion db.SavedVersionedResource
+
+			BeforeEach(func() {
+				resourceConfig := atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}
+
+				err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"ver": "1"}, {"ver": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var found bool
+				savedVersion, found, err = pipelineDB.GetVersionedResourceByVersion(atc.Version{"ver": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err = pipelineDB.CreateJobBuildWithInputOverrides("some-job", map[string]int{
+					"some-input": savedVersion.ID,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a manually triggered build recorded as a manual override", func() {
+				Expect(build.JobName()).To(Equal("some-job"))
+				Expect(build.Status()).To(Equal(db.StatusPending))
+				Expect(build.IsManuallyTriggered()).To(BeTrue())
+				Expect(build.IsManualOverride()).To(BeTrue())
+
+				pendingBuilds, err := pipelineDB.GetPendingBuildsForJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].IsManualOverride()).To(BeTrue())
+			})
+
+			It("saves the overrides", func() {
+				overrides, err := pipelineDB.GetBuildInputOverrides(build.ID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(overrides).To(Equal([]db.BuildInput{
+					{
+						Name: "some-input",
+						VersionedResource: db.VersionedResource{
+							Resource:   "some-resource",
+							Type:       "some-type",
+							Version:    db.Version{"ver": "1"},
+							PipelineID: savedPipeline.ID,
+						},
+						FirstOccurrence: true,
+					},
+				}))
+			})
+
+			Context("when the version has already been used by the input", func() {
+				BeforeEach(func() {
+					otherBuild, err := pipelineDB.CreateJobBuild("some-job")
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = pipelineDB.SaveInput(otherBuild.ID(), db.BuildInput{
+						Name:              "some-input",
+						VersionedResource: savedVersion.VersionedResource,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("is not a first occurrence", func() {
+					overrides, err := pipelineDB.GetBuildInputOverrides(build.ID())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(overrides).To(HaveLen(1))
+					Expect(overrides[0].FirstOccurrence).To(BeFalse())
+				})
 			})
 		})
 
@@ -2543,6 +2851,36 @@ var _ = Describe("PipelineDB", func() {
 					}
 					Expect(ids).To(ConsistOf([]int{startedBuild.ID(), scheduledBuild.ID()}))
 				})
+
+				Context("when a started build is waiting at a gate", func() {
+					BeforeEach(func() {
+						err := startedBuild.WaitForGate("some-plan-id", "some-gate")
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("does not count it as running", func() {
+						builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", []string{"serial-group"})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(len(builds)).To(Equal(1))
+						Expect(builds[0].ID()).To(Equal(scheduledBuild.ID()))
+					})
+
+					Context("once the gate is decided", func() {
+						BeforeEach(func() {
+							decided, err := startedBuild.DecideGate("some-plan-id", true, "some-team")
+							Expect(err).NotTo(HaveOccurred())
+							Expect(decided).To(BeTrue())
+						})
+
+						It("counts it as running again", func() {
+							builds, err := pipelineDB.GetRunningBuildsBySerialGroup("some-job", []string{"serial-group"})
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(len(builds)).To(Equal(2))
+						})
+					})
+				})
 			})
 
 			Describe("multiple jobs with same serial group", func() {
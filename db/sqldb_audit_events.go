@@ -0,0 +1,160 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/atc"
+)
+
+const auditEventColumns = "a.id, a.team_name, a.route, a.target, a.status, a.actor_team_name, a.actor_user, a.actor_role, a.actor_is_system, a.created_at"
+
+func (db *SQLDB) CreateAuditEvent(event AuditEvent) error {
+	target, err := json.Marshal(event.Target)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO audit_events (team_id, team_name, route, target, status, actor_team_name, actor_user, actor_role, actor_is_system)
+		SELECT t.id, COALESCE(t.name, $1), $2, $3, $4, $5, $6, $7, $8
+		FROM (SELECT 1) AS one
+		LEFT JOIN teams t ON LOWER(t.name) = LOWER($1)
+	`, event.TeamName, event.Route, string(target), event.Status, event.ActorTeamName, event.ActorUser, string(event.ActorRole), event.ActorIsSystem)
+
+	return err
+}
+
+// DeleteAuditEventsBefore deletes the audit events recorded before the given
+// time, returning how many were deleted.
+func (db *SQLDB) DeleteAuditEventsBefore(before time.Time) (int, error) {
+	result, err := db.conn.Exec(`
+		DELETE FROM audit_events
+		WHERE created_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func (db *teamDB) GetAuditEvents(page Page) ([]AuditEvent, Pagination, error) {
+	eventsQuery := sq.Select(auditEventColumns).From("audit_events a").
+		Join("teams t ON a.team_id = t.id").
+		Where(sq.Eq{"LOWER(t.name)": strings.ToLower(db.teamName)})
+
+	if page.Since == 0 && page.Until == 0 {
+		eventsQuery = eventsQuery.OrderBy("a.id DESC").Limit(uint64(page.Limit))
+	} else if page.Until != 0 {
+		eventsQuery = eventsQuery.Where(sq.Gt{"a.id": uint64(page.Until)}).OrderBy("a.id ASC").Limit(uint64(page.Limit))
+		eventsQuery = sq.Select("sub.*").FromSelect(eventsQuery, "sub").OrderBy("sub.id DESC")
+	} else {
+		eventsQuery = eventsQuery.Where(sq.Lt{"a.id": page.Since}).OrderBy("a.id DESC").Limit(uint64(page.Limit))
+	}
+
+	query, args, err := eventsQuery.PlaceholderFormat(sq.Dollar).ToSql()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []AuditEvent{}
+
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	var minID int
+	var maxID int
+
+	err = db.conn.QueryRow(`
+		SELECT COALESCE(MAX(a.id), 0), COALESCE(MIN(a.id), 0)
+		FROM audit_events a
+		JOIN teams t ON a.team_id = t.id
+		WHERE LOWER(t.name) = LOWER($1)
+	`, db.teamName).Scan(&maxID, &minID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	var pagination Pagination
+
+	if first.ID < maxID {
+		pagination.Previous = &Page{
+			Until: first.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	if last.ID > minID {
+		pagination.Next = &Page{
+			Since: last.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
+
+func scanAuditEvent(row scannable) (AuditEvent, error) {
+	var event AuditEvent
+	var targetBlob []byte
+	var role string
+
+	err := row.Scan(
+		&event.ID,
+		&event.TeamName,
+		&event.Route,
+		&targetBlob,
+		&event.Status,
+		&event.ActorTeamName,
+		&event.ActorUser,
+		&role,
+		&event.ActorIsSystem,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	var target map[string]string
+	err = json.Unmarshal(targetBlob, &target)
+	if err != nil {
+		return AuditEvent{}, err
+	}
+
+	if len(target) > 0 {
+		event.Target = target
+	}
+
+	event.ActorRole = atc.TeamRole(role)
+
+	return event, nil
+}
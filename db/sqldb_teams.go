@@ -11,7 +11,7 @@ import (
 
 func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth FROM teams
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles FROM teams
 	`)
 	if err != nil {
 		return nil, err
@@ -106,14 +106,19 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 		return SavedTeam{}, err
 	}
 
+	jsonEncodedRoles, err := encodeRoles(team.Roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
 	savedTeam, err := scanTeam(db.conn.QueryRow(`
 	INSERT INTO teams (
-    name, basic_auth, github_auth, uaa_auth, genericoauth_auth
+    name, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 	) VALUES (
-		$1, $2, $3, $4, $5
+		$1, $2, $3, $4, $5, $6
 	)
-	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
-	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth)))
+	RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
+	`, team.Name, jsonEncodedBasicAuth, string(jsonEncodedGitHubAuth), string(jsonEncodedUAAAuth), string(jsonEncodedGenericOAuth), jsonEncodedRoles))
 	if err != nil {
 		return SavedTeam{}, err
 	}
@@ -129,8 +134,18 @@ func (db *SQLDB) CreateTeam(team Team) (SavedTeam, error) {
 	return savedTeam, nil
 }
 
+func encodeRoles(roles []atc.TeamRoleBinding) (string, error) {
+	if roles == nil {
+		roles = []atc.TeamRoleBinding{}
+	}
+
+	jsonEncodedRoles, err := json.Marshal(roles)
+	return string(jsonEncodedRoles), err
+}
+
 func scanTeam(rows scannable) (SavedTeam, error) {
 	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var roles []byte
 	var savedTeam SavedTeam
 
 	err := rows.Scan(
@@ -141,6 +156,7 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -174,6 +190,16 @@ func scanTeam(rows scannable) (SavedTeam, error) {
 		}
 	}
 
+	var roleBindings []atc.TeamRoleBinding
+	err = json.Unmarshal(roles, &roleBindings)
+	if err != nil {
+		return savedTeam, err
+	}
+
+	if len(roleBindings) > 0 {
+		savedTeam.Roles = roleBindings
+	}
+
 	return savedTeam, nil
 }
 
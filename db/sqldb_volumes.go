@@ -79,6 +79,23 @@ func (db *SQLDB) InsertVolume(data Volume) error {
 		columns = append(columns, "replicated_from")
 		params = append(params, data.Identifier.Replication.ReplicatedVolumeHandle)
 		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+	case data.Identifier.TaskCache != nil:
+		columns = append(columns, "task_cache_pipeline_id")
+		params = append(params, data.Identifier.TaskCache.PipelineID)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_job_name")
+		params = append(params, data.Identifier.TaskCache.JobName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_step_name")
+		params = append(params, data.Identifier.TaskCache.StepName)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
+
+		columns = append(columns, "task_cache_path")
+		params = append(params, data.Identifier.TaskCache.Path)
+		values = append(values, fmt.Sprintf("$%d", len(params)))
 	}
 
 	_, err = tx.Exec(
@@ -129,7 +146,11 @@ func (db *SQLDB) GetVolumes() ([]SavedVolume, error) {
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v
 		` + volumeJoins + `
 		WHERE (v.expires_at IS NULL OR v.expires_at > NOW())
@@ -171,6 +192,12 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 		}
 	case id.Replication != nil:
 		addParam("replicated_from", id.Replication.ReplicatedVolumeHandle)
+	case id.TaskCache != nil:
+		addParam("worker_name", id.TaskCache.WorkerName)
+		addParam("task_cache_pipeline_id", id.TaskCache.PipelineID)
+		addParam("task_cache_job_name", id.TaskCache.JobName)
+		addParam("task_cache_step_name", id.TaskCache.StepName)
+		addParam("task_cache_path", id.TaskCache.Path)
 	}
 
 	statement := `
@@ -189,7 +216,11 @@ func (db *SQLDB) GetVolumesByIdentifier(id VolumeIdentifier) ([]SavedVolume, err
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v` + volumeJoins
 
 	statement += "WHERE " + strings.Join(conditions, " AND ")
@@ -227,7 +258,11 @@ func (db *SQLDB) GetVolumesForOneOffBuildImageResources() ([]SavedVolume, error)
 			v.host_path_version,
 			v.size_in_bytes,
 			c.ttl,
-			v.team_id
+			v.team_id,
+			v.task_cache_pipeline_id,
+			v.task_cache_job_name,
+			v.task_cache_step_name,
+			v.task_cache_path
 		FROM volumes v ` + volumeJoins + `
 			INNER JOIN image_resource_versions i
 				ON i.version = v.resource_version
@@ -335,6 +370,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			path                 sql.NullString
 			hostPathVersion      sql.NullString
 			teamID               sql.NullInt64
+			taskCachePipelineID  sql.NullInt64
+			taskCacheJobName     sql.NullString
+			taskCacheStepName    sql.NullString
+			taskCachePath        sql.NullString
 		)
 
 		err := rows.Scan(
@@ -353,6 +392,10 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 			&volume.SizeInBytes,
 			&volume.ContainerTTL,
 			&teamID,
+			&taskCachePipelineID,
+			&taskCacheJobName,
+			&taskCacheStepName,
+			&taskCachePath,
 		)
 		if err != nil {
 			return []SavedVolume{}, err
@@ -396,6 +439,14 @@ func scanVolumes(rows *sql.Rows) ([]SavedVolume, error) {
 				WorkerName: volume.WorkerName,
 				Version:    &hostPathVersion.String,
 			}
+		case taskCachePath.Valid:
+			volume.Volume.Identifier.TaskCache = &TaskCacheIdentifier{
+				WorkerName: volume.WorkerName,
+				PipelineID: int(taskCachePipelineID.Int64),
+				JobName:    taskCacheJobName.String,
+				StepName:   taskCacheStepName.String,
+				Path:       taskCachePath.String,
+			}
 		}
 
 		volumes = append(volumes, volume)
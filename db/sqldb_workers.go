@@ -7,8 +7,8 @@ import (
 	"time"
 )
 
-var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, t.name as team_name, team_id"
-var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time"
+var workerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, w.name as name, start_time, w.state, t.name as team_name, team_id"
+var actualWorkerColumns = "EXTRACT(epoch FROM expires - NOW()), addr, baggageclaim_url, http_proxy_url, https_proxy_url, no_proxy, active_containers, resource_types, platform, tags, name, start_time, state"
 
 func (db *SQLDB) Workers() ([]SavedWorker, error) {
 	rows, err := db.conn.Query(`
@@ -78,7 +78,7 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 
 	row := db.conn.QueryRow(`
   		UPDATE workers
-      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12
+      SET addr = $1, expires = `+expires+`, active_containers = $2, resource_types = $3, platform = $4, tags = $5, baggageclaim_url = $6, http_proxy_url = $7, https_proxy_url = $8, no_proxy = $9, name = $10, start_time = $11, team_id = $12, state = `+heartbeatState+`
 			WHERE name = $10 OR addr = $1
 			RETURNING  `+actualWorkerColumns,
 		info.GardenAddr, info.ActiveContainers, resourceTypes, info.Platform, tags, info.BaggageclaimURL, info.HTTPProxyURL, info.HTTPSProxyURL, info.NoProxy, info.Name, info.StartTime, teamID)
@@ -100,15 +100,138 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) (SavedWorker, er
 	return savedWorker, nil
 }
 
+// landing and retiring workers keep heartbeating until they're done, and a
+// landed worker stays landed until it restarts
+const heartbeatState = `
+	CASE
+		WHEN state IN ('landing', 'retiring') THEN state
+		WHEN state = 'landed' AND start_time = $11 THEN state
+		ELSE 'running'
+	END`
+
+// containers belonging to builds that are yet to finish
+const inFlightContainersOnWorker = `
+	SELECT 1
+	FROM containers c
+	JOIN builds b ON b.id = c.build_id
+	WHERE c.worker_name = w.name
+	AND b.status IN ('pending', 'started')`
+
+func (db *SQLDB) LandWorker(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		UPDATE workers
+		SET state = CASE
+			WHEN state = 'running' THEN 'landing'
+			WHEN state = 'stalled' THEN 'landed'
+			ELSE state
+		END
+		WHERE name = $1
+	`, name)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (db *SQLDB) RetireWorker(name string) (bool, error) {
+	result, err := db.conn.Exec(`
+		UPDATE workers
+		SET state = 'retiring'
+		WHERE name = $1
+	`, name)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (db *SQLDB) PruneWorker(name string) (bool, error) {
+	var state string
+	err := db.conn.QueryRow(`
+		SELECT state
+		FROM workers
+		WHERE name = $1
+	`, name).Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if WorkerState(state) == WorkerStateRunning {
+		return true, ErrCannotPruneRunningWorker
+	}
+
+	_, err = db.conn.Exec(`
+		DELETE FROM workers
+		WHERE name = $1
+		AND state != 'running'
+	`, name)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReapExpiredWorkers removes retiring workers that stopped heartbeating, and
+// marks any others as stalled, or landed if they were landing.
 func (db *SQLDB) ReapExpiredWorkers() error {
 	_, err := db.conn.Exec(`
 		DELETE FROM workers
+		WHERE state = 'retiring'
+		AND expires IS NOT NULL
+		AND expires < NOW()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		UPDATE workers
+		SET state = CASE
+			WHEN state IN ('landing', 'landed') THEN 'landed'
+			ELSE 'stalled'
+		END, expires = NULL
 		WHERE expires IS NOT NULL
 		AND expires < NOW()
 	`)
 	return err
 }
 
+func (db *SQLDB) LandFinishedLandingWorkers() error {
+	_, err := db.conn.Exec(`
+		UPDATE workers w
+		SET state = 'landed'
+		WHERE state = 'landing'
+		AND NOT EXISTS (` + inFlightContainersOnWorker + `)
+	`)
+	return err
+}
+
+func (db *SQLDB) DeleteFinishedRetiringWorkers() error {
+	_, err := db.conn.Exec(`
+		DELETE FROM workers w
+		WHERE state = 'retiring'
+		AND NOT EXISTS (` + inFlightContainersOnWorker + `)
+	`)
+	return err
+}
+
 func scanWorker(row scannable, scanTeam bool) (SavedWorker, error) {
 	info := SavedWorker{}
 
@@ -121,17 +244,20 @@ func scanWorker(row scannable, scanTeam bool) (SavedWorker, error) {
 	var noProxy sql.NullString
 	var teamName sql.NullString
 	var teamID sql.NullInt64
+	var state string
 	var err error
 
 	if scanTeam {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &teamName, &teamID)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &state, &teamName, &teamID)
 	} else {
-		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime)
+		err = row.Scan(&ttlSeconds, &info.GardenAddr, &info.BaggageclaimURL, &httpProxyURL, &httpsProxyURL, &noProxy, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags, &info.Name, &info.StartTime, &state)
 	}
 	if err != nil {
 		return SavedWorker{}, err
 	}
 
+	info.State = WorkerState(state)
+
 	if ttlSeconds != nil {
 		info.ExpiresIn = time.Duration(*ttlSeconds) * time.Second
 	}
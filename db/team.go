@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 
+	"github.com/concourse/atc"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -14,12 +15,52 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth"`
+
+	Roles []atc.TeamRoleBinding `json:"roles"`
 }
 
 func (t Team) IsAuthConfigured() bool {
 	return t.BasicAuth != nil || t.GitHubAuth != nil || t.UAAAuth != nil
 }
 
+// ForRoleBinding returns a copy of the team whose auth providers only admit
+// the identities listed by the binding. Providers for which the binding lists
+// no identities are left unconfigured, as is basic auth.
+func (t Team) ForRoleBinding(binding atc.TeamRoleBinding) Team {
+	bound := Team{
+		Name:  t.Name,
+		Admin: t.Admin,
+	}
+
+	if t.GitHubAuth != nil && (len(binding.GitHubOrganizations) > 0 || len(binding.GitHubTeams) > 0 || len(binding.GitHubUsers) > 0) {
+		gitHubAuth := *t.GitHubAuth
+		gitHubAuth.Organizations = binding.GitHubOrganizations
+		gitHubAuth.Teams = nil
+		for _, team := range binding.GitHubTeams {
+			gitHubAuth.Teams = append(gitHubAuth.Teams, GitHubTeam{
+				OrganizationName: team.OrganizationName,
+				TeamName:         team.TeamName,
+			})
+		}
+		gitHubAuth.Users = binding.GitHubUsers
+		bound.GitHubAuth = &gitHubAuth
+	}
+
+	if t.UAAAuth != nil && len(binding.CFSpaces) > 0 {
+		uaaAuth := *t.UAAAuth
+		uaaAuth.CFSpaces = binding.CFSpaces
+		bound.UAAAuth = &uaaAuth
+	}
+
+	if t.GenericOAuth != nil && binding.GenericOAuthScope != "" {
+		genericOAuth := *t.GenericOAuth
+		genericOAuth.Scope = binding.GenericOAuthScope
+		bound.GenericOAuth = &genericOAuth
+	}
+
+	return bound
+}
+
 type BasicAuth struct {
 	BasicAuthUsername string `json:"basic_auth_username"`
 	BasicAuthPassword string `json:"basic_auth_password"`
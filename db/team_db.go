@@ -27,13 +27,20 @@ type TeamDB interface {
 	UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error)
 	UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error)
 	UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, error)
+	UpdateRoles(roles []atc.TeamRoleBinding) (SavedTeam, error)
 
 	GetConfig(pipelineName string) (atc.Config, atc.RawConfig, ConfigVersion, error)
+	GetPipelineTemplate(pipelineName string) (PipelineTemplate, bool, error)
 	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (SavedPipeline, bool, error)
+	SaveConfigAs(savedBy string, pipelineName string, config atc.Config, pipelineTemplate PipelineTemplate, from ConfigVersion, pausedState PipelinePausedState) (SavedPipeline, bool, error)
+	GetConfigVersions(pipelineName string) ([]SavedConfigVersion, error)
+	GetConfigVersion(pipelineName string, version ConfigVersion) (SavedConfigVersion, bool, error)
 
 	CreateOneOffBuild() (Build, error)
 	GetPrivateAndPublicBuilds(page Page) ([]Build, Pagination, error)
 
+	GetAuditEvents(page Page) ([]AuditEvent, Pagination, error)
+
 	Workers() ([]SavedWorker, error)
 	GetContainer(handle string) (SavedContainer, bool, error)
 	FindContainersByDescriptors(id Container) ([]SavedContainer, error)
@@ -230,17 +237,94 @@ func (db *teamDB) GetConfig(pipelineName string) (atc.Config, atc.RawConfig, Con
 	return config, atc.RawConfig(string(configBlob)), ConfigVersion(version), nil
 }
 
+// GetPipelineTemplate returns the template and variables that the pipeline's
+// current config was rendered from, if it was saved from one. Templates are
+// rendered when the config is saved rather than whenever it is loaded, so
+// changing the variables means saving the pipeline again.
+func (db *teamDB) GetPipelineTemplate(pipelineName string) (PipelineTemplate, bool, error) {
+	var templateBlob sql.NullString
+	var variablesBlob []byte
+	err := db.conn.QueryRow(`
+		SELECT template, variables
+		FROM pipelines
+		WHERE name = $1 AND team_id = (
+			SELECT id
+			FROM teams
+			WHERE LOWER(name) = LOWER($2)
+		)
+	`, pipelineName, db.teamName).Scan(&templateBlob, &variablesBlob)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineTemplate{}, false, nil
+		}
+		return PipelineTemplate{}, false, err
+	}
+
+	if !templateBlob.Valid {
+		return PipelineTemplate{}, false, nil
+	}
+
+	pipelineTemplate := PipelineTemplate{Template: templateBlob.String}
+	err = json.Unmarshal(variablesBlob, &pipelineTemplate.Variables)
+	if err != nil {
+		return PipelineTemplate{}, false, err
+	}
+
+	return pipelineTemplate, true, nil
+}
+
 func (db *teamDB) SaveConfig(
 	pipelineName string,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig("", pipelineName, config, PipelineTemplate{}, from, pausedState)
+}
+
+// SaveConfigAs saves the config on behalf of savedBy, who is recorded in the
+// pipeline's config history. If the config was rendered from a template, the
+// template and its variables are kept around so that they can be edited and
+// rendered again on the next save. Rendering happens here, at save time, and
+// not when the config is loaded: the pipeline always runs the config that was
+// validated and saved.
+func (db *teamDB) SaveConfigAs(
+	savedBy string,
+	pipelineName string,
+	config atc.Config,
+	pipelineTemplate PipelineTemplate,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
+) (SavedPipeline, bool, error) {
+	return db.saveConfig(savedBy, pipelineName, config, pipelineTemplate, from, pausedState)
+}
+
+func (db *teamDB) saveConfig(
+	savedBy string,
+	pipelineName string,
+	config atc.Config,
+	pipelineTemplate PipelineTemplate,
+	from ConfigVersion,
+	pausedState PipelinePausedState,
 ) (SavedPipeline, bool, error) {
 	payload, err := json.Marshal(config)
 	if err != nil {
 		return SavedPipeline{}, false, err
 	}
 
+	var templatePayload sql.NullString
+	variablesPayload := []byte("{}")
+	if pipelineTemplate.IsTemplated() {
+		templatePayload = sql.NullString{String: pipelineTemplate.Template, Valid: true}
+
+		if pipelineTemplate.Variables != nil {
+			variablesPayload, err = json.Marshal(pipelineTemplate.Variables)
+			if err != nil {
+				return SavedPipeline{}, false, err
+			}
+		}
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return SavedPipeline{}, false, err
@@ -274,20 +358,22 @@ func (db *teamDB) SaveConfig(
 		}
 
 		savedPipeline, err = scanPipeline(tx.QueryRow(`
-		INSERT INTO pipelines (name, config, version, ordering, paused, team_id)
+		INSERT INTO pipelines (name, config, version, ordering, paused, team_id, template, variables)
 		VALUES (
 			$1,
 			$2,
 			nextval('config_version_seq'),
 			(SELECT COUNT(1) + 1 FROM pipelines),
 			$3,
-			$4
+			$4,
+			$5,
+			$6
 		)
 		RETURNING `+unqualifiedPipelineColumns+`,
 		(
 			SELECT t.name as team_name FROM teams t WHERE t.id = $4
 		)
-		`, pipelineName, payload, pausedState.Bool(), teamID))
+		`, pipelineName, payload, pausedState.Bool(), teamID, templatePayload, variablesPayload))
 		if err != nil {
 			return SavedPipeline{}, false, err
 		}
@@ -319,7 +405,7 @@ func (db *teamDB) SaveConfig(
 		if pausedState == PipelineNoChange {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq')
+			SET config = $1, version = nextval('config_version_seq'), template = $5, variables = $6
 			WHERE name = $2
 			AND version = $3
 			AND team_id = $4
@@ -327,11 +413,11 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pipelineName, from, teamID))
+			`, payload, pipelineName, from, teamID, templatePayload, variablesPayload))
 		} else {
 			savedPipeline, err = scanPipeline(tx.QueryRow(`
 			UPDATE pipelines
-			SET config = $1, version = nextval('config_version_seq'), paused = $2
+			SET config = $1, version = nextval('config_version_seq'), paused = $2, template = $6, variables = $7
 			WHERE name = $3
 			AND version = $4
 			AND team_id = $5
@@ -339,7 +425,7 @@ func (db *teamDB) SaveConfig(
 			(
 				SELECT t.name as team_name FROM teams t WHERE t.id = $4
 			)
-			`, payload, pausedState.Bool(), pipelineName, from, teamID))
+			`, payload, pausedState.Bool(), pipelineName, from, teamID, templatePayload, variablesPayload))
 		}
 
 		if err != nil && err != sql.ErrNoRows {
@@ -390,6 +476,14 @@ func (db *teamDB) SaveConfig(
 		}
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO pipeline_config_versions (pipeline_id, version, config, template, variables, saved_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, savedPipeline.ID, savedPipeline.Version, payload, templatePayload, variablesPayload, savedBy)
+	if err != nil {
+		return SavedPipeline{}, false, err
+	}
+
 	for _, resource := range config.Resources {
 		err = db.saveResource(tx, resource, savedPipeline.ID)
 		if err != nil {
@@ -520,7 +614,7 @@ func (db *teamDB) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelin
 
 func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 	query := `
-		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		SELECT id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 		FROM teams
 		WHERE LOWER(name) = LOWER($1)
 	`
@@ -539,6 +633,7 @@ func (db *teamDB) GetTeam() (SavedTeam, bool, error) {
 
 func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, error) {
 	var basicAuth, gitHubAuth, uaaAuth, genericOAuth sql.NullString
+	var roles []byte
 	var savedTeam SavedTeam
 
 	tx, err := db.conn.Begin()
@@ -555,6 +650,7 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		&gitHubAuth,
 		&uaaAuth,
 		&genericOAuth,
+		&roles,
 	)
 	if err != nil {
 		return savedTeam, err
@@ -592,6 +688,16 @@ func (db *teamDB) queryTeam(query string, params []interface{}) (SavedTeam, erro
 		}
 	}
 
+	var roleBindings []atc.TeamRoleBinding
+	err = json.Unmarshal(roles, &roleBindings)
+	if err != nil {
+		return savedTeam, err
+	}
+
+	if len(roleBindings) > 0 {
+		savedTeam.Roles = roleBindings
+	}
+
 	return savedTeam, nil
 }
 
@@ -605,7 +711,7 @@ func (db *teamDB) UpdateBasicAuth(basicAuth *BasicAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 	`
 
 	params := []interface{}{encryptedBasicAuth, db.teamName}
@@ -627,7 +733,7 @@ func (db *teamDB) UpdateGitHubAuth(gitHubAuth *GitHubAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET github_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGitHubAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -643,7 +749,7 @@ func (db *teamDB) UpdateUAAAuth(uaaAuth *UAAAuth) (SavedTeam, error) {
 		UPDATE teams
 		SET uaa_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedUAAAuth), db.teamName}
 	return db.queryTeam(query, params)
@@ -659,12 +765,28 @@ func (db *teamDB) UpdateGenericOAuth(genericOAuth *GenericOAuth) (SavedTeam, err
 		UPDATE teams
 		SET genericoauth_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
 	`
 	params := []interface{}{string(jsonEncodedGenericOAuth), db.teamName}
 	return db.queryTeam(query, params)
 }
 
+func (db *teamDB) UpdateRoles(roles []atc.TeamRoleBinding) (SavedTeam, error) {
+	jsonEncodedRoles, err := encodeRoles(roles)
+	if err != nil {
+		return SavedTeam{}, err
+	}
+
+	query := `
+		UPDATE teams
+		SET roles = $1
+		WHERE LOWER(name) = LOWER($2)
+		RETURNING id, name, admin, basic_auth, github_auth, uaa_auth, genericoauth_auth, roles
+	`
+	params := []interface{}{jsonEncodedRoles, db.teamName}
+	return db.queryTeam(query, params)
+}
+
 func (db *teamDB) CreateOneOffBuild() (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/template"
 )
 
 var _ = Describe("Updating pipeline config for specific team", func() {
@@ -898,4 +899,148 @@ var _ = Describe("Updating pipeline config for specific team", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("with a template", func() {
+		var pipelineTemplate db.PipelineTemplate
+
+		BeforeEach(func() {
+			pipelineTemplate = db.PipelineTemplate{
+				Template: `
+resources:
+- name: some-resource
+  type: some-type
+  source:
+    uri: {{uri}}
+`,
+				Variables: template.Variables{"uri": "some-uri"},
+			}
+		})
+
+		It("stores the template and its variables", func() {
+			_, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", atc.Config{}, pipelineTemplate, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			savedTemplate, found, err := teamDB.GetPipelineTemplate("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(savedTemplate).To(Equal(pipelineTemplate))
+		})
+
+		It("loads the config that was rendered when it was saved", func() {
+			renderedConfig := atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"uri": "some-uri"},
+					},
+				},
+			}
+
+			_, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", renderedConfig, pipelineTemplate, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, found, err := teamDB.GetPipelineByName("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.Config).To(Equal(renderedConfig))
+
+			savedConfig, _, _, err := teamDB.GetConfig("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedConfig).To(Equal(pipeline.Config))
+		})
+
+		It("clears the template when saved without one", func() {
+			_, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", atc.Config{}, pipelineTemplate, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, configVersion, err := teamDB.GetConfig("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig("a-pipeline-name", config, configVersion, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := teamDB.GetPipelineTemplate("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			pipeline, found, err := teamDB.GetPipelineByName("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.Config).To(Equal(config))
+		})
+	})
+
+	Describe("config history", func() {
+		It("keeps every saved config along with who saved it", func() {
+			firstSave, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", config, db.PipelineTemplate{}, 0, db.PipelinePaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			secondSave, _, err := teamDB.SaveConfig("a-pipeline-name", otherConfig, firstSave.Version, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := teamDB.GetConfigVersions("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+
+			Expect(versions[0].Version).To(Equal(secondSave.Version))
+			Expect(versions[0].Config).To(Equal(otherConfig))
+			Expect(versions[0].SavedBy).To(BeEmpty())
+
+			Expect(versions[1].Version).To(Equal(firstSave.Version))
+			Expect(versions[1].Config).To(Equal(config))
+			Expect(versions[1].SavedBy).To(Equal("some-team"))
+			Expect(versions[1].SavedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			version, found, err := teamDB.GetConfigVersion("a-pipeline-name", firstSave.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version).To(Equal(versions[1]))
+		})
+
+		It("keeps the template a config was rendered from", func() {
+			pipelineTemplate := db.PipelineTemplate{
+				Template:  "resources: []",
+				Variables: template.Variables{"some": "variable"},
+			}
+
+			saved, _, err := teamDB.SaveConfigAs("some-team", "a-pipeline-name", atc.Config{}, pipelineTemplate, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			version, found, err := teamDB.GetConfigVersion("a-pipeline-name", saved.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Template).To(Equal(pipelineTemplate))
+		})
+
+		It("does not keep configs from failed saves", func() {
+			saved, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfig("a-pipeline-name", otherConfig, saved.Version-1, db.PipelineNoChange)
+			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
+
+			versions, err := teamDB.GetConfigVersions("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+		})
+
+		It("does not find versions of other teams' pipelines", func() {
+			saved, _, err := teamDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = database.CreateTeam(db.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherTeamDB := teamDBFactory.GetTeamDB("other-team")
+
+			versions, err := otherTeamDB.GetConfigVersions("a-pipeline-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(BeEmpty())
+
+			_, found, err := otherTeamDB.GetConfigVersion("a-pipeline-name", saved.Version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// SavedConfigVersion is a config that was saved for a pipeline at some point,
+// as kept in the pipeline's config history.
+type SavedConfigVersion struct {
+	Version   ConfigVersion
+	Config    atc.Config
+	RawConfig atc.RawConfig
+	Template  PipelineTemplate
+	SavedBy   string
+	SavedAt   time.Time
+}
+
+const configVersionColumns = "v.version, v.config, v.template, v.variables, v.saved_by, v.saved_at"
+
+func (db *teamDB) GetConfigVersions(pipelineName string) ([]SavedConfigVersion, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+configVersionColumns+`
+		FROM pipeline_config_versions v
+		JOIN pipelines p ON v.pipeline_id = p.id
+		JOIN teams t ON p.team_id = t.id
+		WHERE p.name = $1
+		AND LOWER(t.name) = LOWER($2)
+		ORDER BY v.version DESC
+	`, pipelineName, db.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	versions := []SavedConfigVersion{}
+
+	for rows.Next() {
+		version, err := scanConfigVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (db *teamDB) GetConfigVersion(pipelineName string, version ConfigVersion) (SavedConfigVersion, bool, error) {
+	savedVersion, err := scanConfigVersion(db.conn.QueryRow(`
+		SELECT `+configVersionColumns+`
+		FROM pipeline_config_versions v
+		JOIN pipelines p ON v.pipeline_id = p.id
+		JOIN teams t ON p.team_id = t.id
+		WHERE p.name = $1
+		AND LOWER(t.name) = LOWER($2)
+		AND v.version = $3
+	`, pipelineName, db.teamName, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedConfigVersion{}, false, nil
+		}
+
+		return SavedConfigVersion{}, false, err
+	}
+
+	return savedVersion, true, nil
+}
+
+func scanConfigVersion(row scannable) (SavedConfigVersion, error) {
+	var version int
+	var configBlob []byte
+	var templateBlob sql.NullString
+	var variablesBlob []byte
+	var savedBy string
+	var savedAt time.Time
+
+	err := row.Scan(&version, &configBlob, &templateBlob, &variablesBlob, &savedBy, &savedAt)
+	if err != nil {
+		return SavedConfigVersion{}, err
+	}
+
+	savedVersion := SavedConfigVersion{
+		Version:   ConfigVersion(version),
+		RawConfig: atc.RawConfig(string(configBlob)),
+		SavedBy:   savedBy,
+		SavedAt:   savedAt,
+	}
+
+	err = json.Unmarshal(configBlob, &savedVersion.Config)
+	if err != nil {
+		return SavedConfigVersion{}, atc.MalformedConfigError{err}
+	}
+
+	if templateBlob.Valid {
+		savedVersion.Template.Template = templateBlob.String
+
+		err = json.Unmarshal(variablesBlob, &savedVersion.Template.Variables)
+		if err != nil {
+			return SavedConfigVersion{}, err
+		}
+	}
+
+	return savedVersion, nil
+}
@@ -446,6 +446,25 @@ var _ = Describe("TeamDB", func() {
 				Expect(savedTeam.GenericOAuth).To(Equal(genericOAuth))
 			})
 		})
+
+		Describe("UpdateRoles", func() {
+			It("saves the role bindings to the existing team", func() {
+				roles := []atc.TeamRoleBinding{
+					{
+						Role:     atc.TeamRoleOperator,
+						CFSpaces: []string{"some-space"},
+					},
+				}
+
+				savedTeam, err := teamDB.UpdateRoles(roles)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.Roles).To(Equal(roles))
+
+				savedTeam, err = teamDB.UpdateRoles(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(savedTeam.Roles).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("GetTeam", func() {
@@ -739,4 +758,150 @@ var _ = Describe("TeamDB", func() {
 			})
 		})
 	})
+
+	Describe("GetAuditEvents", func() {
+		Context("when there are no audit events", func() {
+			It("returns an empty list of events", func() {
+				events, pagination, err := teamDB.GetAuditEvents(db.Page{Limit: 2})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pagination.Next).To(BeNil())
+				Expect(pagination.Previous).To(BeNil())
+				Expect(events).To(BeEmpty())
+			})
+		})
+
+		Context("when there are audit events", func() {
+			BeforeEach(func() {
+				for _, pipelineName := range []string{"pipeline-a", "pipeline-b", "pipeline-c"} {
+					err := database.CreateAuditEvent(db.AuditEvent{
+						TeamName:      "team-name",
+						Route:         atc.PausePipeline,
+						Target:        map[string]string{"pipeline_name": pipelineName},
+						Status:        200,
+						ActorTeamName: "TEAM-name",
+						ActorUser:     "some-user",
+						ActorRole:     atc.TeamRoleOperator,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				err := database.CreateAuditEvent(db.AuditEvent{
+					TeamName:      "other-team-name",
+					Route:         atc.SaveConfig,
+					Status:        403,
+					ActorTeamName: "other-team-name",
+					ActorRole:     atc.TeamRoleViewer,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = database.CreateAuditEvent(db.AuditEvent{
+					Route:         atc.RegisterWorker,
+					Status:        200,
+					ActorIsSystem: true,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the team's events, newest first, with correct pagination", func() {
+				events, pagination, err := teamDB.GetAuditEvents(db.Page{Limit: 2})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events).To(HaveLen(2))
+				Expect(events[0].Target).To(Equal(map[string]string{"pipeline_name": "pipeline-c"}))
+				Expect(events[1].Target).To(Equal(map[string]string{"pipeline_name": "pipeline-b"}))
+
+				Expect(events[0].TeamName).To(Equal("TEAM-name"))
+				Expect(events[0].Route).To(Equal(atc.PausePipeline))
+				Expect(events[0].Status).To(Equal(200))
+				Expect(events[0].ActorTeamName).To(Equal("TEAM-name"))
+				Expect(events[0].ActorUser).To(Equal("some-user"))
+				Expect(events[0].ActorRole).To(Equal(atc.TeamRoleOperator))
+				Expect(events[0].ActorIsSystem).To(BeFalse())
+				Expect(events[0].CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: events[1].ID, Limit: 2}))
+
+				newerEvent := events[1]
+
+				events, pagination, err = teamDB.GetAuditEvents(*pagination.Next)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Target).To(Equal(map[string]string{"pipeline_name": "pipeline-a"}))
+
+				Expect(pagination.Previous).To(Equal(&db.Page{Until: events[0].ID, Limit: 2}))
+				Expect(pagination.Next).To(BeNil())
+
+				events, pagination, err = teamDB.GetAuditEvents(*pagination.Previous)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events).To(HaveLen(2))
+				Expect(events[1]).To(Equal(newerEvent))
+
+				Expect(pagination.Previous).To(BeNil())
+				Expect(pagination.Next).To(Equal(&db.Page{Since: newerEvent.ID, Limit: 2}))
+			})
+
+			It("does not return events belonging to other teams", func() {
+				events, _, err := otherTeamDB.GetAuditEvents(db.Page{Limit: 10})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Route).To(Equal(atc.SaveConfig))
+				Expect(events[0].Status).To(Equal(403))
+				Expect(events[0].Target).To(BeNil())
+			})
+
+			It("keeps the events of deleted teams, along with the team's name", func() {
+				err := database.DeleteTeamByName("other-team-name")
+				Expect(err).NotTo(HaveOccurred())
+
+				var teamNames []string
+				rows, err := dbConn.Query(`SELECT team_name FROM audit_events WHERE team_id IS NULL ORDER BY id`)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer rows.Close()
+
+				for rows.Next() {
+					var teamName string
+					err := rows.Scan(&teamName)
+					Expect(err).NotTo(HaveOccurred())
+
+					teamNames = append(teamNames, teamName)
+				}
+
+				Expect(teamNames).To(Equal([]string{"other-team-name", ""}))
+			})
+		})
+	})
+
+	Describe("DeleteAuditEventsBefore", func() {
+		BeforeEach(func() {
+			for _, status := range []int{200, 403} {
+				err := database.CreateAuditEvent(db.AuditEvent{
+					TeamName: "team-name",
+					Route:    atc.PausePipeline,
+					Status:   status,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := dbConn.Exec(`UPDATE audit_events SET created_at = now() - '2 days'::interval WHERE status = 403`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes only the events recorded before the given time", func() {
+			deleted, err := database.DeleteAuditEventsBefore(time.Now().Add(-24 * time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			events, _, err := teamDB.GetAuditEvents(db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Status).To(Equal(200))
+		})
+	})
 })
@@ -0,0 +1,25 @@
+package db
+
+// WorkerState is where a worker is in its lifecycle.
+type WorkerState string
+
+const (
+	// Running workers are heartbeating and may be given new containers.
+	WorkerStateRunning WorkerState = "running"
+
+	// Stalled workers stopped heartbeating without landing or retiring. They
+	// are kept around until they come back or an operator prunes them.
+	WorkerStateStalled WorkerState = "stalled"
+
+	// Landing workers are given no new containers, and become landed once the
+	// builds running on them have finished.
+	WorkerStateLanding WorkerState = "landing"
+
+	// Landed workers have no running builds, and stay landed until they
+	// restart.
+	WorkerStateLanded WorkerState = "landed"
+
+	// Retiring workers are given no new containers, and are removed once the
+	// builds running on them have finished.
+	WorkerStateRetiring WorkerState = "retiring"
+)
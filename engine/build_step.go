@@ -22,6 +22,23 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return step
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("in-parallel")
+
+	step := exec.InParallel{
+		Limit:    plan.InParallel.Limit,
+		FailFast: plan.InParallel.FailFast,
+	}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		stepFactory := build.buildStepFactory(logger, innerPlan)
+		step.Steps = append(step.Steps, stepFactory)
+	}
+
+	return step
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("do")
 
@@ -94,7 +111,10 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		return exec.Identity{}
 	}
 
-	configSource = exec.ValidatingConfigSource{configSource}
+	configSource = exec.ValidatingConfigSource{exec.EvaluatingConfigSource{
+		ConfigSource: configSource,
+		Variables:    build.variables,
+	}}
 
 	workerID, workerMetadata := build.stepIdentifier(
 		logger.Session("taskIdentifier"),
@@ -105,10 +125,14 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		"task",
 	)
 
+	// tasks' caches are shared between builds of the same job
+	workerMetadata.JobName = build.stepMetadata.JobName
+
 	clock := clock.NewClock()
 
 	return build.factory.Task(
 		logger,
+		build.stepMetadata,
 		exec.SourceName(plan.Task.Name),
 		workerID,
 		workerMetadata,
@@ -233,16 +257,34 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 	)
 }
 
+func (build *execBuild) buildGateStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("gate", lager.Data{
+		"name": plan.Gate.Name,
+	})
+
+	return exec.Gate(
+		build.delegate.GateDelegate(logger, *plan.Gate, event.OriginID(plan.ID)),
+		clock.NewClock(),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
-	step := exec.Retry{}
+	step := exec.RetryWithPolicy{
+		Delegate: build.delegate.RetryDelegate(logger, event.OriginID(plan.ID)),
+		Clock:    clock.NewClock(),
+	}
+
+	if plan.RetryPolicy != nil {
+		step.Policy = *plan.RetryPolicy
+	}
 
 	for index, innerPlan := range *plan.Retry {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		stepFactory := build.buildStepFactory(logger, innerPlan)
-		step = append(step, stepFactory)
+		step.Attempts = append(step.Attempts, stepFactory)
 	}
 
 	return step
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+// evaluateCredentials returns a copy of the plan with the ((secret))
+// references in its sources, params and resource types resolved. The plan
+// itself is left alone, as it is stored with the build and must never contain
+// the secrets. Task configs are resolved by the task step once they've been
+// fetched, as they may come from a file.
+func (build *execBuild) evaluateCredentials(plan atc.Plan) (atc.Plan, error) {
+	// a plan is full of pointers, so make a deep copy to keep from
+	// resolving the references in place
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	var evaluated atc.Plan
+	err = json.Unmarshal(payload, &evaluated)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	err = atc.NewPlanTraversal(build.evaluatePlanCredentials).Traverse(&evaluated)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	return evaluated, nil
+}
+
+func (build *execBuild) evaluatePlanCredentials(plan *atc.Plan) error {
+	var err error
+
+	switch {
+	case plan.Get != nil:
+		plan.Get.Source, plan.Get.Params, plan.Get.ResourceTypes, err = build.evaluateResource(
+			plan.Get.Source,
+			plan.Get.Params,
+			plan.Get.ResourceTypes,
+		)
+
+	case plan.Put != nil:
+		plan.Put.Source, plan.Put.Params, plan.Put.ResourceTypes, err = build.evaluateResource(
+			plan.Put.Source,
+			plan.Put.Params,
+			plan.Put.ResourceTypes,
+		)
+
+	case plan.DependentGet != nil:
+		plan.DependentGet.Source, plan.DependentGet.Params, plan.DependentGet.ResourceTypes, err = build.evaluateResource(
+			plan.DependentGet.Source,
+			plan.DependentGet.Params,
+			plan.DependentGet.ResourceTypes,
+		)
+
+	case plan.Task != nil:
+		plan.Task.ResourceTypes, err = build.variables.EvaluateResourceTypes(plan.Task.ResourceTypes)
+	}
+
+	return err
+}
+
+func (build *execBuild) evaluateResource(
+	source atc.Source,
+	params atc.Params,
+	resourceTypes atc.ResourceTypes,
+) (atc.Source, atc.Params, atc.ResourceTypes, error) {
+	source, err := build.variables.EvaluateSource(source)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	params, err = build.variables.EvaluateParams(params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resourceTypes, err = build.variables.EvaluateResourceTypes(resourceTypes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return source, params, resourceTypes, nil
+}
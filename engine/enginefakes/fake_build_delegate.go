@@ -50,6 +50,25 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	RetryDelegateStub        func(lager.Logger, event.OriginID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	GateDelegateStub        func(lager.Logger, atc.GatePlan, event.OriginID) exec.GateDelegate
+	gateDelegateMutex       sync.RWMutex
+	gateDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.GatePlan
+		arg3 event.OriginID
+	}
+	gateDelegateReturns struct {
+		result1 exec.GateDelegate
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -186,6 +205,75 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 lager.Logger, arg2 event.OriginID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 event.OriginID
+	}{arg1, arg2})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1, arg2})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1, arg2)
+	} else {
+		return fake.retryDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) (lager.Logger, event.OriginID) {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1, fake.retryDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) GateDelegate(arg1 lager.Logger, arg2 atc.GatePlan, arg3 event.OriginID) exec.GateDelegate {
+	fake.gateDelegateMutex.Lock()
+	fake.gateDelegateArgsForCall = append(fake.gateDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.GatePlan
+		arg3 event.OriginID
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GateDelegate", []interface{}{arg1, arg2, arg3})
+	fake.gateDelegateMutex.Unlock()
+	if fake.GateDelegateStub != nil {
+		return fake.GateDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.gateDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) GateDelegateCallCount() int {
+	fake.gateDelegateMutex.RLock()
+	defer fake.gateDelegateMutex.RUnlock()
+	return len(fake.gateDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) GateDelegateArgsForCall(i int) (lager.Logger, atc.GatePlan, event.OriginID) {
+	fake.gateDelegateMutex.RLock()
+	defer fake.gateDelegateMutex.RUnlock()
+	return fake.gateDelegateArgsForCall[i].arg1, fake.gateDelegateArgsForCall[i].arg2, fake.gateDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) GateDelegateReturns(result1 exec.GateDelegate) {
+	fake.GateDelegateStub = nil
+	fake.gateDelegateReturns = struct {
+		result1 exec.GateDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -197,6 +285,10 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.outputDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.gateDelegateMutex.RLock()
+	defer fake.gateDelegateMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -25,10 +26,11 @@ const successTTL = 5 * time.Minute
 const failureTTL = 5 * time.Minute
 
 type execEngine struct {
-	factory         exec.Factory
-	delegateFactory BuildDelegateFactory
-	teamDBFactory   db.TeamDBFactory
-	externalURL     string
+	factory           exec.Factory
+	delegateFactory   BuildDelegateFactory
+	teamDBFactory     db.TeamDBFactory
+	externalURL       string
+	credentialManager creds.CredentialManager
 }
 
 func NewExecEngine(
@@ -36,12 +38,14 @@ func NewExecEngine(
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
 	externalURL string,
+	credentialManager creds.CredentialManager,
 ) Engine {
 	return &execEngine{
-		factory:         factory,
-		delegateFactory: delegateFactory,
-		teamDBFactory:   teamDBFactory,
-		externalURL:     externalURL,
+		factory:           factory,
+		delegateFactory:   delegateFactory,
+		teamDBFactory:     teamDBFactory,
+		externalURL:       externalURL,
+		credentialManager: credentialManager,
 	}
 }
 
@@ -55,6 +59,7 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -87,6 +92,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
+		variables:    creds.NewVariables(engine.credentialManager, build.TeamName(), build.PipelineName()),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
@@ -164,6 +170,7 @@ type execBuild struct {
 	stepMetadata StepMetadata
 	teamName     string
 	teamID       int
+	variables    creds.Variables
 
 	factory  exec.Factory
 	delegate BuildDelegate
@@ -198,7 +205,14 @@ func (build *execBuild) Abort(lager.Logger) error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
-	stepFactory := build.buildStepFactory(logger, build.metadata.Plan)
+	plan, err := build.evaluateCredentials(build.metadata.Plan)
+	if err != nil {
+		logger.Error("failed-to-evaluate-credentials", err)
+		build.delegate.Finish(logger.Session("finish"), err, false, false)
+		return
+	}
+
+	stepFactory := build.buildStepFactory(logger, plan)
 	source := stepFactory.Using(&exec.NoopStep{}, exec.NewSourceRepository())
 
 	defer source.Release()
@@ -238,6 +252,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -282,6 +300,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.Gate != nil {
+		return build.buildGateStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
@@ -25,6 +25,8 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	RetryDelegate(lager.Logger, event.OriginID) exec.RetryDelegate
+	GateDelegate(lager.Logger, atc.GatePlan, event.OriginID) exec.GateDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -91,6 +93,25 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) RetryDelegate(logger lager.Logger, id event.OriginID) exec.RetryDelegate {
+	return &retryDelegate{
+		logger: logger,
+
+		id:       id,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) GateDelegate(logger lager.Logger, plan atc.GatePlan, id event.OriginID) exec.GateDelegate {
+	return &gateDelegate{
+		logger: logger,
+
+		id:       id,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -178,6 +199,41 @@ func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveStartAttempt(logger lager.Logger, attempt int, wait time.Duration, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.StartAttempt{
+		Time:    time.Now().Unix(),
+		Attempt: attempt,
+		Wait:    int64(wait / time.Millisecond),
+		Origin:  origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-attempt-event", err)
+	}
+}
+
+func (delegate *delegate) saveWaitForGate(logger lager.Logger, plan atc.GatePlan, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.WaitForGate{
+		Time:   time.Now().Unix(),
+		Name:   plan.Name,
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-wait-for-gate-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishGate(logger lager.Logger, decision exec.GateDecision, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.FinishGate{
+		Time:      time.Now().Unix(),
+		Approved:  decision.Approved,
+		DecidedBy: decision.DecidedBy,
+		Origin:    origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-gate-event", err)
+	}
+}
+
 func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus) {
 	err := delegate.build.Finish(db.Status(status))
 	if err != nil {
@@ -456,6 +512,80 @@ func (execution *executionDelegate) Stderr() io.Writer {
 	})
 }
 
+type retryDelegate struct {
+	logger lager.Logger
+
+	id event.OriginID
+
+	delegate *delegate
+}
+
+func (retry *retryDelegate) AttemptStarting(attempt int, wait time.Duration) {
+	retry.delegate.saveStartAttempt(retry.logger, attempt, wait, event.Origin{
+		ID: retry.id,
+	})
+}
+
+type gateDelegate struct {
+	logger lager.Logger
+
+	id   event.OriginID
+	plan atc.GatePlan
+
+	delegate *delegate
+}
+
+func (gate *gateDelegate) Waiting() error {
+	err := gate.delegate.build.WaitForGate(atc.PlanID(gate.id), gate.plan.Name)
+	if err != nil {
+		gate.logger.Error("failed-to-wait-for-gate", err)
+		return err
+	}
+
+	gate.delegate.saveWaitForGate(gate.logger, gate.plan, event.Origin{
+		ID: gate.id,
+	})
+
+	return nil
+}
+
+func (gate *gateDelegate) Decision() (exec.GateDecision, bool, error) {
+	buildGate, found, err := gate.delegate.build.GetGate(atc.PlanID(gate.id))
+	if err != nil {
+		gate.logger.Error("failed-to-get-gate", err)
+		return exec.GateDecision{}, false, err
+	}
+
+	if !found || buildGate.Approved == nil {
+		return exec.GateDecision{}, false, nil
+	}
+
+	return exec.GateDecision{
+		Approved:  *buildGate.Approved,
+		DecidedBy: buildGate.DecidedBy,
+	}, true, nil
+}
+
+func (gate *gateDelegate) Finished(decision exec.GateDecision) {
+	gate.delegate.saveFinishGate(gate.logger, decision, event.Origin{
+		ID: gate.id,
+	})
+
+	gate.logger.Info("finished", lager.Data{"approved": decision.Approved, "decided-by": decision.DecidedBy})
+}
+
+func (gate *gateDelegate) Interrupted() {
+	// reject the gate so that the build no longer counts as waiting at it
+	_, err := gate.delegate.build.DecideGate(atc.PlanID(gate.id), false, "")
+	if err != nil {
+		gate.logger.Error("failed-to-reject-interrupted-gate", err)
+	}
+
+	gate.delegate.saveFinishGate(gate.logger, exec.GateDecision{}, event.Origin{
+		ID: gate.id,
+	})
+}
+
 type dbEventWriter struct {
 	build db.Build
 
@@ -1120,6 +1120,185 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("RetryDelegate", func() {
+		var retryDelegate exec.RetryDelegate
+
+		BeforeEach(func() {
+			retryDelegate = delegate.RetryDelegate(logger, originID)
+		})
+
+		Describe("AttemptStarting", func() {
+			JustBeforeEach(func() {
+				retryDelegate.AttemptStarting(2, 1500*time.Millisecond)
+			})
+
+			It("saves a start-attempt event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartAttempt{}))
+				Expect(savedEvent.(event.StartAttempt).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.StartAttempt).Attempt).To(Equal(2))
+				Expect(savedEvent.(event.StartAttempt).Wait).To(Equal(int64(1500)))
+				Expect(savedEvent.(event.StartAttempt).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+	})
+
+	Describe("GateDelegate", func() {
+		var gateDelegate exec.GateDelegate
+
+		BeforeEach(func() {
+			gateDelegate = delegate.GateDelegate(logger, atc.GatePlan{Name: "some-gate"}, originID)
+		})
+
+		Describe("Waiting", func() {
+			var waitingErr error
+
+			JustBeforeEach(func() {
+				waitingErr = gateDelegate.Waiting()
+			})
+
+			It("records the build as waiting at the gate", func() {
+				Expect(waitingErr).NotTo(HaveOccurred())
+
+				Expect(fakeBuild.WaitForGateCallCount()).To(Equal(1))
+				planID, name := fakeBuild.WaitForGateArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID(originID)))
+				Expect(name).To(Equal("some-gate"))
+			})
+
+			It("saves a wait-for-gate event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.WaitForGate{}))
+				Expect(savedEvent.(event.WaitForGate).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.WaitForGate).Name).To(Equal("some-gate"))
+				Expect(savedEvent.(event.WaitForGate).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+
+			Context("when recording the build as waiting fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.WaitForGateReturns(disaster)
+				})
+
+				It("returns the error without saving an event", func() {
+					Expect(waitingErr).To(Equal(disaster))
+					Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Describe("Decision", func() {
+			var (
+				decision    exec.GateDecision
+				found       bool
+				decisionErr error
+			)
+
+			JustBeforeEach(func() {
+				decision, found, decisionErr = gateDelegate.Decision()
+			})
+
+			Context("when the gate is undecided", func() {
+				BeforeEach(func() {
+					fakeBuild.GetGateReturns(db.BuildGate{Name: "some-gate"}, true, nil)
+				})
+
+				It("is not found", func() {
+					Expect(decisionErr).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+
+					Expect(fakeBuild.GetGateCallCount()).To(Equal(1))
+					Expect(fakeBuild.GetGateArgsForCall(0)).To(Equal(atc.PlanID(originID)))
+				})
+			})
+
+			Context("when the gate is decided", func() {
+				BeforeEach(func() {
+					approved := true
+					fakeBuild.GetGateReturns(db.BuildGate{
+						Name:      "some-gate",
+						Approved:  &approved,
+						DecidedBy: "some-team",
+					}, true, nil)
+				})
+
+				It("returns the decision", func() {
+					Expect(decisionErr).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(decision).To(Equal(exec.GateDecision{
+						Approved:  true,
+						DecidedBy: "some-team",
+					}))
+				})
+			})
+
+			Context("when getting the gate fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.GetGateReturns(db.BuildGate{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(decisionErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				gateDelegate.Finished(exec.GateDecision{
+					Approved:  true,
+					DecidedBy: "some-team",
+				})
+			})
+
+			It("saves a finish-gate event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGate{}))
+				Expect(savedEvent.(event.FinishGate).Approved).To(BeTrue())
+				Expect(savedEvent.(event.FinishGate).DecidedBy).To(Equal("some-team"))
+				Expect(savedEvent.(event.FinishGate).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
+		Describe("Interrupted", func() {
+			JustBeforeEach(func() {
+				gateDelegate.Interrupted()
+			})
+
+			It("rejects the gate", func() {
+				Expect(fakeBuild.DecideGateCallCount()).To(Equal(1))
+				planID, approved, decidedBy := fakeBuild.DecideGateArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID(originID)))
+				Expect(approved).To(BeFalse())
+				Expect(decidedBy).To(BeEmpty())
+			})
+
+			It("saves a finish-gate event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishGate{}))
+				Expect(savedEvent.(event.FinishGate).Approved).To(BeFalse())
+				Expect(savedEvent.(event.FinishGate).DecidedBy).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Aborted", func() {
 		var aborted bool
 
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -41,6 +42,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			creds.NoopCredentialManager{},
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
@@ -206,13 +208,14 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
+					logger, _, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-completion-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
 						StepName:   "some-completion-task",
 						Type:       db.ContainerTypeTask,
+						JobName:    "some-job",
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -227,13 +230,14 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					logger, _, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-failure-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
 						StepName:   "some-failure-task",
 						Type:       db.ContainerTypeTask,
+						JobName:    "some-job",
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -248,13 +252,14 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
+					logger, _, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-success-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
 						StepName:   "some-success-task",
 						Type:       db.ContainerTypeTask,
+						JobName:    "some-job",
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -269,13 +274,14 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Expect(fakeFactory.TaskCallCount()).To(Equal(4))
-					logger, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
+					logger, _, sourceName, workerID, workerMetadata, delegate, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
 					Expect(logger).NotTo(BeNil())
 					Expect(sourceName).To(Equal(exec.SourceName("some-next-task")))
 					Expect(workerMetadata).To(Equal(worker.Metadata{
 						PipelineID: 57,
 						StepName:   "some-next-task",
 						Type:       db.ContainerTypeTask,
+						JobName:    "some-job",
 					}))
 					Expect(workerID).To(Equal(worker.Identifier{
 						BuildID: 84,
@@ -10,6 +10,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -28,6 +30,7 @@ var _ = Describe("ExecEngine", func() {
 		fakeFactory         *execfakes.FakeFactory
 		fakeTeamDB          *dbfakes.FakeTeamDB
 		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
+		fakeCredentials     *credsfakes.FakeCredentialManager
 		logger              *lagertest.TestLogger
 
 		execEngine engine.Engine
@@ -37,6 +40,7 @@ var _ = Describe("ExecEngine", func() {
 	BeforeEach(func() {
 		fakeFactory = new(execfakes.FakeFactory)
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
+		fakeCredentials = new(credsfakes.FakeCredentialManager)
 		logger = lagertest.NewTestLogger("test")
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
@@ -47,6 +51,7 @@ var _ = Describe("ExecEngine", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			fakeCredentials,
 		)
 	})
 
@@ -378,6 +383,104 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 
+		Context("with an in_parallel plan", func() {
+			var (
+				taskPlan      atc.Plan
+				otherTaskPlan atc.Plan
+			)
+
+			BeforeEach(func() {
+				taskPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-task",
+					PipelineID: 57,
+					ConfigPath: "some-config-path",
+				})
+
+				otherTaskPlan = planFactory.NewPlan(atc.TaskPlan{
+					Name:       "some-other-task",
+					PipelineID: 57,
+					ConfigPath: "some-other-config-path",
+				})
+
+				inParallelPlan := planFactory.NewPlan(atc.InParallelPlan{
+					Steps:    []atc.Plan{taskPlan, otherTaskPlan},
+					Limit:    1,
+					FailFast: true,
+				})
+
+				build, err := execEngine.CreateBuild(logger, dbBuild, inParallelPlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs each step", func() {
+				Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+				_, _, sourceName, workerID, _, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
+				Expect(workerID.PlanID).To(Equal(taskPlan.ID))
+
+				_, _, sourceName, workerID, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				Expect(sourceName).To(Equal(exec.SourceName("some-other-task")))
+				Expect(workerID.PlanID).To(Equal(otherTaskPlan.ID))
+			})
+
+			It("gives each step its own origin", func() {
+				Expect(fakeDelegate.ExecutionDelegateCallCount()).To(Equal(2))
+
+				_, _, planID := fakeDelegate.ExecutionDelegateArgsForCall(0)
+				Expect(planID).To(Equal(event.OriginID(taskPlan.ID)))
+
+				_, _, planID = fakeDelegate.ExecutionDelegateArgsForCall(1)
+				Expect(planID).To(Equal(event.OriginID(otherTaskPlan.ID)))
+			})
+		})
+
+		Context("with a gate plan", func() {
+			var (
+				gatePlan         atc.Plan
+				fakeGateDelegate *execfakes.FakeGateDelegate
+			)
+
+			BeforeEach(func() {
+				fakeGateDelegate = new(execfakes.FakeGateDelegate)
+				fakeGateDelegate.DecisionReturns(exec.GateDecision{Approved: true, DecidedBy: "some-team"}, true, nil)
+				fakeDelegate.GateDelegateReturns(fakeGateDelegate)
+
+				gatePlan = planFactory.NewPlan(atc.GatePlan{
+					Name: "some-gate",
+				})
+
+				build, err := execEngine.CreateBuild(logger, dbBuild, gatePlan)
+				Expect(err).NotTo(HaveOccurred())
+				build.Resume(logger)
+			})
+
+			It("constructs the gate with its own origin", func() {
+				Expect(fakeDelegate.GateDelegateCallCount()).To(Equal(1))
+
+				_, plan, planID := fakeDelegate.GateDelegateArgsForCall(0)
+				Expect(plan).To(Equal(atc.GatePlan{Name: "some-gate"}))
+				Expect(planID).To(Equal(event.OriginID(gatePlan.ID)))
+			})
+
+			It("does not run anything on a worker", func() {
+				Expect(fakeFactory.TaskCallCount()).To(BeZero())
+				Expect(fakeFactory.GetCallCount()).To(BeZero())
+				Expect(fakeFactory.PutCallCount()).To(BeZero())
+			})
+
+			It("finishes the build with the decision", func() {
+				Expect(fakeGateDelegate.FinishedCallCount()).To(Equal(1))
+
+				Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+				_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(succeeded).To(Equal(exec.Success(true)))
+				Expect(aborted).To(BeFalse())
+			})
+		})
+
 		Context("with a retry plan", func() {
 			var (
 				getPlan       atc.Plan
@@ -438,6 +541,16 @@ var _ = Describe("ExecEngine", func() {
 				Expect(*retryPlan.Retry).To(HaveLen(3))
 			})
 
+			It("records the attempts of each retry", func() {
+				Expect(fakeDelegate.RetryDelegateCallCount()).To(Equal(2))
+
+				_, planID := fakeDelegate.RetryDelegateArgsForCall(0)
+				Expect(planID).To(Equal(event.OriginID(retryPlan.ID)))
+
+				_, planID = fakeDelegate.RetryDelegateArgsForCall(1)
+				Expect(planID).To(Equal(event.OriginID(retryPlanTwo.ID)))
+			})
+
 			It("constructs the first get correctly", func() {
 				logger, metadata, sourceName, workerID, workerMetadata, delegate, resourceConfig, tags, actualTeamID, params, _, _, _, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
@@ -499,12 +612,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, _, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
+					JobName:      "some-job",
 					StepName:     "some-task",
 					PipelineID:   57,
 					Attempts:     []int{2, 1},
@@ -521,12 +635,13 @@ var _ = Describe("ExecEngine", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, _, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
 					ResourceName: "",
 					Type:         db.ContainerTypeTask,
+					JobName:      "some-job",
 					StepName:     "some-task",
 					PipelineID:   57,
 					Attempts:     []int{2, 2},
@@ -589,17 +704,89 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
 
+		Context("with a plan that references secrets", func() {
+			var plan atc.Plan
+
+			BeforeEach(func() {
+				plan = planFactory.NewPlan(atc.GetPlan{
+					Name:     "some-input",
+					Resource: "some-input-resource",
+					Type:     "git",
+					Source:   atc.Source{"private_key": "((password))"},
+					Params:   atc.Params{"token": "((password))"},
+					ResourceTypes: atc.ResourceTypes{
+						{
+							Name:   "git",
+							Type:   "docker-image",
+							Source: atc.Source{"password": "((password))"},
+						},
+					},
+				})
+
+				fakeCredentials.GetStub = func(teamName string, pipelineName string, secretName string) (interface{}, bool, error) {
+					if secretName == "password" {
+						return "some-password", true, nil
+					}
+
+					return nil, false, nil
+				}
+
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("resolves the secrets when constructing the steps", func() {
+				build.Resume(logger)
+				Expect(fakeFactory.GetCallCount()).To(Equal(1))
+
+				_, _, _, _, _, _, resourceConfig, _, _, params, _, resourceTypes, _, _ := fakeFactory.GetArgsForCall(0)
+				Expect(resourceConfig.Source).To(Equal(atc.Source{"private_key": "some-password"}))
+				Expect(params).To(Equal(atc.Params{"token": "some-password"}))
+				Expect(resourceTypes[0].Source).To(Equal(atc.Source{"password": "some-password"}))
+
+				teamName, pipelineName, _ := fakeCredentials.GetArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+			})
+
+			It("keeps the secrets out of the build's metadata", func() {
+				build.Resume(logger)
+
+				Expect(build.Metadata()).To(ContainSubstring("((password))"))
+				Expect(build.Metadata()).NotTo(ContainSubstring("some-password"))
+			})
+
+			Context("when a secret is not defined", func() {
+				BeforeEach(func() {
+					fakeCredentials.GetReturns(nil, false, nil)
+					fakeCredentials.GetStub = nil
+				})
+
+				It("errors the build without constructing any steps", func() {
+					build.Resume(logger)
+					Expect(fakeFactory.GetCallCount()).To(BeZero())
+
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).To(Equal(creds.UndefinedSecretError{Name: "password"}))
+					Expect(succeeded).To(BeFalse())
+					Expect(aborted).To(BeFalse())
+				})
+			})
+		})
+
 		Context("with a basic plan", func() {
 			var plan atc.Plan
 			Context("that contains inputs", func() {
@@ -746,7 +933,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -765,7 +952,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, containerSuccessTTL, containerFailureTTL := fakeFactory.TaskArgsForCall(0)
 						Expect(containerSuccessTTL).To(Equal(5 * time.Minute))
 						Expect(containerFailureTTL).To(Equal(5 * time.Minute))
 					})
@@ -778,12 +965,14 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, metadata, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
+						Expect(metadata).To(Equal(expectedMetadata))
 						Expect(sourceName).To(Equal(exec.SourceName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
 							ResourceName: "",
 							Type:         db.ContainerTypeTask,
+							JobName:      "some-job",
 							StepName:     "some-task",
 							PipelineID:   57,
 							TeamID:       teamID,
@@ -820,7 +1009,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
@@ -832,7 +1021,7 @@ var _ = Describe("ExecEngine", func() {
 							}
 						})
 
-						It("creates the task with a MergedConfigSource wrapped in a ValidatingConfigSource", func() {
+						It("creates the task with a MergedConfigSource wrapped in an EvaluatingConfigSource and a ValidatingConfigSource", func() {
 							var err error
 							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
 							Expect(err).NotTo(HaveOccurred())
@@ -840,10 +1029,12 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
-							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
+							ecs, ok := vcs.ConfigSource.(exec.EvaluatingConfigSource)
+							Expect(ok).To(BeTrue())
+							_, ok = ecs.ConfigSource.(exec.MergedConfigSource)
 							Expect(ok).To(BeTrue())
 						})
 					})
@@ -857,7 +1048,7 @@ var _ = Describe("ExecEngine", func() {
 							}
 						})
 
-						It("creates the task with a MergedConfigSource wrapped in a ValidatingConfigSource", func() {
+						It("creates the task with a MergedConfigSource wrapped in an EvaluatingConfigSource and a ValidatingConfigSource", func() {
 							var err error
 							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
 							Expect(err).NotTo(HaveOccurred())
@@ -865,10 +1056,12 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
-							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
+							ecs, ok := vcs.ConfigSource.(exec.EvaluatingConfigSource)
+							Expect(ok).To(BeTrue())
+							_, ok = ecs.ConfigSource.(exec.MergedConfigSource)
 							Expect(ok).To(BeTrue())
 						})
 					})
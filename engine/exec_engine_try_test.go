@@ -3,6 +3,7 @@ package engine_test
 import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -42,6 +43,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
 			"http://example.com",
+			creds.NoopCredentialManager{},
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
@@ -77,6 +77,37 @@ type StartTask struct {
 func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
 func (StartTask) Version() atc.EventVersion { return "4.0" }
 
+type StartAttempt struct {
+	Time    int64 `json:"time"`
+	Attempt int   `json:"attempt"`
+	// how long the attempt waited after the previous one, in milliseconds
+	Wait   int64  `json:"wait"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartAttempt) EventType() atc.EventType  { return EventTypeStartAttempt }
+func (StartAttempt) Version() atc.EventVersion { return "1.0" }
+
+type WaitForGate struct {
+	Time   int64  `json:"time"`
+	Name   string `json:"name"`
+	Origin Origin `json:"origin"`
+}
+
+func (WaitForGate) EventType() atc.EventType  { return EventTypeWaitForGate }
+func (WaitForGate) Version() atc.EventVersion { return "1.0" }
+
+type FinishGate struct {
+	Time     int64 `json:"time"`
+	Approved bool  `json:"approved"`
+	// empty if the gate was interrupted, e.g. by timing out
+	DecidedBy string `json:"decided_by,omitempty"`
+	Origin    Origin `json:"origin"`
+}
+
+func (FinishGate) EventType() atc.EventType  { return EventTypeFinishGate }
+func (FinishGate) Version() atc.EventVersion { return "1.0" }
+
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
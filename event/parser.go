@@ -40,6 +40,9 @@ func init() {
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
 	registerEvent(FinishPut{})
+	registerEvent(StartAttempt{})
+	registerEvent(WaitForGate{})
+	registerEvent(FinishGate{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
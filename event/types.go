@@ -30,6 +30,15 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// attempt of a step with attempts starting
+	EventTypeStartAttempt atc.EventType = "start-attempt"
+
+	// gate step waiting for approval
+	EventTypeWaitForGate atc.EventType = "wait-for-gate"
+
+	// gate step approved or rejected
+	EventTypeFinishGate atc.EventType = "finish-gate"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
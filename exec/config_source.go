@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -217,6 +218,33 @@ func (configSource ValidatingConfigSource) Warnings() []string {
 	return configSource.ConfigSource.Warnings()
 }
 
+// EvaluatingConfigSource delegates to another ConfigSource, and resolves the
+// ((secret)) references in its task config.
+type EvaluatingConfigSource struct {
+	ConfigSource TaskConfigSource
+	Variables    creds.Variables
+}
+
+// FetchConfig fetches the config using the underlying ConfigSource, and
+// resolves its params and image resource source.
+func (configSource EvaluatingConfigSource) FetchConfig(source *SourceRepository) (atc.TaskConfig, error) {
+	config, err := configSource.ConfigSource.FetchConfig(source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	evaluated, err := configSource.Variables.EvaluateTaskConfig(&config)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	return *evaluated, nil
+}
+
+func (configSource EvaluatingConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
 // UnknownArtifactSourceError is returned when the SourceName specified by the
 // path does not exist in the SourceRepository.
 type UnknownArtifactSourceError struct {
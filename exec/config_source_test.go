@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/baggageclaim"
@@ -547,4 +549,80 @@ run: {path: a/file}
 			})
 		})
 	})
+
+	Describe("EvaluatingConfigSource", func() {
+		var (
+			fakeConfigSource      *execfakes.FakeTaskConfigSource
+			fakeCredentialManager *credsfakes.FakeCredentialManager
+
+			configSource TaskConfigSource
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			fakeConfigSource = new(execfakes.FakeTaskConfigSource)
+			fakeCredentialManager = new(credsfakes.FakeCredentialManager)
+
+			configSource = EvaluatingConfigSource{
+				ConfigSource: fakeConfigSource,
+				Variables:    creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			fetchedConfig, fetchErr = configSource.FetchConfig(repo)
+		})
+
+		Context("when the config references secrets", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{
+					Platform: "some-platform",
+					Params:   map[string]string{"PASSWORD": "((password))"},
+				}, nil)
+
+				fakeCredentialManager.GetReturns("some-password", true, nil)
+			})
+
+			It("returns the config with the secrets resolved", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig).To(Equal(atc.TaskConfig{
+					Platform: "some-platform",
+					Params:   map[string]string{"PASSWORD": "some-password"},
+				}))
+
+				teamName, pipelineName, secretName := fakeCredentialManager.GetArgsForCall(0)
+				Expect(teamName).To(Equal("some-team"))
+				Expect(pipelineName).To(Equal("some-pipeline"))
+				Expect(secretName).To(Equal("password"))
+			})
+		})
+
+		Context("when a secret is not defined", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{
+					Params: map[string]string{"PASSWORD": "((missing))"},
+				}, nil)
+
+				fakeCredentialManager.GetReturns(nil, false, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(fetchErr).To(Equal(creds.UndefinedSecretError{Name: "missing"}))
+			})
+		})
+
+		Context("when fetching the config fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(fetchErr).To(Equal(disaster))
+			})
+		})
+	})
 })
@@ -74,7 +74,7 @@ var _ = Describe("DependentGet", func() {
 		fakeResourceFetcher = new(rfakes.FakeFetcher)
 		fakeTracker := new(rfakes.FakeTracker)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, new(execfakes.FakeLockDB), new(wfakes.FakeContainerPlacementStrategy), atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
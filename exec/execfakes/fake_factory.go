@@ -73,25 +73,26 @@ type FakeFactory struct {
 	dependentGetReturns struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) exec.StepFactory
+	TaskStub        func(lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1  lager.Logger
-		arg2  exec.SourceName
-		arg3  worker.Identifier
-		arg4  worker.Metadata
-		arg5  exec.TaskDelegate
-		arg6  exec.Privileged
-		arg7  atc.Tags
-		arg8  int
-		arg9  exec.TaskConfigSource
-		arg10 atc.ResourceTypes
-		arg11 map[string]string
+		arg2  exec.StepMetadata
+		arg3  exec.SourceName
+		arg4  worker.Identifier
+		arg5  worker.Metadata
+		arg6  exec.TaskDelegate
+		arg7  exec.Privileged
+		arg8  atc.Tags
+		arg9  int
+		arg10 exec.TaskConfigSource
+		arg11 atc.ResourceTypes
 		arg12 map[string]string
-		arg13 string
-		arg14 clock.Clock
-		arg15 time.Duration
+		arg13 map[string]string
+		arg14 string
+		arg15 clock.Clock
 		arg16 time.Duration
+		arg17 time.Duration
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -235,30 +236,31 @@ func (fake *FakeFactory) DependentGetReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 exec.SourceName, arg3 worker.Identifier, arg4 worker.Metadata, arg5 exec.TaskDelegate, arg6 exec.Privileged, arg7 atc.Tags, arg8 int, arg9 exec.TaskConfigSource, arg10 atc.ResourceTypes, arg11 map[string]string, arg12 map[string]string, arg13 string, arg14 clock.Clock, arg15 time.Duration, arg16 time.Duration) exec.StepFactory {
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 exec.StepMetadata, arg3 exec.SourceName, arg4 worker.Identifier, arg5 worker.Metadata, arg6 exec.TaskDelegate, arg7 exec.Privileged, arg8 atc.Tags, arg9 int, arg10 exec.TaskConfigSource, arg11 atc.ResourceTypes, arg12 map[string]string, arg13 map[string]string, arg14 string, arg15 clock.Clock, arg16 time.Duration, arg17 time.Duration) exec.StepFactory {
 	fake.taskMutex.Lock()
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1  lager.Logger
-		arg2  exec.SourceName
-		arg3  worker.Identifier
-		arg4  worker.Metadata
-		arg5  exec.TaskDelegate
-		arg6  exec.Privileged
-		arg7  atc.Tags
-		arg8  int
-		arg9  exec.TaskConfigSource
-		arg10 atc.ResourceTypes
-		arg11 map[string]string
+		arg2  exec.StepMetadata
+		arg3  exec.SourceName
+		arg4  worker.Identifier
+		arg5  worker.Metadata
+		arg6  exec.TaskDelegate
+		arg7  exec.Privileged
+		arg8  atc.Tags
+		arg9  int
+		arg10 exec.TaskConfigSource
+		arg11 atc.ResourceTypes
 		arg12 map[string]string
-		arg13 string
-		arg14 clock.Clock
-		arg15 time.Duration
+		arg13 map[string]string
+		arg14 string
+		arg15 clock.Clock
 		arg16 time.Duration
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16})
+		arg17 time.Duration
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16, arg17})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16, arg17})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16, arg17)
 	} else {
 		return fake.taskReturns.result1
 	}
@@ -270,10 +272,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, exec.StepMetadata, exec.SourceName, worker.Identifier, worker.Metadata, exec.TaskDelegate, exec.Privileged, atc.Tags, int, exec.TaskConfigSource, atc.ResourceTypes, map[string]string, map[string]string, string, clock.Clock, time.Duration, time.Duration) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15, fake.taskArgsForCall[i].arg16
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15, fake.taskArgsForCall[i].arg16, fake.taskArgsForCall[i].arg17
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
@@ -0,0 +1,155 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeGateDelegate struct {
+	WaitingStub        func() error
+	waitingMutex       sync.RWMutex
+	waitingArgsForCall []struct{}
+	waitingReturns     struct {
+		result1 error
+	}
+	DecisionStub        func() (exec.GateDecision, bool, error)
+	decisionMutex       sync.RWMutex
+	decisionArgsForCall []struct{}
+	decisionReturns     struct {
+		result1 exec.GateDecision
+		result2 bool
+		result3 error
+	}
+	FinishedStub        func(exec.GateDecision)
+	finishedMutex       sync.RWMutex
+	finishedArgsForCall []struct {
+		arg1 exec.GateDecision
+	}
+	InterruptedStub        func()
+	interruptedMutex       sync.RWMutex
+	interruptedArgsForCall []struct{}
+	invocations            map[string][][]interface{}
+	invocationsMutex       sync.RWMutex
+}
+
+func (fake *FakeGateDelegate) Waiting() error {
+	fake.waitingMutex.Lock()
+	fake.waitingArgsForCall = append(fake.waitingArgsForCall, struct{}{})
+	fake.recordInvocation("Waiting", []interface{}{})
+	fake.waitingMutex.Unlock()
+	if fake.WaitingStub != nil {
+		return fake.WaitingStub()
+	} else {
+		return fake.waitingReturns.result1
+	}
+}
+
+func (fake *FakeGateDelegate) WaitingCallCount() int {
+	fake.waitingMutex.RLock()
+	defer fake.waitingMutex.RUnlock()
+	return len(fake.waitingArgsForCall)
+}
+
+func (fake *FakeGateDelegate) WaitingReturns(result1 error) {
+	fake.WaitingStub = nil
+	fake.waitingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGateDelegate) Decision() (exec.GateDecision, bool, error) {
+	fake.decisionMutex.Lock()
+	fake.decisionArgsForCall = append(fake.decisionArgsForCall, struct{}{})
+	fake.recordInvocation("Decision", []interface{}{})
+	fake.decisionMutex.Unlock()
+	if fake.DecisionStub != nil {
+		return fake.DecisionStub()
+	} else {
+		return fake.decisionReturns.result1, fake.decisionReturns.result2, fake.decisionReturns.result3
+	}
+}
+
+func (fake *FakeGateDelegate) DecisionCallCount() int {
+	fake.decisionMutex.RLock()
+	defer fake.decisionMutex.RUnlock()
+	return len(fake.decisionArgsForCall)
+}
+
+func (fake *FakeGateDelegate) DecisionReturns(result1 exec.GateDecision, result2 bool, result3 error) {
+	fake.DecisionStub = nil
+	fake.decisionReturns = struct {
+		result1 exec.GateDecision
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeGateDelegate) Finished(arg1 exec.GateDecision) {
+	fake.finishedMutex.Lock()
+	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
+		arg1 exec.GateDecision
+	}{arg1})
+	fake.recordInvocation("Finished", []interface{}{arg1})
+	fake.finishedMutex.Unlock()
+	if fake.FinishedStub != nil {
+		fake.FinishedStub(arg1)
+	}
+}
+
+func (fake *FakeGateDelegate) FinishedCallCount() int {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return len(fake.finishedArgsForCall)
+}
+
+func (fake *FakeGateDelegate) FinishedArgsForCall(i int) exec.GateDecision {
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	return fake.finishedArgsForCall[i].arg1
+}
+
+func (fake *FakeGateDelegate) Interrupted() {
+	fake.interruptedMutex.Lock()
+	fake.interruptedArgsForCall = append(fake.interruptedArgsForCall, struct{}{})
+	fake.recordInvocation("Interrupted", []interface{}{})
+	fake.interruptedMutex.Unlock()
+	if fake.InterruptedStub != nil {
+		fake.InterruptedStub()
+	}
+}
+
+func (fake *FakeGateDelegate) InterruptedCallCount() int {
+	fake.interruptedMutex.RLock()
+	defer fake.interruptedMutex.RUnlock()
+	return len(fake.interruptedArgsForCall)
+}
+
+func (fake *FakeGateDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.waitingMutex.RLock()
+	defer fake.waitingMutex.RUnlock()
+	fake.decisionMutex.RLock()
+	defer fake.decisionMutex.RUnlock()
+	fake.finishedMutex.RLock()
+	defer fake.finishedMutex.RUnlock()
+	fake.interruptedMutex.RLock()
+	defer fake.interruptedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeGateDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.GateDelegate = new(FakeGateDelegate)
@@ -0,0 +1,67 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeRetryDelegate struct {
+	AttemptStartingStub        func(attempt int, wait time.Duration)
+	attemptStartingMutex       sync.RWMutex
+	attemptStartingArgsForCall []struct {
+		attempt int
+		wait    time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRetryDelegate) AttemptStarting(attempt int, wait time.Duration) {
+	fake.attemptStartingMutex.Lock()
+	fake.attemptStartingArgsForCall = append(fake.attemptStartingArgsForCall, struct {
+		attempt int
+		wait    time.Duration
+	}{attempt, wait})
+	fake.recordInvocation("AttemptStarting", []interface{}{attempt, wait})
+	fake.attemptStartingMutex.Unlock()
+	if fake.AttemptStartingStub != nil {
+		fake.AttemptStartingStub(attempt, wait)
+	}
+}
+
+func (fake *FakeRetryDelegate) AttemptStartingCallCount() int {
+	fake.attemptStartingMutex.RLock()
+	defer fake.attemptStartingMutex.RUnlock()
+	return len(fake.attemptStartingArgsForCall)
+}
+
+func (fake *FakeRetryDelegate) AttemptStartingArgsForCall(i int) (int, time.Duration) {
+	fake.attemptStartingMutex.RLock()
+	defer fake.attemptStartingMutex.RUnlock()
+	return fake.attemptStartingArgsForCall[i].attempt, fake.attemptStartingArgsForCall[i].wait
+}
+
+func (fake *FakeRetryDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attemptStartingMutex.RLock()
+	defer fake.attemptStartingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRetryDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.RetryDelegate = new(FakeRetryDelegate)
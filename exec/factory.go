@@ -69,6 +69,7 @@ type Factory interface {
 	// Task constructs a TaskStep factory.
 	Task(
 		lager.Logger,
+		StepMetadata,
 		SourceName,
 		worker.Identifier,
 		worker.Metadata,
@@ -140,6 +141,25 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . RetryDelegate
+
+// RetryDelegate is used to record events related to a RetryStep's attempts.
+type RetryDelegate interface {
+	AttemptStarting(attempt int, wait time.Duration)
+}
+
+//go:generate counterfeiter . GateDelegate
+
+// GateDelegate is used to record a GateStep waiting and to check whether it
+// has been decided.
+type GateDelegate interface {
+	Waiting() error
+	Decision() (GateDecision, bool, error)
+
+	Finished(GateDecision)
+	Interrupted()
+}
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
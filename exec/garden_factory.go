@@ -10,14 +10,24 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
 
 type gardenFactory struct {
-	workerClient    worker.Client
-	tracker         resource.Tracker
-	resourceFetcher resource.Fetcher
+	workerClient      worker.Client
+	tracker           resource.Tracker
+	resourceFetcher   resource.Fetcher
+	lockDB            LockDB
+	placementStrategy worker.ContainerPlacementStrategy
+	defaultLimits     atc.ContainerLimits
+}
+
+//go:generate counterfeiter . LockDB
+
+type LockDB interface {
+	GetTaskLock(logger lager.Logger, lockName string) (db.Lock, bool, error)
 }
 
 //go:generate counterfeiter . TrackerFactory
@@ -30,11 +40,17 @@ func NewGardenFactory(
 	workerClient worker.Client,
 	tracker resource.Tracker,
 	resourceFetcher resource.Fetcher,
+	lockDB LockDB,
+	placementStrategy worker.ContainerPlacementStrategy,
+	defaultLimits atc.ContainerLimits,
 ) Factory {
 	return &gardenFactory{
-		workerClient:    workerClient,
-		tracker:         tracker,
-		resourceFetcher: resourceFetcher,
+		workerClient:      workerClient,
+		tracker:           tracker,
+		resourceFetcher:   resourceFetcher,
+		lockDB:            lockDB,
+		placementStrategy: placementStrategy,
+		defaultLimits:     defaultLimits,
 	}
 }
 
@@ -157,6 +173,7 @@ func (factory *gardenFactory) Put(
 
 func (factory *gardenFactory) Task(
 	logger lager.Logger,
+	stepMetadata StepMetadata,
 	sourceName SourceName,
 	id worker.Identifier,
 	workerMetadata worker.Metadata,
@@ -177,6 +194,7 @@ func (factory *gardenFactory) Task(
 	workerMetadata.WorkingDirectory = workingDirectory
 	return newTaskStep(
 		logger,
+		stepMetadata,
 		id,
 		workerMetadata,
 		tags,
@@ -185,11 +203,14 @@ func (factory *gardenFactory) Task(
 		privileged,
 		configSource,
 		factory.workerClient,
+		factory.lockDB,
+		factory.placementStrategy,
 		workingDirectory,
 		resourceTypes,
 		inputMapping,
 		outputMapping,
 		imageArtifactName,
+		factory.defaultLimits,
 		clock,
 		containerSuccessTTL,
 		containerFailureTTL,
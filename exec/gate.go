@@ -0,0 +1,99 @@
+package exec
+
+import (
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// GatePollingInterval is how often a GateStep checks whether it has been
+// decided.
+const GatePollingInterval = 5 * time.Second
+
+// GateDecision is the approval or rejection of a GateStep.
+type GateDecision struct {
+	Approved  bool
+	DecidedBy string
+}
+
+// GateStep waits for someone to approve or reject the build.
+type GateStep struct {
+	delegate GateDelegate
+	clock    clock.Clock
+
+	decision GateDecision
+	decided  bool
+}
+
+// Gate constructs a GateStep factory.
+func Gate(
+	delegate GateDelegate,
+	clock clock.Clock,
+) GateStep {
+	return GateStep{
+		delegate: delegate,
+		clock:    clock,
+	}
+}
+
+// Using constructs a *GateStep.
+func (step GateStep) Using(prev Step, repo *SourceRepository) Step {
+	return &step
+}
+
+// Run records that the step is waiting and then checks for a decision every
+// GatePollingInterval until one is made. No container is needed while it
+// waits.
+//
+// If the step is interrupted, e.g. by a timeout, before it is decided, the
+// gate is rejected and ErrInterrupted is returned.
+func (step *GateStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	err := step.delegate.Waiting()
+	if err != nil {
+		return err
+	}
+
+	close(ready)
+
+	for {
+		decision, found, err := step.delegate.Decision()
+		if err != nil {
+			return err
+		}
+
+		if found {
+			step.decision = decision
+			step.decided = true
+			step.delegate.Finished(decision)
+			return nil
+		}
+
+		timer := step.clock.NewTimer(GatePollingInterval)
+
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			step.delegate.Interrupted()
+			return ErrInterrupted
+		}
+	}
+}
+
+// Release is a no-op.
+func (step *GateStep) Release() {}
+
+// Result indicates Success as true if the gate was approved.
+//
+// All other types are ignored.
+func (step *GateStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.decided && step.decision.Approved)
+		return true
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Gate Step", func() {
+	var (
+		fakeDelegate *execfakes.FakeGateDelegate
+		fakeClock    *fakeclock.FakeClock
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeDelegate = new(execfakes.FakeGateDelegate)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+	})
+
+	JustBeforeEach(func() {
+		step = Gate(fakeDelegate, fakeClock).Using(nil, NewSourceRepository())
+		process = ifrit.Background(step)
+	})
+
+	It("records that it is waiting", func() {
+		Eventually(fakeDelegate.WaitingCallCount).Should(Equal(1))
+	})
+
+	Context("when recording that it is waiting fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.WaitingReturns(disaster)
+		})
+
+		It("exits with the error without checking for a decision", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+			Expect(fakeDelegate.DecisionCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the gate is approved", func() {
+		BeforeEach(func() {
+			fakeDelegate.DecisionReturns(GateDecision{Approved: true, DecidedBy: "some-team"}, true, nil)
+		})
+
+		It("exits successfully", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(true)))
+		})
+
+		It("records the decision", func() {
+			Eventually(process.Wait()).Should(Receive())
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedArgsForCall(0)).To(Equal(GateDecision{Approved: true, DecidedBy: "some-team"}))
+		})
+	})
+
+	Context("when the gate is rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.DecisionReturns(GateDecision{Approved: false, DecidedBy: "some-team"}, true, nil)
+		})
+
+		It("exits successfully but fails", func() {
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(success).To(Equal(Success(false)))
+		})
+	})
+
+	Context("when checking for a decision fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.DecisionReturns(GateDecision{}, false, disaster)
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+		})
+	})
+
+	Context("when the gate has not been decided yet", func() {
+		It("checks again after the polling interval", func() {
+			Eventually(fakeDelegate.DecisionCallCount).Should(Equal(1))
+
+			fakeDelegate.DecisionReturns(GateDecision{Approved: true, DecidedBy: "some-team"}, true, nil)
+
+			fakeClock.WaitForWatcherAndIncrement(GatePollingInterval)
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Expect(fakeDelegate.DecisionCallCount()).To(Equal(2))
+		})
+
+		Context("when interrupted", func() {
+			It("records the interruption and exits with ErrInterrupted", func() {
+				Eventually(fakeDelegate.DecisionCallCount).Should(Equal(1))
+
+				process.Signal(os.Interrupt)
+
+				Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+				Expect(fakeDelegate.InterruptedCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(success).To(Equal(Success(false)))
+			})
+		})
+	})
+})
@@ -118,7 +118,7 @@ var _ = Describe("Get", func() {
 		fakeVersionedSource = new(rfakes.FakeVersionedSource)
 		fakeFetchSource.VersionedSourceReturns(fakeVersionedSource)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, new(execfakes.FakeLockDB), new(wfakes.FakeContainerPlacementStrategy), atc.ContainerLimits{})
 	})
 
 	JustBeforeEach(func() {
@@ -0,0 +1,186 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InParallel constructs a Step that will run each step in parallel, running at
+// most Limit steps at a time.
+type InParallel struct {
+	Steps    []StepFactory
+	Limit    int
+	FailFast bool
+}
+
+// Using delegates to each StepFactory and returns an *InParallelStep.
+func (stepFactory InParallel) Using(prev Step, repo *SourceRepository) Step {
+	step := &InParallelStep{
+		limit:    stepFactory.Limit,
+		failFast: stepFactory.FailFast,
+	}
+
+	for _, subStepFactory := range stepFactory.Steps {
+		step.steps = append(step.steps, subStepFactory.Using(prev, repo))
+	}
+
+	return step
+}
+
+// InParallelStep is a step of steps to run in parallel.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+
+	started int
+}
+
+type inParallelExit struct {
+	index int
+	err   error
+}
+
+// Run executes the steps in parallel, starting the next step whenever one
+// exits once the limit of concurrently running steps is reached. A limit of
+// zero runs every step at once. It is ready as soon as it begins running the
+// steps, and propagates any signal received to all running steps.
+//
+// If FailFast is set, the first step to fail or error will cause the running
+// steps to be interrupted and no further steps to be started. Otherwise it
+// will wait for all steps to exit. Errors of the steps (if any) are aggregated
+// and returned as a single error.
+func (step *InParallelStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	limit := step.limit
+	if limit <= 0 || limit > len(step.steps) {
+		limit = len(step.steps)
+	}
+
+	exited := make(chan inParallelExit, len(step.steps))
+	running := map[int]ifrit.Process{}
+
+	start := func() {
+		index := step.started
+		step.started++
+
+		process := ifrit.Background(step.steps[index])
+		running[index] = process
+
+		go func() {
+			exited <- inParallelExit{index: index, err: <-process.Wait()}
+		}()
+	}
+
+	for step.started < limit {
+		start()
+	}
+
+	var errorMessages []string
+	failedFast := false
+
+	for len(running) > 0 {
+		select {
+		case sig := <-signals:
+			for _, process := range running {
+				process.Signal(sig)
+			}
+
+			for _, process := range running {
+				<-process.Wait()
+			}
+
+			return ErrInterrupted
+
+		case exit := <-exited:
+			delete(running, exit.index)
+
+			if exit.err != nil {
+				// siblings interrupted by a failing step are not errors themselves
+				if !(failedFast && exit.err == ErrInterrupted) {
+					errorMessages = append(errorMessages, exit.err.Error())
+				}
+			}
+
+			if step.failFast && !failedFast && !step.succeeded(exit) {
+				failedFast = true
+
+				for _, process := range running {
+					process.Signal(os.Interrupt)
+				}
+			}
+
+			if !failedFast && step.started < len(step.steps) {
+				start()
+			}
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("steps failed:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return nil
+}
+
+func (step *InParallelStep) succeeded(exit inParallelExit) bool {
+	if exit.err != nil {
+		return false
+	}
+
+	var success Success
+	if !step.steps[exit.index].Result(&success) {
+		return true
+	}
+
+	return bool(success)
+}
+
+// Release iterates over the steps and Releases them individually.
+func (step *InParallelStep) Release() {
+	for _, src := range step.steps {
+		src.Release()
+	}
+}
+
+// Result indicates Success as true if all of the steps indicate Success as
+// true, or if there were no steps at all. Steps that were never started, i.e.
+// because another step failed fast, do not count towards the result. If none
+// of the steps can indicate Success, it will return false and not indicate
+// success itself.
+//
+// All other result types are ignored, and Result will return false.
+func (step *InParallelStep) Result(x interface{}) bool {
+	if success, ok := x.(*Success); ok {
+		if len(step.steps) == 0 {
+			*success = Success(true)
+			return true
+		}
+
+		succeeded := true
+		anyIndicated := false
+		for _, src := range step.steps[:step.started] {
+			var s Success
+			if !src.Result(&s) {
+				continue
+			}
+
+			anyIndicated = true
+			succeeded = succeeded && bool(s)
+		}
+
+		if !anyIndicated {
+			return false
+		}
+
+		*success = Success(succeeded)
+
+		return true
+	}
+
+	return false
+}
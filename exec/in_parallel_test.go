@@ -0,0 +1,325 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		fakeStepA *execfakes.FakeStepFactory
+		fakeStepB *execfakes.FakeStepFactory
+		fakeStepC *execfakes.FakeStepFactory
+
+		inParallel InParallel
+
+		inStep *execfakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *execfakes.FakeStep
+		outStepB *execfakes.FakeStep
+		outStepC *execfakes.FakeStep
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(execfakes.FakeStepFactory)
+		fakeStepB = new(execfakes.FakeStepFactory)
+		fakeStepC = new(execfakes.FakeStepFactory)
+
+		inParallel = InParallel{
+			Steps: []StepFactory{
+				fakeStepA,
+				fakeStepB,
+				fakeStepC,
+			},
+		}
+
+		inStep = new(execfakes.FakeStep)
+		repo = NewSourceRepository()
+
+		outStepA = new(execfakes.FakeStep)
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(execfakes.FakeStep)
+		fakeStepB.UsingReturns(outStepB)
+
+		outStepC = new(execfakes.FakeStep)
+		fakeStepC.UsingReturns(outStepC)
+	})
+
+	JustBeforeEach(func() {
+		step = inParallel.Using(inStep, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	It("uses the input source for all steps", func() {
+		for _, fakeStep := range []*execfakes.FakeStepFactory{fakeStepA, fakeStepB, fakeStepC} {
+			Expect(fakeStep.UsingCallCount()).To(Equal(1))
+			step, repo := fakeStep.UsingArgsForCall(0)
+			Expect(step).To(Equal(inStep))
+			Expect(repo).To(Equal(repo))
+		}
+	})
+
+	It("exits successfully", func() {
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	Describe("executing each step", func() {
+		var started chan string
+		var finish chan struct{}
+
+		BeforeEach(func() {
+			started = make(chan string, 3)
+			finish = make(chan struct{})
+
+			run := func(name string) func(<-chan os.Signal, chan<- struct{}) error {
+				return func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					started <- name
+					<-finish
+					return nil
+				}
+			}
+
+			outStepA.RunStub = run("a")
+			outStepB.RunStub = run("b")
+			outStepC.RunStub = run("c")
+		})
+
+		Context("when there is no limit", func() {
+			It("happens concurrently", func() {
+				Eventually(started).Should(Receive())
+				Eventually(started).Should(Receive())
+				Eventually(started).Should(Receive())
+
+				close(finish)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+			})
+		})
+
+		Context("when there is a limit", func() {
+			BeforeEach(func() {
+				inParallel.Limit = 2
+			})
+
+			It("runs at most that many steps at a time", func() {
+				Eventually(started).Should(Receive(Or(Equal("a"), Equal("b"))))
+				Eventually(started).Should(Receive(Or(Equal("a"), Equal("b"))))
+				Consistently(started).ShouldNot(Receive())
+
+				finish <- struct{}{}
+
+				Eventually(started).Should(Receive(Equal("c")))
+
+				close(finish)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepA.RunCallCount()).To(Equal(1))
+				Expect(outStepB.RunCallCount()).To(Equal(1))
+				Expect(outStepC.RunCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("signalling", func() {
+		var receivedSignals chan os.Signal
+		var actuallyExit chan struct{}
+
+		BeforeEach(func() {
+			receivedSignals = make(chan os.Signal, 3)
+			actuallyExit = make(chan struct{}, 1)
+
+			run := func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+				receivedSignals <- <-signals
+				<-actuallyExit
+				return ErrInterrupted
+			}
+
+			outStepA.RunStub = run
+			outStepB.RunStub = run
+			outStepC.RunStub = run
+		})
+
+		It("returns ErrInterrupted", func() {
+			process.Signal(os.Interrupt)
+
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
+			Consistently(process.Wait()).ShouldNot(Receive())
+			close(actuallyExit)
+			Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+		})
+	})
+
+	Context("when steps error", func() {
+		disasterA := errors.New("nope A")
+		disasterB := errors.New("nope B")
+
+		BeforeEach(func() {
+			outStepA.RunReturns(disasterA)
+			outStepB.RunReturns(disasterB)
+		})
+
+		It("exits with an error including the original message", func() {
+			var err error
+			Eventually(process.Wait()).Should(Receive(&err))
+
+			Expect(err.Error()).To(ContainSubstring("nope A"))
+			Expect(err.Error()).To(ContainSubstring("nope B"))
+		})
+
+		It("runs all of the steps", func() {
+			Eventually(process.Wait()).Should(Receive())
+			Expect(outStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a step fails", func() {
+		var interrupted chan os.Signal
+		var exitB chan struct{}
+
+		BeforeEach(func() {
+			interrupted = make(chan os.Signal, 2)
+			exitB = make(chan struct{})
+
+			inParallel.Limit = 2
+
+			outStepA.ResultStub = successResult(false)
+
+			outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+
+				select {
+				case sig := <-signals:
+					interrupted <- sig
+					return ErrInterrupted
+				case <-exitB:
+					return nil
+				}
+			}
+			outStepB.ResultStub = successResult(true)
+
+			outStepC.ResultStub = successResult(true)
+		})
+
+		AfterEach(func() {
+			close(exitB)
+		})
+
+		Context("when fail fast is not set", func() {
+			It("runs the remaining steps", func() {
+				Eventually(outStepC.RunCallCount).Should(Equal(1))
+				Consistently(interrupted).ShouldNot(Receive())
+			})
+		})
+
+		Context("when fail fast is set", func() {
+			BeforeEach(func() {
+				inParallel.FailFast = true
+			})
+
+			It("interrupts the running steps without starting the remaining ones", func() {
+				Eventually(interrupted).Should(Receive(Equal(os.Interrupt)))
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(outStepC.RunCallCount()).To(BeZero())
+			})
+
+			It("fails", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				var result Success
+				Expect(step.Result(&result)).To(BeTrue())
+				Expect(result).To(Equal(Success(false)))
+			})
+		})
+	})
+
+	Describe("releasing", func() {
+		It("releases all steps", func() {
+			step.Release()
+
+			Expect(outStepA.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepB.ReleaseCallCount()).To(Equal(1))
+			Expect(outStepC.ReleaseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("getting a result", func() {
+		Context("when the result type is bad", func() {
+			It("returns false", func() {
+				result := "this-is-bad"
+				Expect(step.Result(&result)).To(BeFalse())
+			})
+		})
+
+		Context("when getting a Success result", func() {
+			var result Success
+
+			BeforeEach(func() {
+				result = false
+			})
+
+			JustBeforeEach(func() {
+				Eventually(process.Wait()).Should(Receive())
+			})
+
+			Context("and all steps are successful", func() {
+				BeforeEach(func() {
+					outStepA.ResultStub = successResult(true)
+					outStepB.ResultStub = successResult(true)
+					outStepC.ResultStub = successResult(true)
+				})
+
+				It("yields true", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(true)))
+				})
+			})
+
+			Context("and some steps are not successful", func() {
+				BeforeEach(func() {
+					outStepA.ResultStub = successResult(true)
+					outStepB.ResultStub = successResult(false)
+					outStepC.ResultStub = successResult(true)
+				})
+
+				It("yields false", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(false)))
+				})
+			})
+
+			Context("when no steps indicate success", func() {
+				It("returns false", func() {
+					Expect(step.Result(&result)).To(BeFalse())
+					Expect(result).To(Equal(Success(false)))
+				})
+			})
+
+			Context("when there are no steps", func() {
+				BeforeEach(func() {
+					inParallel = InParallel{}
+				})
+
+				It("returns true", func() {
+					Expect(step.Result(&result)).To(BeTrue())
+					Expect(result).To(Equal(Success(true)))
+				})
+			})
+		})
+	})
+})
@@ -1,6 +1,13 @@
 package exec
 
-import "os"
+import (
+	"math"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc"
+)
 
 // Retry constructs a Step that will run the steps in order until one of them
 // succeeds.
@@ -17,34 +24,87 @@ func (stepFactory Retry) Using(prev Step, repo *SourceRepository) Step {
 	return retry
 }
 
+// RetryWithPolicy constructs a Step that will run the steps in order until one
+// of them succeeds, waiting between attempts and only retrying the failures
+// allowed by the policy.
+type RetryWithPolicy struct {
+	Attempts []StepFactory
+	Policy   atc.RetryPolicy
+	Delegate RetryDelegate
+	Clock    clock.Clock
+}
+
+// Using constructs a *RetryStep.
+func (stepFactory RetryWithPolicy) Using(prev Step, repo *SourceRepository) Step {
+	retry := &RetryStep{
+		Policy:   stepFactory.Policy,
+		Delegate: stepFactory.Delegate,
+		Clock:    stepFactory.Clock,
+	}
+
+	for _, subStepFactory := range stepFactory.Attempts {
+		retry.Attempts = append(retry.Attempts, subStepFactory.Using(prev, repo))
+	}
+
+	return retry
+}
+
 // RetryStep is a step that will run the steps in order until one of them
 // succeeds.
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	Policy   atc.RetryPolicy
+	Delegate RetryDelegate
+	Clock    clock.Clock
 }
 
 // Run iterates through each step, stopping once a step succeeds. If all steps
 // fail, the RetryStep will fail.
+//
+// If the policy has a backoff, it waits before each attempt after the first,
+// growing the wait by the multiplier up to the maximum. If the policy has
+// conditions, only errors or task exit statuses matching them are retried;
+// any other failure is returned as-is.
 func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	close(ready)
 
 	var attemptErr error
+	var wait time.Duration
+
+	for i, attempt := range step.Attempts {
+		if i > 0 {
+			var err error
+			wait, err = step.nextWait(wait)
+			if err != nil {
+				return err
+			}
+
+			if wait > 0 {
+				timer := step.Clock.NewTimer(wait)
+
+				select {
+				case <-timer.C():
+				case <-signals:
+					timer.Stop()
+					return ErrInterrupted
+				}
+			}
+		}
+
+		if step.Delegate != nil {
+			step.Delegate.AttemptStarting(i+1, wait)
+		}
 
-	for _, attempt := range step.Attempts {
 		step.LastAttempt = attempt
 
-		var succeeded Success
 		attemptErr = attempt.Run(signals, make(chan struct{}))
 		if attemptErr == ErrInterrupted {
 			return attemptErr
 		}
 
-		if attemptErr != nil {
-			continue
-		}
-
-		if attempt.Result(&succeeded) && bool(succeeded) {
+		if !step.shouldRetry(attempt, attemptErr) {
 			break
 		}
 	}
@@ -52,6 +112,84 @@ func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 	return attemptErr
 }
 
+func (step *RetryStep) shouldRetry(attempt Step, attemptErr error) bool {
+	conditions := step.Policy.RetryOn
+
+	if attemptErr != nil {
+		return conditions == nil || conditions.Errors
+	}
+
+	var succeeded Success
+	if attempt.Result(&succeeded) && bool(succeeded) {
+		return false
+	}
+
+	if conditions == nil {
+		return true
+	}
+
+	var status ExitStatus
+	if !attempt.Result(&status) {
+		return false
+	}
+
+	for _, retryStatus := range conditions.ExitStatuses {
+		if int(status) == retryStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (step *RetryStep) nextWait(previous time.Duration) (time.Duration, error) {
+	backoff := step.Policy.Backoff
+	if backoff == nil {
+		return 0, nil
+	}
+
+	var wait time.Duration
+	if previous == 0 {
+		if backoff.Initial == "" {
+			return 0, nil
+		}
+
+		initial, err := time.ParseDuration(backoff.Initial)
+		if err != nil {
+			return 0, err
+		}
+
+		wait = initial
+	} else {
+		multiplier := backoff.Multiplier
+		if multiplier == 0 {
+			multiplier = 2
+		}
+
+		// without a maximum, enough attempts would overflow the duration,
+		// leaving no wait at all
+		next := float64(previous) * multiplier
+		if next >= math.MaxInt64 {
+			wait = math.MaxInt64
+		} else {
+			wait = time.Duration(next)
+		}
+	}
+
+	if backoff.Max != "" {
+		max, err := time.ParseDuration(backoff.Max)
+		if err != nil {
+			return 0, err
+		}
+
+		if wait > max {
+			wait = max
+		}
+	}
+
+	return wait, nil
+}
+
 // Release releases each nested step.
 func (step *RetryStep) Release() {
 	for _, src := range step.Attempts {
@@ -3,7 +3,10 @@ package exec_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/tedsuo/ifrit"
 
@@ -358,3 +361,251 @@ var _ = Describe("Retry Step", func() {
 		})
 	})
 })
+
+var _ = Describe("Retry Step with a policy", func() {
+	var (
+		attempt1Factory *execfakes.FakeStepFactory
+		attempt1Step    *execfakes.FakeStep
+
+		attempt2Factory *execfakes.FakeStepFactory
+		attempt2Step    *execfakes.FakeStep
+
+		attempt3Factory *execfakes.FakeStepFactory
+		attempt3Step    *execfakes.FakeStep
+
+		fakeDelegate *execfakes.FakeRetryDelegate
+		fakeClock    *fakeclock.FakeClock
+
+		policy atc.RetryPolicy
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		attempt1Factory = new(execfakes.FakeStepFactory)
+		attempt1Step = new(execfakes.FakeStep)
+		attempt1Factory.UsingReturns(attempt1Step)
+
+		attempt2Factory = new(execfakes.FakeStepFactory)
+		attempt2Step = new(execfakes.FakeStep)
+		attempt2Factory.UsingReturns(attempt2Step)
+
+		attempt3Factory = new(execfakes.FakeStepFactory)
+		attempt3Step = new(execfakes.FakeStep)
+		attempt3Factory.UsingReturns(attempt3Step)
+
+		fakeDelegate = new(execfakes.FakeRetryDelegate)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		policy = atc.RetryPolicy{}
+	})
+
+	JustBeforeEach(func() {
+		step = RetryWithPolicy{
+			Attempts: []StepFactory{attempt1Factory, attempt2Factory, attempt3Factory},
+			Policy:   policy,
+			Delegate: fakeDelegate,
+			Clock:    fakeClock,
+		}.Using(nil, nil)
+
+		process = ifrit.Invoke(step)
+	})
+
+	Context("when every attempt fails", func() {
+		BeforeEach(func() {
+			attempt1Step.ResultStub = successResult(false)
+			attempt2Step.ResultStub = successResult(false)
+			attempt3Step.ResultStub = successResult(false)
+		})
+
+		Context("without a backoff", func() {
+			It("runs every attempt without waiting", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(1))
+				Expect(attempt3Step.RunCallCount()).To(Equal(1))
+			})
+
+			It("records each attempt starting", func() {
+				Eventually(process.Wait()).Should(Receive())
+
+				Expect(fakeDelegate.AttemptStartingCallCount()).To(Equal(3))
+
+				for i := 0; i < 3; i++ {
+					attempt, wait := fakeDelegate.AttemptStartingArgsForCall(i)
+					Expect(attempt).To(Equal(i + 1))
+					Expect(wait).To(BeZero())
+				}
+			})
+		})
+
+		Context("with a backoff", func() {
+			BeforeEach(func() {
+				policy.Backoff = &atc.RetryBackoff{
+					Initial:    "10s",
+					Multiplier: 3,
+					Max:        "20s",
+				}
+			})
+
+			It("waits longer before each attempt, up to the maximum", func() {
+				Eventually(fakeDelegate.AttemptStartingCallCount).Should(Equal(1))
+				attempt, wait := fakeDelegate.AttemptStartingArgsForCall(0)
+				Expect(attempt).To(Equal(1))
+				Expect(wait).To(BeZero())
+
+				fakeClock.WaitForWatcherAndIncrement(9 * time.Second)
+				Consistently(attempt2Step.RunCallCount).Should(BeZero())
+
+				fakeClock.Increment(time.Second)
+				Eventually(attempt2Step.RunCallCount).Should(Equal(1))
+
+				attempt, wait = fakeDelegate.AttemptStartingArgsForCall(1)
+				Expect(attempt).To(Equal(2))
+				Expect(wait).To(Equal(10 * time.Second))
+
+				Eventually(func() int {
+					fakeClock.Increment(20 * time.Second)
+					return attempt3Step.RunCallCount()
+				}).Should(Equal(1))
+
+				attempt, wait = fakeDelegate.AttemptStartingArgsForCall(2)
+				Expect(attempt).To(Equal(3))
+				Expect(wait).To(Equal(20 * time.Second))
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+			})
+
+			Context("when the wait grows too long to represent", func() {
+				BeforeEach(func() {
+					policy.Backoff = &atc.RetryBackoff{
+						Initial:    "2000000h",
+						Multiplier: 10,
+					}
+				})
+
+				It("keeps waiting as long as it can", func() {
+					Eventually(attempt1Step.RunCallCount).Should(Equal(1))
+
+					fakeClock.WaitForWatcherAndIncrement(2000000 * time.Hour)
+					Eventually(attempt2Step.RunCallCount).Should(Equal(1))
+
+					Consistently(attempt3Step.RunCallCount).Should(BeZero())
+
+					process.Signal(os.Interrupt)
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+				})
+			})
+
+			Context("when interrupted while waiting", func() {
+				It("returns ErrInterrupted without running the next attempt", func() {
+					Eventually(attempt1Step.RunCallCount).Should(Equal(1))
+
+					fakeClock.WaitForWatcherAndIncrement(time.Second)
+					process.Signal(os.Interrupt)
+
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+					Expect(attempt2Step.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when only retrying on some exit statuses", func() {
+			BeforeEach(func() {
+				policy.RetryOn = &atc.RetryConditions{
+					ExitStatuses: []int{2},
+				}
+
+				attempt1Step.ResultStub = func(x interface{}) bool {
+					switch v := x.(type) {
+					case *Success:
+						*v = false
+						return true
+					case *ExitStatus:
+						*v = 2
+						return true
+					default:
+						return false
+					}
+				}
+
+				attempt2Step.ResultStub = func(x interface{}) bool {
+					switch v := x.(type) {
+					case *Success:
+						*v = false
+						return true
+					case *ExitStatus:
+						*v = 1
+						return true
+					default:
+						return false
+					}
+				}
+			})
+
+			It("stops once an attempt exits with another status", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(1))
+				Expect(attempt3Step.RunCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when only retrying on errors", func() {
+			BeforeEach(func() {
+				policy.RetryOn = &atc.RetryConditions{
+					Errors: true,
+				}
+			})
+
+			It("does not retry the failure", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when an attempt errors", func() {
+		disaster := errors.New("oh no")
+
+		BeforeEach(func() {
+			attempt1Step.RunReturns(disaster)
+			attempt2Step.ResultStub = successResult(true)
+		})
+
+		Context("when only retrying on errors", func() {
+			BeforeEach(func() {
+				policy.RetryOn = &atc.RetryConditions{
+					Errors: true,
+				}
+			})
+
+			It("retries the error", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(1))
+				Expect(attempt3Step.RunCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when only retrying on exit statuses", func() {
+			BeforeEach(func() {
+				policy.RetryOn = &atc.RetryConditions{
+					ExitStatuses: []int{1},
+				}
+			})
+
+			It("returns the error without retrying", func() {
+				Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+
+				Expect(attempt2Step.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -3,12 +3,14 @@ package exec
 import (
 	"archive/tar"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +26,10 @@ import (
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// CacheLockInterval is how often a task waiting for another build to finish
+// using one of its caches checks whether it's done.
+const CacheLockInterval = 5 * time.Second
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -39,6 +45,7 @@ func (err MissingInputsError) Error() string {
 // SourceRepository and outputs will be added to the SourceRepository.
 type TaskStep struct {
 	logger            lager.Logger
+	stepMetadata      StepMetadata
 	containerID       worker.Identifier
 	metadata          worker.Metadata
 	tags              atc.Tags
@@ -47,11 +54,14 @@ type TaskStep struct {
 	privileged        Privileged
 	configSource      TaskConfigSource
 	workerPool        worker.Client
+	lockDB            LockDB
+	placementStrategy worker.ContainerPlacementStrategy
 	artifactsRoot     string
 	resourceTypes     atc.ResourceTypes
 	inputMapping      map[string]string
 	outputMapping     map[string]string
 	imageArtifactName string
+	defaultLimits     atc.ContainerLimits
 	clock             clock.Clock
 	repo              *SourceRepository
 
@@ -66,6 +76,7 @@ type TaskStep struct {
 
 func newTaskStep(
 	logger lager.Logger,
+	stepMetadata StepMetadata,
 	containerID worker.Identifier,
 	metadata worker.Metadata,
 	tags atc.Tags,
@@ -74,17 +85,21 @@ func newTaskStep(
 	privileged Privileged,
 	configSource TaskConfigSource,
 	workerPool worker.Client,
+	lockDB LockDB,
+	placementStrategy worker.ContainerPlacementStrategy,
 	artifactsRoot string,
 	resourceTypes atc.ResourceTypes,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	defaultLimits atc.ContainerLimits,
 	clock clock.Clock,
 	containerSuccessTTL time.Duration,
 	containerFailureTTL time.Duration,
 ) TaskStep {
 	return TaskStep{
 		logger:              logger,
+		stepMetadata:        stepMetadata,
 		containerID:         containerID,
 		metadata:            metadata,
 		tags:                tags,
@@ -93,11 +108,14 @@ func newTaskStep(
 		privileged:          privileged,
 		configSource:        configSource,
 		workerPool:          workerPool,
+		lockDB:              lockDB,
+		placementStrategy:   placementStrategy,
 		artifactsRoot:       artifactsRoot,
 		resourceTypes:       resourceTypes,
 		inputMapping:        inputMapping,
 		outputMapping:       outputMapping,
 		imageArtifactName:   imageArtifactName,
+		defaultLimits:       defaultLimits,
 		clock:               clock,
 		containerSuccessTTL: containerSuccessTTL,
 		containerFailureTTL: containerFailureTTL,
@@ -116,8 +134,8 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 }
 
 // Run will first load the TaskConfig. A worker will be selected based on the
-// TaskConfig's platform, the TaskStep's tags, and prioritized by availability
-// of volumes for the TaskConfig's inputs. Inputs that did not have volumes
+// TaskConfig's platform and the TaskStep's tags, and then chosen among those by
+// the configured ContainerPlacementStrategy. Inputs that did not have volumes
 // available on the worker will be streamed in to the container.
 //
 // If any inputs are not available in the SourceRepository, MissingInputsError
@@ -127,6 +145,9 @@ func (step TaskStep) Using(prev Step, repo *SourceRepository) Step {
 // the RunStep indicates that it's ready, and any signals will be forwarded to
 // the script.
 //
+// The script runs with the build's metadata and the versions of its inputs in
+// its environment, in addition to the task's params.
+//
 // If the script exits successfully, the outputs specified in the TaskConfig
 // are registered with the SourceRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
@@ -150,7 +171,15 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
-	step.metadata.EnvironmentVariables = step.envForParams(config.Params)
+	cacheLocks, err := step.lockCaches(config.Caches, signals)
+	if err != nil {
+		return err
+	}
+
+	defer step.releaseCaches(cacheLocks)
+
+	env := step.envFor(config)
+	step.metadata.EnvironmentVariables = env
 
 	runContainerID := step.containerID
 	runContainerID.Stage = db.ContainerStageRun
@@ -238,7 +267,7 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.process, err = step.container.Run(garden.ProcessSpec{
 			Path: config.Run.Path,
 			Args: config.Run.Args,
-			Env:  step.envForParams(config.Params),
+			Env:  env,
 
 			Dir: path.Join(step.artifactsRoot, config.Run.Dir),
 			TTY: &garden.TTYSpec{},
@@ -298,7 +327,7 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 }
 
 func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config atc.TaskConfig, signals <-chan os.Signal) (worker.Container, []inputPair, error) {
-	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorkerWithMostVolumes(compatibleWorkers, config.Inputs)
+	chosenWorker, inputMounts, inputsToStream, err := step.chooseWorker(compatibleWorkers, config.Inputs)
 	if err != nil {
 		return nil, []inputPair{}, err
 	}
@@ -331,6 +360,13 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		step.logger.Debug("created-output-volume", lager.Data{"volume-Handle": outVolume.Handle()})
 	}
 
+	cacheMounts, err := step.cachesOn(chosenWorker, config.Caches)
+	if err != nil {
+		return nil, []inputPair{}, err
+	}
+
+	outputMounts = append(outputMounts, cacheMounts...)
+
 	var imageSpec worker.ImageSpec
 	if step.imageArtifactName != "" {
 		source, found := step.repo.SourceFor(SourceName(step.imageArtifactName))
@@ -421,6 +457,7 @@ func (step *TaskStep) createContainer(compatibleWorkers []worker.Worker, config
 		Outputs:   outputMounts,
 		ImageSpec: imageSpec,
 		User:      config.Run.User,
+		Limits:    step.limitsFor(config),
 	}
 
 	runContainerID := step.containerID
@@ -509,33 +546,168 @@ func (step *TaskStep) Release() {
 	}
 }
 
-func (step *TaskStep) chooseWorkerWithMostVolumes(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
-	inputMounts := []worker.VolumeMount{}
-	inputsToStream := []inputPair{}
+func (step *TaskStep) chooseWorker(compatibleWorkers []worker.Worker, inputs []atc.TaskInputConfig) (worker.Worker, []worker.VolumeMount, []inputPair, error) {
+	inputSources := []worker.InputSource{}
+	for _, input := range inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
 
-	var chosenWorker worker.Worker
-	for _, w := range compatibleWorkers {
-		mounts, toStream, err := step.inputsOn(inputs, w)
-		if err != nil {
-			return nil, nil, nil, err
+		source, found := step.repo.SourceFor(SourceName(inputName))
+		if found {
+			inputSources = append(inputSources, source)
 		}
+	}
 
-		if len(mounts) >= len(inputMounts) {
-			for _, mount := range inputMounts {
-				mount.Volume.Release(nil)
-			}
+	chosenWorker, err := step.placementStrategy.Choose(compatibleWorkers, inputSources)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-			inputMounts = mounts
-			inputsToStream = toStream
-			chosenWorker = w
+	inputMounts, inputsToStream, err := step.inputsOn(inputs, chosenWorker)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return chosenWorker, inputMounts, inputsToStream, nil
+}
+
+// cachesOn finds or creates the volumes for the task's caches on the chosen
+// worker. Unlike inputs, the volumes are mounted directly rather than
+// copy-on-write, so that whatever the task leaves in them is there for the
+// next build of the job on the worker; see lockCaches for how concurrent
+// builds are kept out of each other's way. Tasks not run by a job (i.e. in
+// one-off builds) have nothing to share their caches with, and get none.
+func (step *TaskStep) cachesOn(chosenWorker worker.Worker, caches []atc.CacheConfig) ([]worker.VolumeMount, error) {
+	if step.metadata.JobName == "" {
+		return nil, nil
+	}
+
+	var mounts []worker.VolumeMount
+
+	for _, cache := range caches {
+		cachePath := filepath.Clean(cache.Path)
+
+		volumeSpec := worker.VolumeSpec{
+			Strategy: worker.TaskCacheStrategy{
+				WorkerName: chosenWorker.Name(),
+				PipelineID: step.metadata.PipelineID,
+				JobName:    step.metadata.JobName,
+				StepName:   step.metadata.StepName,
+				Path:       cachePath,
+			},
+			Privileged: bool(step.privileged),
+			TTL:        worker.VolumeTTL,
+		}
+
+		cacheVolume, found, err := chosenWorker.FindVolume(step.logger, volumeSpec)
+		if err == worker.ErrNoVolumeManager {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			step.logger.Debug("found-cache-volume", lager.Data{"path": cachePath, "volume-handle": cacheVolume.Handle()})
 		} else {
-			for _, mount := range mounts {
-				mount.Volume.Release(nil)
+			cacheVolume, err = chosenWorker.CreateVolume(step.logger, volumeSpec, step.teamID)
+			if err != nil {
+				return nil, err
 			}
+
+			step.logger.Debug("created-cache-volume", lager.Data{"path": cachePath, "volume-handle": cacheVolume.Handle()})
 		}
+
+		mounts = append(mounts, worker.VolumeMount{
+			Volume:    cacheVolume,
+			MountPath: filepath.Join(step.artifactsRoot, cachePath),
+		})
 	}
 
-	return chosenWorker, inputMounts, inputsToStream, nil
+	return mounts, nil
+}
+
+// lockCaches waits until no other build of the job is running the step with
+// any of the task's caches, as they are mounted read-write and would be
+// corrupted by concurrent writes. The locks are taken in order of path so that
+// two builds can't each hold a lock the other is waiting for.
+func (step *TaskStep) lockCaches(caches []atc.CacheConfig, signals <-chan os.Signal) ([]db.Lock, error) {
+	if step.metadata.JobName == "" {
+		return nil, nil
+	}
+
+	paths := []string{}
+	for _, cache := range caches {
+		paths = append(paths, filepath.Clean(cache.Path))
+	}
+
+	sort.Strings(paths)
+
+	locks := []db.Lock{}
+	for _, cachePath := range paths {
+		lock, err := step.lockCache(cachePath, signals)
+		if err != nil {
+			step.releaseCaches(locks)
+			return nil, err
+		}
+
+		locks = append(locks, lock)
+	}
+
+	return locks, nil
+}
+
+func (step *TaskStep) lockCache(cachePath string, signals <-chan os.Signal) (db.Lock, error) {
+	lockName := fmt.Sprintf(
+		"task-cache:%d/%s/%s:%s",
+		step.metadata.PipelineID,
+		step.metadata.JobName,
+		step.metadata.StepName,
+		cachePath,
+	)
+
+	lockLogger := step.logger.Session("lock-cache", lager.Data{"lock-name": lockName})
+
+	ticker := step.clock.NewTicker(CacheLockInterval)
+	defer ticker.Stop()
+
+	waiting := false
+
+	for {
+		lock, acquired, err := step.lockDB.GetTaskLock(lockLogger, lockName)
+		if err != nil {
+			lockLogger.Error("failed-to-get-lock", err)
+			return nil, err
+		}
+
+		if acquired {
+			return lock, nil
+		}
+
+		if !waiting {
+			lockLogger.Debug("waiting-for-lock")
+			fmt.Fprintf(step.delegate.Stderr(), "waiting for another build to finish using cache '%s'\n", cachePath)
+			waiting = true
+		}
+
+		select {
+		case <-ticker.C():
+		case <-signals:
+			return nil, ErrInterrupted
+		}
+	}
+}
+
+func (step *TaskStep) releaseCaches(locks []db.Lock) {
+	for _, lock := range locks {
+		err := lock.Release()
+		if err != nil {
+			step.logger.Error("failed-to-release-cache-lock", err)
+		}
+	}
 }
 
 type inputPair struct {
@@ -630,16 +802,71 @@ func (step *TaskStep) setupOutputs(outputs []atc.TaskOutputConfig) error {
 	return nil
 }
 
-func (TaskStep) envForParams(params map[string]string) []string {
-	env := make([]string, 0, len(params))
+// limitsFor overrides the default container limits with any set by the task.
+func (step *TaskStep) limitsFor(config atc.TaskConfig) atc.ContainerLimits {
+	if config.ContainerLimits == nil {
+		return step.defaultLimits
+	}
+
+	return step.defaultLimits.Merge(*config.ContainerLimits)
+}
+
+// envFor constructs the environment of the task's process: the metadata of
+// the build, followed by the version of each input that was fetched by a get
+// step, followed by the task's params, so that params can override the rest.
+func (step *TaskStep) envFor(config atc.TaskConfig) []string {
+	env := step.stepMetadata.Env()
+
+	for _, input := range config.Inputs {
+		sourceName := input.Name
+		if mappedName, ok := step.inputMapping[sourceName]; ok {
+			sourceName = mappedName
+		}
+
+		source, found := step.repo.SourceFor(SourceName(sourceName))
+		if !found {
+			continue
+		}
 
-	for k, v := range params {
+		versioned, ok := source.(Step)
+		if !ok {
+			continue
+		}
+
+		var info VersionInfo
+		if !versioned.Result(&info) || info.Version == nil {
+			continue
+		}
+
+		version, err := json.Marshal(info.Version)
+		if err != nil {
+			continue
+		}
+
+		env = append(env, inputVersionEnvName(input.Name)+"="+string(version))
+	}
+
+	for k, v := range config.Params {
 		env = append(env, k+"="+v)
 	}
 
 	return env
 }
 
+// inputVersionEnvName converts an input name to e.g. BUILD_INPUT_SOME_REPO_VERSION,
+// as input names may contain characters not allowed in variable names.
+func inputVersionEnvName(inputName string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, inputName)
+
+	return "BUILD_INPUT_" + strings.ToUpper(name) + "_VERSION"
+}
+
 type containerDestination struct {
 	container     garden.Container
 	inputConfig   atc.TaskInputConfig
@@ -4,7 +4,6 @@ import (
 	"archive/tar"
 	"bytes"
 	"errors"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -18,6 +17,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	rfakes "github.com/concourse/atc/resource/resourcefakes"
@@ -31,8 +31,10 @@ import (
 
 var _ = Describe("GardenFactory", func() {
 	var (
-		fakeWorkerClient *wfakes.FakeClient
-		fakeTracker      *rfakes.FakeTracker
+		fakeWorkerClient      *wfakes.FakeClient
+		fakeTracker           *rfakes.FakeTracker
+		fakeLockDB            *execfakes.FakeLockDB
+		fakePlacementStrategy *wfakes.FakeContainerPlacementStrategy
 
 		factory Factory
 
@@ -51,7 +53,15 @@ var _ = Describe("GardenFactory", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeResourceFetcher := new(rfakes.FakeFetcher)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher)
+		fakeLockDB = new(execfakes.FakeLockDB)
+		fakeLockDB.GetTaskLockReturns(new(dbfakes.FakeLock), true, nil)
+
+		fakePlacementStrategy = new(wfakes.FakeContainerPlacementStrategy)
+		fakePlacementStrategy.ChooseStub = func(workers []worker.Worker, inputs []worker.InputSource) (worker.Worker, error) {
+			return workers[0], nil
+		}
+
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, fakeResourceFetcher, fakeLockDB, fakePlacementStrategy, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -67,6 +77,7 @@ var _ = Describe("GardenFactory", func() {
 			resourceTypes atc.ResourceTypes
 			inputMapping  map[string]string
 			outputMapping map[string]string
+			stepMetadata  testMetadata
 
 			inStep *execfakes.FakeStep
 			repo   *SourceRepository
@@ -87,6 +98,7 @@ var _ = Describe("GardenFactory", func() {
 			tags = []string{"step", "tags"}
 			teamID = 123
 			configSource = new(execfakes.FakeTaskConfigSource)
+			stepMetadata = nil
 
 			inStep = new(execfakes.FakeStep)
 			repo = NewSourceRepository()
@@ -122,6 +134,7 @@ var _ = Describe("GardenFactory", func() {
 		JustBeforeEach(func() {
 			step = factory.Task(
 				lagertest.NewTestLogger("test"),
+				stepMetadata,
 				sourceName,
 				identifier,
 				workerMetadata,
@@ -379,6 +392,100 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						It("creates the container without limits", func() {
+							_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+							Expect(spec.Limits).To(BeZero())
+						})
+
+						Context("when there are default container limits", func() {
+							BeforeEach(func() {
+								factory = NewGardenFactory(fakeWorkerClient, fakeTracker, new(rfakes.FakeFetcher), fakeLockDB, fakePlacementStrategy, atc.ContainerLimits{
+									CPU:    512,
+									Memory: 1024,
+								})
+							})
+
+							It("creates the container with the default limits", func() {
+								_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{CPU: 512, Memory: 1024}))
+							})
+
+							Context("when the configuration specifies container limits", func() {
+								BeforeEach(func() {
+									fetchedConfig.ContainerLimits = &atc.ContainerLimits{Memory: 2048}
+									configSource.FetchConfigReturns(fetchedConfig, nil)
+								})
+
+								It("overrides the defaults with the configured limits", func() {
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Limits).To(Equal(atc.ContainerLimits{CPU: 512, Memory: 2048}))
+								})
+							})
+						})
+
+						Context("when the step has build metadata", func() {
+							BeforeEach(func() {
+								stepMetadata = testMetadata{"BUILD_ID=1", "BUILD_NAME=42"}
+							})
+
+							It("runs the process with the metadata in its environment, before the params", func() {
+								Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+								spec, _ := fakeContainer.RunArgsForCall(0)
+								Expect(spec.Env).To(Equal([]string{"BUILD_ID=1", "BUILD_NAME=42", "SOME=params"}))
+							})
+
+							Context("when an input was fetched by a get step", func() {
+								var inputSource *versionedArtifactSource
+
+								BeforeEach(func() {
+									inputSource = &versionedArtifactSource{
+										FakeArtifactSource: new(execfakes.FakeArtifactSource),
+										FakeStep:           new(execfakes.FakeStep),
+									}
+
+									inputSource.FakeStep.ResultStub = func(x interface{}) bool {
+										switch v := x.(type) {
+										case *VersionInfo:
+											*v = VersionInfo{Version: atc.Version{"ref": "abc"}}
+											return true
+										default:
+											return false
+										}
+									}
+
+									inputSource.FakeArtifactSource.VolumeOnReturns(nil, false, nil)
+
+									repo.RegisterSource("some-mapped-input", inputSource)
+									inputMapping = map[string]string{"some-input.v2": "some-mapped-input"}
+
+									configSource.FetchConfigReturns(atc.TaskConfig{
+										Platform: "some-platform",
+										Image:    "some-image",
+										Params:   map[string]string{"SOME": "params"},
+										Run: atc.TaskRunConfig{
+											Path: "ls",
+										},
+										Inputs: []atc.TaskInputConfig{
+											{Name: "some-input.v2"},
+										},
+									}, nil)
+								})
+
+								It("includes the input's version under its name in the environment", func() {
+									Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+									spec, _ := fakeContainer.RunArgsForCall(0)
+									Expect(spec.Env).To(Equal([]string{
+										"BUILD_ID=1",
+										"BUILD_NAME=42",
+										`BUILD_INPUT_SOME_INPUT_V2_VERSION={"ref":"abc"}`,
+										"SOME=params",
+									}))
+								})
+							})
+						})
+
 						Context("when the configuration specifies paths for inputs", func() {
 							var inputSource *execfakes.FakeArtifactSource
 							var otherInputSource *execfakes.FakeArtifactSource
@@ -628,6 +735,179 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when the configuration specifies caches", func() {
+							var cacheVolume *wfakes.FakeVolume
+
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Caches: []atc.CacheConfig{
+										{Path: "some/cache/"},
+									},
+								}, nil)
+
+								fakeWorker.NameReturns("some-worker")
+
+								cacheVolume = new(wfakes.FakeVolume)
+								cacheVolume.HandleReturns("some-cache-handle")
+
+								workerMetadata.PipelineID = 42
+							})
+
+							expectedStrategy := worker.TaskCacheStrategy{
+								WorkerName: "some-worker",
+								JobName:    "some-job",
+								StepName:   "some-step",
+								Path:       "some/cache",
+							}
+
+							Context("when the cache is not in use by another build", func() {
+								var fakeLock *dbfakes.FakeLock
+
+								BeforeEach(func() {
+									fakeLock = new(dbfakes.FakeLock)
+									fakeLockDB.GetTaskLockReturns(fakeLock, true, nil)
+
+									fakeWorker.FindVolumeReturns(cacheVolume, true, nil)
+								})
+
+								It("locks the cache for the job's step", func() {
+									Expect(fakeLockDB.GetTaskLockCallCount()).To(Equal(1))
+									_, lockName := fakeLockDB.GetTaskLockArgsForCall(0)
+									Expect(lockName).To(Equal("task-cache:42/some-job/some-step:some/cache"))
+								})
+
+								It("releases the lock once the task has exited", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when the cache is in use by another build", func() {
+								var fakeLock *dbfakes.FakeLock
+
+								BeforeEach(func() {
+									fakeLock = new(dbfakes.FakeLock)
+
+									fakeLockDB.GetTaskLockStub = func(lager.Logger, string) (db.Lock, bool, error) {
+										if fakeLockDB.GetTaskLockCallCount() == 1 {
+											fakeClock.Increment(CacheLockInterval)
+											return nil, false, nil
+										}
+
+										return fakeLock, true, nil
+									}
+
+									fakeWorker.FindVolumeReturns(cacheVolume, true, nil)
+								})
+
+								It("tells the user it is waiting for the cache", func() {
+									Expect(stderrBuf).To(gbytes.Say("waiting for another build to finish using cache 'some/cache'"))
+								})
+
+								It("retries until it gets the lock before running the task", func() {
+									Expect(fakeLockDB.GetTaskLockCallCount()).To(Equal(2))
+									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+								})
+							})
+
+							Context("when locking the cache fails", func() {
+								disaster := errors.New("nope")
+
+								BeforeEach(func() {
+									fakeLockDB.GetTaskLockReturns(nil, false, disaster)
+								})
+
+								It("exits with the error without running the task", func() {
+									Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+									Expect(fakeWorker.CreateContainerCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the cache's volume exists on the worker", func() {
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(cacheVolume, true, nil)
+								})
+
+								It("looks up the volume for the job's task", func() {
+									Expect(fakeWorker.FindVolumeCallCount()).To(Equal(1))
+
+									_, spec := fakeWorker.FindVolumeArgsForCall(0)
+									Expect(spec.Strategy).To(Equal(expectedStrategy))
+								})
+
+								It("mounts the volume directly", func() {
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+
+									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Outputs).To(ConsistOf(worker.VolumeMount{
+										Volume:    cacheVolume,
+										MountPath: "/tmp/build/a1f5c0c1/some/cache",
+									}))
+								})
+							})
+
+							Context("when the cache's volume does not exist on the worker", func() {
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(nil, false, nil)
+									fakeWorker.CreateVolumeReturns(cacheVolume, nil)
+								})
+
+								It("creates a volume for the job's task", func() {
+									Expect(fakeWorker.CreateVolumeCallCount()).To(Equal(1))
+
+									_, spec, actualTeamID := fakeWorker.CreateVolumeArgsForCall(0)
+									Expect(spec.Strategy).To(Equal(expectedStrategy))
+									Expect(actualTeamID).To(Equal(teamID))
+								})
+
+								It("mounts the created volume directly", func() {
+									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Outputs).To(ConsistOf(worker.VolumeMount{
+										Volume:    cacheVolume,
+										MountPath: "/tmp/build/a1f5c0c1/some/cache",
+									}))
+								})
+							})
+
+							Context("when looking up the cache's volume fails", func() {
+								disaster := errors.New("nope")
+
+								BeforeEach(func() {
+									fakeWorker.FindVolumeReturns(nil, false, disaster)
+								})
+
+								It("exits with the error", func() {
+									Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								})
+							})
+
+							Context("when the task is not run by a job", func() {
+								BeforeEach(func() {
+									workerMetadata.JobName = ""
+								})
+
+								It("does not use any cache volumes", func() {
+									Expect(fakeLockDB.GetTaskLockCallCount()).To(BeZero())
+									Expect(fakeWorker.FindVolumeCallCount()).To(BeZero())
+									Expect(fakeWorker.CreateVolumeCallCount()).To(BeZero())
+
+									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(1))
+									_, _, _, _, _, spec, _ := fakeWorker.CreateContainerArgsForCall(0)
+									Expect(spec.Outputs).To(BeEmpty())
+								})
+							})
+						})
+
 						Context("when the configuration specifies paths for outputs", func() {
 							BeforeEach(func() {
 								configSource.FetchConfigReturns(atc.TaskConfig{
@@ -1986,73 +2266,57 @@ var _ = Describe("GardenFactory", func() {
 								repo.RegisterSource("some-other-input", otherInputSource)
 							})
 
-							Context("and some workers have more matching input volumes than others", func() {
-								var rootVolume *wfakes.FakeVolume
+							Context("and the placement strategy chooses a worker", func() {
 								var inputVolume *wfakes.FakeVolume
-								var inputVolume2 *wfakes.FakeVolume
-								var inputVolume3 *wfakes.FakeVolume
 								var otherInputVolume *wfakes.FakeVolume
 
 								BeforeEach(func() {
-									rootVolume = new(wfakes.FakeVolume)
-									rootVolume.HandleReturns("root-volume")
-
 									inputVolume = new(wfakes.FakeVolume)
 									inputVolume.HandleReturns("input-volume")
 
-									inputVolume2 = new(wfakes.FakeVolume)
-									inputVolume2.HandleReturns("input-volume")
-
-									inputVolume3 = new(wfakes.FakeVolume)
-									inputVolume3.HandleReturns("input-volume")
-
 									otherInputVolume = new(wfakes.FakeVolume)
 									otherInputVolume.HandleReturns("other-input-volume")
 
-									fakeWorker2.CreateVolumeReturns(rootVolume, nil)
-
-									inputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return inputVolume, true, nil
-										} else if w == fakeWorker2 {
-											return inputVolume2, true, nil
-										} else if w == fakeWorker3 {
-											return inputVolume3, true, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
+									inputSource.VolumeOnReturns(inputVolume, true, nil)
+									otherInputSource.VolumeOnReturns(otherInputVolume, true, nil)
 
-									otherInputSource.VolumeOnStub = func(w worker.Worker) (worker.Volume, bool, error) {
-										if w == fakeWorker {
-											return nil, false, nil
-										} else if w == fakeWorker2 {
-											return otherInputVolume, true, nil
-										} else if w == fakeWorker3 {
-											return nil, false, nil
-										} else {
-											return nil, false, fmt.Errorf("unexpected worker: %#v\n", w)
-										}
-									}
+									fakePlacementStrategy.ChooseReturns(fakeWorker2, nil)
 
-									fakeWorker.CreateContainerReturns(nil, errors.New("fall out of method here"))
 									fakeWorker2.CreateContainerReturns(nil, errors.New("fall out of method here"))
 								})
 
-								It("picks the worker that has the most", func() {
+								It("gives the strategy every compatible worker and the input sources", func() {
+									Expect(fakePlacementStrategy.ChooseCallCount()).To(Equal(1))
+									workers, inputs := fakePlacementStrategy.ChooseArgsForCall(0)
+									Expect(workers).To(Equal([]worker.Worker{fakeWorker, fakeWorker2, fakeWorker3}))
+									Expect(inputs).To(ConsistOf(inputSource, otherInputSource))
+								})
+
+								It("creates the container on the chosen worker", func() {
 									Expect(fakeWorker.CreateContainerCallCount()).To(Equal(0))
 									Expect(fakeWorker2.CreateContainerCallCount()).To(Equal(1))
 									Expect(fakeWorker3.CreateContainerCallCount()).To(Equal(0))
 								})
 
-								It("releases the volumes on the unused workers", func() {
+								It("only looks up input volumes on the chosen worker", func() {
+									Expect(inputSource.VolumeOnCallCount()).To(Equal(1))
+									Expect(inputSource.VolumeOnArgsForCall(0)).To(Equal(fakeWorker2))
 									Expect(inputVolume.ReleaseCallCount()).To(Equal(1))
-									Expect(inputVolume3.ReleaseCallCount()).To(Equal(1))
-
-									Expect(inputVolume2.ReleaseCallCount()).To(Equal(1))
 									Expect(otherInputVolume.ReleaseCallCount()).To(Equal(1))
 								})
 							})
+
+							Context("and the placement strategy fails", func() {
+								disaster := errors.New("nope")
+
+								BeforeEach(func() {
+									fakePlacementStrategy.ChooseReturns(nil, disaster)
+								})
+
+								It("exits with the error", func() {
+									Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								})
+							})
 						})
 					})
 				})
@@ -2267,3 +2531,8 @@ var _ = Describe("GardenFactory", func() {
 		})
 	})
 })
+
+type versionedArtifactSource struct {
+	*execfakes.FakeArtifactSource
+	*execfakes.FakeStep
+}
@@ -0,0 +1,59 @@
+package auditreaper
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . AuditReaperDB
+
+type AuditReaperDB interface {
+	DeleteAuditEventsBefore(before time.Time) (int, error)
+}
+
+type AuditReaper interface {
+	Run() error
+}
+
+type auditReaper struct {
+	logger    lager.Logger
+	db        AuditReaperDB
+	retention time.Duration
+	clock     clock.Clock
+}
+
+// NewAuditReaper returns an AuditReaper which deletes audit events once they
+// are older than retention. A retention of zero keeps them forever.
+func NewAuditReaper(
+	logger lager.Logger,
+	db AuditReaperDB,
+	retention time.Duration,
+	clock clock.Clock,
+) AuditReaper {
+	return &auditReaper{
+		logger:    logger,
+		db:        db,
+		retention: retention,
+		clock:     clock,
+	}
+}
+
+func (r *auditReaper) Run() error {
+	if r.retention == 0 {
+		return nil
+	}
+
+	deleted, err := r.db.DeleteAuditEventsBefore(r.clock.Now().Add(-r.retention))
+	if err != nil {
+		r.logger.Error("failed-to-delete-audit-events", err)
+		return err
+	}
+
+	if deleted > 0 {
+		r.logger.Info("deleted-audit-events", lager.Data{"count": deleted})
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package auditreaper_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAuditReaper(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Reaper Suite")
+}
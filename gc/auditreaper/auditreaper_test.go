@@ -0,0 +1,69 @@
+package auditreaper_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/gc/auditreaper"
+	"github.com/concourse/atc/gc/auditreaper/auditreaperfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditReaper", func() {
+	var (
+		fakeDB    *auditreaperfakes.FakeAuditReaperDB
+		fakeClock *fakeclock.FakeClock
+		retention time.Duration
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(auditreaperfakes.FakeAuditReaperDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		retention = 24 * time.Hour
+	})
+
+	JustBeforeEach(func() {
+		reaper := auditreaper.NewAuditReaper(
+			lagertest.NewTestLogger("test"),
+			fakeDB,
+			retention,
+			fakeClock,
+		)
+
+		runErr = reaper.Run()
+	})
+
+	It("deletes the events older than the retention period", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeDB.DeleteAuditEventsBeforeCallCount()).To(Equal(1))
+		Expect(fakeDB.DeleteAuditEventsBeforeArgsForCall(0)).To(Equal(fakeClock.Now().Add(-24 * time.Hour)))
+	})
+
+	Context("when deleting the events fails", func() {
+		BeforeEach(func() {
+			fakeDB.DeleteAuditEventsBeforeReturns(0, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+
+	Context("when there is no retention period", func() {
+		BeforeEach(func() {
+			retention = 0
+		})
+
+		It("keeps the events forever", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeDB.DeleteAuditEventsBeforeCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package auditreaperfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/gc/auditreaper"
+)
+
+type FakeAuditReaperDB struct {
+	DeleteAuditEventsBeforeStub        func(before time.Time) (int, error)
+	deleteAuditEventsBeforeMutex       sync.RWMutex
+	deleteAuditEventsBeforeArgsForCall []struct {
+		before time.Time
+	}
+	deleteAuditEventsBeforeReturns struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditReaperDB) DeleteAuditEventsBefore(before time.Time) (int, error) {
+	fake.deleteAuditEventsBeforeMutex.Lock()
+	fake.deleteAuditEventsBeforeArgsForCall = append(fake.deleteAuditEventsBeforeArgsForCall, struct {
+		before time.Time
+	}{before})
+	fake.recordInvocation("DeleteAuditEventsBefore", []interface{}{before})
+	fake.deleteAuditEventsBeforeMutex.Unlock()
+	if fake.DeleteAuditEventsBeforeStub != nil {
+		return fake.DeleteAuditEventsBeforeStub(before)
+	} else {
+		return fake.deleteAuditEventsBeforeReturns.result1, fake.deleteAuditEventsBeforeReturns.result2
+	}
+}
+
+func (fake *FakeAuditReaperDB) DeleteAuditEventsBeforeCallCount() int {
+	fake.deleteAuditEventsBeforeMutex.RLock()
+	defer fake.deleteAuditEventsBeforeMutex.RUnlock()
+	return len(fake.deleteAuditEventsBeforeArgsForCall)
+}
+
+func (fake *FakeAuditReaperDB) DeleteAuditEventsBeforeArgsForCall(i int) time.Time {
+	fake.deleteAuditEventsBeforeMutex.RLock()
+	defer fake.deleteAuditEventsBeforeMutex.RUnlock()
+	return fake.deleteAuditEventsBeforeArgsForCall[i].before
+}
+
+func (fake *FakeAuditReaperDB) DeleteAuditEventsBeforeReturns(result1 int, result2 error) {
+	fake.DeleteAuditEventsBeforeStub = nil
+	fake.deleteAuditEventsBeforeReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditReaperDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteAuditEventsBeforeMutex.RLock()
+	defer fake.deleteAuditEventsBeforeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuditReaperDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auditreaper.AuditReaperDB = new(FakeAuditReaperDB)
@@ -1,8 +1,13 @@
 package buildreaper
 
 import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/logarchive"
 )
 
 //go:generate counterfeiter . BuildReaperDB
@@ -21,19 +26,32 @@ type buildReaper struct {
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
 	batchSize         int
+	defaultRetention  atc.BuildLogRetention
+	clock             clock.Clock
+	logArchive        logarchive.LogArchive
 }
 
+// NewBuildReaper returns a BuildReaper which applies defaultRetention to any
+// job that does not configure a build log retention policy of its own. If
+// logArchive is non-nil, builds' events are archived to it before they are
+// deleted.
 func NewBuildReaper(
 	logger lager.Logger,
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
 	batchSize int,
+	defaultRetention atc.BuildLogRetention,
+	clock clock.Clock,
+	logArchive logarchive.LogArchive,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
 		batchSize:         batchSize,
+		defaultRetention:  defaultRetention,
+		clock:             clock,
+		logArchive:        logArchive,
 	}
 }
 
@@ -58,7 +76,12 @@ func (br *buildReaper) Run() error {
 		}
 
 		for _, job := range jobs {
-			if job.Config.BuildLogsToRetain == 0 {
+			retention, configured := job.Config.LogRetention()
+			if !configured {
+				retention = br.defaultRetention
+			}
+
+			if !retention.Limited() {
 				continue
 			}
 
@@ -102,34 +125,53 @@ func (br *buildReaper) Run() error {
 				buildIDsToConsiderDeleting = append(buildIDsToConsiderDeleting, build.ID())
 			}
 
-			buildsToRetain, _, err := pipelineDB.GetJobBuilds(
-				job.Job.Name,
-				db.Page{Limit: job.Config.BuildLogsToRetain},
-			)
-			if err != nil {
-				br.logger.Error("could-not-get-job-builds-to-retain", err)
-				return err
+			if len(buildsToConsiderDeleting) == 0 {
+				continue
 			}
 
-			buildIDsToRetain := []int{}
-			for _, build := range buildsToRetain {
-				buildIDsToRetain = append(buildIDsToRetain, build.ID())
+			// builds from this one onwards are within the count limit
+			firstBuildToRetain := 0
+			if retention.Builds > 0 {
+				firstBuildToRetain, err = br.firstOfMostRecentBuilds(pipelineDB, job.Job.Name, retention.Builds)
+				if err != nil {
+					br.logger.Error("could-not-get-job-builds-to-retain", err)
+					return err
+				}
 			}
 
-			if len(buildsToRetain) == 0 {
-				continue
+			// builds from this one onwards are kept no matter what
+			firstBuildToProtect := 0
+			if retention.MinimumBuilds > 0 {
+				firstBuildToProtect, err = br.firstOfMostRecentBuilds(pipelineDB, job.Job.Name, retention.MinimumBuilds)
+				if err != nil {
+					br.logger.Error("could-not-get-job-builds-to-protect", err)
+					return err
+				}
 			}
 
-			firstBuildToRetain := buildsToRetain[len(buildsToRetain)-1].ID()
+			expiry := br.clock.Now().Add(-time.Duration(retention.Days) * 24 * time.Hour)
 
+			buildsToDelete := []db.Build{}
 			buildIDsToDelete := []int{}
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
 
-				if build.ID() >= firstBuildToRetain || build.IsRunning() {
+				if build.IsRunning() {
+					break
+				}
+
+				if firstBuildToProtect != 0 && build.ID() >= firstBuildToProtect {
 					break
 				}
 
+				exceedsCount := firstBuildToRetain != 0 && build.ID() < firstBuildToRetain
+				expired := retention.Days > 0 && finishedBefore(build, expiry)
+
+				if !exceedsCount && !expired {
+					break
+				}
+
+				buildsToDelete = append(buildsToDelete, build)
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
@@ -137,6 +179,16 @@ func (br *buildReaper) Run() error {
 				continue
 			}
 
+			if br.logArchive != nil {
+				for _, build := range buildsToDelete {
+					err = br.archiveBuildEvents(build)
+					if err != nil {
+						br.logger.Error("could-not-archive-build-events", err, lager.Data{"build-id": build.ID()})
+						return err
+					}
+				}
+			}
+
 			err = br.db.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
 			if err != nil {
 				br.logger.Error("could-not-delete-build-events", err)
@@ -153,3 +205,38 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+func (br *buildReaper) archiveBuildEvents(build db.Build) error {
+	events, err := build.Events(0)
+	if err != nil {
+		return err
+	}
+
+	defer events.Close()
+
+	return logarchive.Archive(br.logArchive, build.ID(), events)
+}
+
+// firstOfMostRecentBuilds returns the ID of the oldest of the job's n most
+// recent builds, or 0 if the job has no builds.
+func (br *buildReaper) firstOfMostRecentBuilds(pipelineDB db.PipelineDB, jobName string, n int) (int, error) {
+	builds, _, err := pipelineDB.GetJobBuilds(jobName, db.Page{Limit: n})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(builds) == 0 {
+		return 0, nil
+	}
+
+	return builds[len(builds)-1].ID(), nil
+}
+
+func finishedBefore(build db.Build, expiry time.Time) bool {
+	finished := build.EndTime()
+	if finished.IsZero() {
+		return false
+	}
+
+	return finished.Before(expiry)
+}
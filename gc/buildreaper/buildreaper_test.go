@@ -3,13 +3,20 @@ package buildreaper_test
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
 	. "github.com/concourse/atc/gc/buildreaper"
 	"github.com/concourse/atc/gc/buildreaper/buildreaperfakes"
+	"github.com/concourse/atc/logarchive"
+	"github.com/concourse/atc/logarchive/logarchivefakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,12 +28,18 @@ var _ = Describe("BuildReaper", func() {
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
 		batchSize             int
+		defaultRetention      atc.BuildLogRetention
+		fakeClock             *fakeclock.FakeClock
+		logArchive            logarchive.LogArchive
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		batchSize = 5
+		defaultRetention = atc.BuildLogRetention{}
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123456789, 0))
+		logArchive = nil
 	})
 
 	JustBeforeEach(func() {
@@ -36,6 +49,9 @@ var _ = Describe("BuildReaper", func() {
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
 			batchSize,
+			defaultRetention,
+			fakeClock,
+			logArchive,
 		)
 	})
 
@@ -485,6 +501,174 @@ var _ = Describe("BuildReaper", func() {
 			})
 		})
 
+		Context("when the job retains build logs by age", func() {
+			var retention *atc.BuildLogRetention
+
+			daysAgo := func(days int) time.Time {
+				return fakeClock.Now().Add(-time.Duration(days) * 24 * time.Hour)
+			}
+
+			BeforeEach(func() {
+				retention = &atc.BuildLogRetention{Days: 90}
+
+				fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+					if job == "job-1" && page == (db.Page{Until: 5, Limit: 5}) {
+						return []db.Build{
+							finishedBuild(10, daysAgo(1)),
+							finishedBuild(9, daysAgo(10)),
+							finishedBuild(8, daysAgo(95)),
+							finishedBuild(7, daysAgo(100)),
+							finishedBuild(6, daysAgo(100)),
+						}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Limit: 10}) {
+						return []db.Build{sb(12), sb(11), sb(10), sb(9), sb(8), sb(7), sb(6), sb(5), sb(4), sb(3)}, db.Pagination{}, nil
+					} else if job == "job-1" && page == (db.Page{Limit: 4}) {
+						return []db.Build{sb(10), sb(9), sb(8), sb(7)}, db.Pagination{}, nil
+					} else {
+						Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+					}
+					return nil, db.Pagination{}, nil
+				}
+
+				fakeBuildReaperDB.DeleteBuildEventsByBuildIDsReturns(nil)
+				fakePipelineDB.UpdateFirstLoggedBuildIDReturns(nil)
+			})
+
+			JustBeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					db.SavedJob{
+						Job:                db.Job{Name: "job-1"},
+						FirstLoggedBuildID: 6,
+						Config: atc.JobConfig{
+							BuildLogRetention: retention,
+						},
+					},
+				}, nil)
+			})
+
+			It("reaps the builds which finished too long ago", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+				Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+			})
+
+			It("updates FirstLoggedBuildID to the oldest build that was kept", func() {
+				err := buildReaper.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+				_, actualNewFirstLoggedBuildID := fakePipelineDB.UpdateFirstLoggedBuildIDArgsForCall(0)
+				Expect(actualNewFirstLoggedBuildID).To(Equal(9))
+			})
+
+			Context("when a minimum number of builds must be kept", func() {
+				BeforeEach(func() {
+					retention.MinimumBuilds = 4
+				})
+
+				It("keeps them even if they are too old", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6))
+				})
+			})
+
+			Context("when a count limit is configured too", func() {
+				BeforeEach(func() {
+					retention.Builds = 10
+				})
+
+				It("reaps builds which break either rule", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+				})
+			})
+
+			Context("when the job does not configure retention but there is a default", func() {
+				BeforeEach(func() {
+					retention = nil
+					defaultRetention = atc.BuildLogRetention{Days: 90}
+				})
+
+				It("applies the default", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					actualBuildIDs := fakeBuildReaperDB.DeleteBuildEventsByBuildIDsArgsForCall(0)
+					Expect(actualBuildIDs).To(ConsistOf(6, 7, 8))
+				})
+			})
+
+			Context("when a log archive is configured", func() {
+				var fakeLogArchive *logarchivefakes.FakeLogArchive
+
+				BeforeEach(func() {
+					fakeLogArchive = new(logarchivefakes.FakeLogArchive)
+					fakeLogArchive.StoreStub = func(buildID int, r io.Reader) error {
+						_, err := io.Copy(ioutil.Discard, r)
+						return err
+					}
+
+					logArchive = fakeLogArchive
+				})
+
+				It("archives each build's events before reaping it", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeLogArchive.StoreCallCount()).To(Equal(3))
+
+					archivedBuildIDs := []int{}
+					for i := 0; i < fakeLogArchive.StoreCallCount(); i++ {
+						buildID, _ := fakeLogArchive.StoreArgsForCall(i)
+						archivedBuildIDs = append(archivedBuildIDs, buildID)
+					}
+
+					Expect(archivedBuildIDs).To(Equal([]int{6, 7, 8}))
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+				})
+
+				Context("when archiving fails", func() {
+					disaster := errors.New("no space left on device")
+
+					BeforeEach(func() {
+						fakeLogArchive.StoreStub = nil
+						fakeLogArchive.StoreReturns(disaster)
+					})
+
+					It("returns the error without reaping anything", func() {
+						err := buildReaper.Run()
+						Expect(err).To(Equal(disaster))
+
+						Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+						Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the job configures nothing and there is no default", func() {
+				BeforeEach(func() {
+					retention = nil
+				})
+
+				It("does not reap anything", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.GetJobBuildsCallCount()).To(BeZero())
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when the dashboard job says retain 0 builds", func() {
 			BeforeEach(func() {
 				fakePipelineDB.GetDashboardReturns(db.Dashboard{
@@ -556,3 +740,16 @@ func runningBuild(id int) db.Build {
 	build.IsRunningReturns(true)
 	return build
 }
+
+func finishedBuild(id int, endTime time.Time) db.Build {
+	build := new(dbfakes.FakeBuild)
+	build.IDReturns(id)
+	build.IsRunningReturns(false)
+	build.EndTimeReturns(endTime)
+
+	events := new(dbfakes.FakeEventSource)
+	events.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+	build.EventsReturns(events, nil)
+
+	return build
+}
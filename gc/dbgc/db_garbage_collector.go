@@ -10,6 +10,8 @@ type ReaperDB interface {
 	ReapExpiredContainers() error
 	ReapExpiredVolumes() error
 	ReapExpiredWorkers() error
+	LandFinishedLandingWorkers() error
+	DeleteFinishedRetiringWorkers() error
 }
 
 type DBGarbageCollector interface {
@@ -50,5 +52,17 @@ func (c *dbGarbageCollector) Run() error {
 		return err
 	}
 
+	err = c.db.LandFinishedLandingWorkers()
+	if err != nil {
+		c.logger.Error("failed-to-land-finished-landing-workers", err)
+		return err
+	}
+
+	err = c.db.DeleteFinishedRetiringWorkers()
+	if err != nil {
+		c.logger.Error("failed-to-delete-finished-retiring-workers", err)
+		return err
+	}
+
 	return nil
 }
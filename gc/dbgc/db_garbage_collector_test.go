@@ -28,5 +28,13 @@ var _ = Describe("DbGarbageCollector", func() {
 			Expect(fakeDB.ReapExpiredVolumesCallCount()).To(Equal(1))
 			Expect(fakeDB.ReapExpiredWorkersCallCount()).To(Equal(1))
 		})
+
+		It("lands and removes workers whose builds have finished", func() {
+			err := dbGarbageCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDB.LandFinishedLandingWorkersCallCount()).To(Equal(1))
+			Expect(fakeDB.DeleteFinishedRetiringWorkersCallCount()).To(Equal(1))
+		})
 	})
 })
@@ -26,6 +26,18 @@ type FakeReaperDB struct {
 	reapExpiredWorkersReturns     struct {
 		result1 error
 	}
+	LandFinishedLandingWorkersStub        func() error
+	landFinishedLandingWorkersMutex       sync.RWMutex
+	landFinishedLandingWorkersArgsForCall []struct{}
+	landFinishedLandingWorkersReturns     struct {
+		result1 error
+	}
+	DeleteFinishedRetiringWorkersStub        func() error
+	deleteFinishedRetiringWorkersMutex       sync.RWMutex
+	deleteFinishedRetiringWorkersArgsForCall []struct{}
+	deleteFinishedRetiringWorkersReturns     struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -105,6 +117,56 @@ func (fake *FakeReaperDB) ReapExpiredWorkersReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeReaperDB) LandFinishedLandingWorkers() error {
+	fake.landFinishedLandingWorkersMutex.Lock()
+	fake.landFinishedLandingWorkersArgsForCall = append(fake.landFinishedLandingWorkersArgsForCall, struct{}{})
+	fake.recordInvocation("LandFinishedLandingWorkers", []interface{}{})
+	fake.landFinishedLandingWorkersMutex.Unlock()
+	if fake.LandFinishedLandingWorkersStub != nil {
+		return fake.LandFinishedLandingWorkersStub()
+	} else {
+		return fake.landFinishedLandingWorkersReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) LandFinishedLandingWorkersCallCount() int {
+	fake.landFinishedLandingWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	return len(fake.landFinishedLandingWorkersArgsForCall)
+}
+
+func (fake *FakeReaperDB) LandFinishedLandingWorkersReturns(result1 error) {
+	fake.LandFinishedLandingWorkersStub = nil
+	fake.landFinishedLandingWorkersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReaperDB) DeleteFinishedRetiringWorkers() error {
+	fake.deleteFinishedRetiringWorkersMutex.Lock()
+	fake.deleteFinishedRetiringWorkersArgsForCall = append(fake.deleteFinishedRetiringWorkersArgsForCall, struct{}{})
+	fake.recordInvocation("DeleteFinishedRetiringWorkers", []interface{}{})
+	fake.deleteFinishedRetiringWorkersMutex.Unlock()
+	if fake.DeleteFinishedRetiringWorkersStub != nil {
+		return fake.DeleteFinishedRetiringWorkersStub()
+	} else {
+		return fake.deleteFinishedRetiringWorkersReturns.result1
+	}
+}
+
+func (fake *FakeReaperDB) DeleteFinishedRetiringWorkersCallCount() int {
+	fake.deleteFinishedRetiringWorkersMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
+	return len(fake.deleteFinishedRetiringWorkersArgsForCall)
+}
+
+func (fake *FakeReaperDB) DeleteFinishedRetiringWorkersReturns(result1 error) {
+	fake.DeleteFinishedRetiringWorkersStub = nil
+	fake.deleteFinishedRetiringWorkersReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -114,6 +176,10 @@ func (fake *FakeReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.reapExpiredVolumesMutex.RUnlock()
 	fake.reapExpiredWorkersMutex.RLock()
 	defer fake.reapExpiredWorkersMutex.RUnlock()
+	fake.landFinishedLandingWorkersMutex.RLock()
+	defer fake.landFinishedLandingWorkersMutex.RUnlock()
+	fake.deleteFinishedRetiringWorkersMutex.RLock()
+	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -2,6 +2,7 @@ package lostandfound
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"time"
 
@@ -113,6 +114,9 @@ func (bc *baggageCollector) getLatestVersionSet() (hashedVersionSet, error) {
 				"job":      pipelineJob.Name,
 			})
 
+			// task caches live for as long as their job does
+			insertOrIncreaseVersionTTL(latestVersions, taskCacheHashKey(pipeline.ID, pipelineJob.Name), 0)
+
 			finishedBuild, _, err := pipelineDB.GetJobFinishedAndNextBuild(pipelineJob.Name)
 			if err != nil {
 				logger.Error("could-not-acquire-finished-and-next-builds-for-job", err)
@@ -164,6 +168,10 @@ func resourceCacheHashKey(volume db.SavedVolume) (string, bool) {
 	return string(version) + resourceCacheID.ResourceHash, true
 }
 
+func taskCacheHashKey(pipelineID int, jobName string) string {
+	return fmt.Sprintf("task-cache:%d/%s", pipelineID, jobName)
+}
+
 func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error {
 	volumesToExpire, err := bc.db.GetVolumes()
 	if err != nil {
@@ -195,6 +203,7 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 		}
 
 		var hashKey string
+		var uniqueKey string
 		switch {
 		case volumeToExpire.Volume.Identifier.ResourceCache != nil:
 			version, err := json.Marshal(volumeToExpire.Volume.Identifier.ResourceCache.ResourceVersion)
@@ -210,11 +219,19 @@ func (bc *baggageCollector) expireVolumes(latestVersions hashedVersionSet) error
 			}
 
 			hashKey = identifier.WorkerName + identifier.Path + *identifier.Version
+		case volumeToExpire.Volume.Identifier.TaskCache != nil:
+			identifier := volumeToExpire.Volume.Identifier.TaskCache
+			hashKey = taskCacheHashKey(identifier.PipelineID, identifier.JobName)
+			uniqueKey = hashKey + "/" + identifier.StepName + ":" + identifier.Path
 		default:
 			continue
 		}
 
-		identifier := hashKey + volumeToExpire.WorkerName
+		if uniqueKey == "" {
+			uniqueKey = hashKey
+		}
+
+		identifier := uniqueKey + volumeToExpire.WorkerName
 
 		var ttlForVol time.Duration
 		if _, found := seenIdentifiers[identifier]; found {
@@ -179,4 +179,77 @@ var _ = Describe("Volumes are reaped", func() {
 			Expect(fakeBaggageCollectorDB.ReapVolumeArgsForCall(0)).To(Equal(returnedSavedVolume.Handle))
 		})
 	})
+
+	Context("when the volume is a task cache", func() {
+		var (
+			fakeSavedPipeline db.SavedPipeline
+			fakePipelineDB    dbfakes.FakePipelineDB
+		)
+
+		BeforeEach(func() {
+			fakeSavedPipeline = db.SavedPipeline{
+				Pipeline: db.Pipeline{
+					Name: "some-pipeline",
+					Config: atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "some-job"},
+						},
+					},
+					Version: 42,
+				},
+				ID:     7,
+				TeamID: 13,
+			}
+
+			returnedVolumes = []db.SavedVolume{
+				{
+					Volume: db.Volume{
+						WorkerName: "a-new-worker",
+						TTL:        time.Minute,
+						Handle:     "some-cache-handle",
+						Identifier: db.VolumeIdentifier{
+							TaskCache: &db.TaskCacheIdentifier{
+								WorkerName: "a-new-worker",
+								PipelineID: 7,
+								JobName:    "some-job",
+								StepName:   "some-task",
+								Path:       "some/cache",
+							},
+						},
+					},
+					ID: 125,
+				},
+			}
+
+			fakeBaggageCollectorDB.GetAllPipelinesReturns([]db.SavedPipeline{fakeSavedPipeline}, nil)
+			fakePipelineDBFactory.BuildReturns(&fakePipelineDB)
+			fakeWorkerClient.WorkersReturns([]worker.Worker{fakeWorker}, nil)
+			fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+		})
+
+		Context("when its job is still in the pipeline", func() {
+			It("keeps the volume forever", func() {
+				err := baggageCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+				Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(0)))
+			})
+		})
+
+		Context("when its job has been removed from the pipeline", func() {
+			BeforeEach(func() {
+				fakeSavedPipeline.Config.Jobs = atc.JobConfigs{{Name: "some-other-job"}}
+				fakeBaggageCollectorDB.GetAllPipelinesReturns([]db.SavedPipeline{fakeSavedPipeline}, nil)
+			})
+
+			It("releases the volume with the old resource grace period", func() {
+				err := baggageCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeVolume.ReleaseCallCount()).To(Equal(1))
+				Expect(fakeVolume.ReleaseArgsForCall(0)).To(Equal(worker.FinalTTL(expectedOldResourceGracePeriod)))
+			})
+		})
+	})
 })
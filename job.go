@@ -27,6 +27,21 @@ type JobOutput struct {
 	Resource string `json:"resource"`
 }
 
+// CreateJobBuildRequestBody optionally pins some of a manually triggered
+// build's inputs to versions chosen by hand, keyed by input name. Overrides
+// must satisfy the inputs' passed constraints unless IgnorePassed is set.
+type CreateJobBuildRequestBody struct {
+	Inputs       map[string]InputVersionOverride `json:"inputs,omitempty"`
+	IgnorePassed bool                            `json:"ignore_passed,omitempty"`
+}
+
+// InputVersionOverride identifies a version of an input's resource, either by
+// the version itself or by its versioned resource ID.
+type InputVersionOverride struct {
+	Version             Version `json:"version,omitempty"`
+	VersionedResourceID int     `json:"versioned_resource_id,omitempty"`
+}
+
 type BuildInput struct {
 	Name     string   `json:"name"`
 	Resource string   `json:"resource"`
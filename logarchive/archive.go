@@ -0,0 +1,153 @@
+package logarchive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+//go:generate counterfeiter . LogArchive
+
+// LogArchive is a blob store for the event streams of builds whose logs have
+// been reaped from the database.
+type LogArchive interface {
+	// Store saves everything read from r as the archive for the given build,
+	// replacing any archive already stored for it. Nothing is stored if r
+	// returns an error.
+	Store(buildID int, r io.Reader) error
+
+	// Retrieve opens the archive for the given build, returning false if
+	// there isn't one.
+	Retrieve(buildID int) (io.ReadCloser, bool, error)
+}
+
+// Archive writes the remaining events from the source to the log archive as
+// gzipped newline-delimited JSON, one event.Envelope per line. The source must
+// belong to a finished build, otherwise this blocks until the build finishes.
+func Archive(logArchive LogArchive, buildID int, events db.EventSource) error {
+	reader, writer := io.Pipe()
+
+	encodeErr := make(chan error, 1)
+	go func() {
+		err := encode(writer, events)
+		writer.CloseWithError(err)
+		encodeErr <- err
+	}()
+
+	err := logArchive.Store(buildID, reader)
+
+	// unblock the encoder if the archive stopped reading early
+	reader.Close()
+
+	if err != nil {
+		<-encodeErr
+		return err
+	}
+
+	return <-encodeErr
+}
+
+func encode(w io.Writer, events db.EventSource) error {
+	gz := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gz)
+
+	for {
+		ev, err := events.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			return err
+		}
+	}
+
+	return gz.Close()
+}
+
+// Events returns an EventSource which replays the archived events of the
+// given build, starting from the event with index from. It returns false if
+// the build's events were never archived.
+func Events(logArchive LogArchive, buildID int, from uint) (db.EventSource, bool, error) {
+	reader, found, err := logArchive.Retrieve(buildID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		reader.Close()
+		return nil, false, err
+	}
+
+	source := &archivedEventSource{
+		reader:  reader,
+		gz:      gz,
+		decoder: json.NewDecoder(gz),
+	}
+
+	for i := uint(0); i < from; i++ {
+		var skipped json.RawMessage
+		err := source.decoder.Decode(&skipped)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			source.Close()
+			return nil, false, err
+		}
+	}
+
+	return source, true, nil
+}
+
+type archivedEventSource struct {
+	reader  io.ReadCloser
+	gz      *gzip.Reader
+	decoder *json.Decoder
+
+	closed bool
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	if source.closed {
+		return event.Envelope{}, db.ErrBuildEventStreamClosed
+	}
+
+	var ev event.Envelope
+	err := source.decoder.Decode(&ev)
+	if err == io.EOF {
+		return event.Envelope{}, db.ErrEndOfBuildEventStream
+	}
+
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	if source.closed {
+		return nil
+	}
+
+	source.closed = true
+
+	source.gz.Close()
+
+	return source.reader.Close()
+}
@@ -0,0 +1,200 @@
+package logarchive_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	. "github.com/concourse/atc/logarchive"
+	"github.com/concourse/atc/logarchive/logarchivefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func fakeEvent(payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   "fake",
+		Version: "42.0",
+	}
+}
+
+func eventSourceOf(events ...event.Envelope) *dbfakes.FakeEventSource {
+	source := new(dbfakes.FakeEventSource)
+	source.NextStub = func() (event.Envelope, error) {
+		if len(events) == 0 {
+			return event.Envelope{}, db.ErrEndOfBuildEventStream
+		}
+
+		ev := events[0]
+		events = events[1:]
+		return ev, nil
+	}
+
+	return source
+}
+
+var _ = Describe("LogArchive", func() {
+	var (
+		dir        string
+		logArchive LogArchive
+
+		events []event.Envelope
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).NotTo(HaveOccurred())
+
+		logArchive = NewLocalArchive(dir)
+
+		events = []event.Envelope{
+			fakeEvent(`{"event":1}`),
+			fakeEvent(`{"event":2}`),
+			fakeEvent(`{"event":3}`),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("Archive", func() {
+		It("writes the events to the archive as gzipped NDJSON", func() {
+			err := Archive(logArchive, 42, eventSourceOf(events...))
+			Expect(err).NotTo(HaveOccurred())
+
+			file, err := os.Open(filepath.Join(dir, "42.ndjson.gz"))
+			Expect(err).NotTo(HaveOccurred())
+			defer file.Close()
+
+			gz, err := gzip.NewReader(file)
+			Expect(err).NotTo(HaveOccurred())
+
+			decoder := json.NewDecoder(gz)
+			for _, expected := range events {
+				var ev event.Envelope
+				Expect(decoder.Decode(&ev)).To(Succeed())
+				Expect(ev).To(Equal(expected))
+			}
+
+			var extra event.Envelope
+			Expect(decoder.Decode(&extra)).To(Equal(io.EOF))
+		})
+
+		Context("when reading the events fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				Expect(Archive(logArchive, 42, eventSourceOf(events...))).To(Succeed())
+			})
+
+			It("returns the error and keeps the previous archive", func() {
+				source := new(dbfakes.FakeEventSource)
+				source.NextReturns(event.Envelope{}, disaster)
+
+				err := Archive(logArchive, 42, source)
+				Expect(err).To(Equal(disaster))
+
+				archived, found, err := Events(logArchive, 42, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				ev, err := archived.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(events[0]))
+			})
+
+			It("does not leave temporary files behind", func() {
+				source := new(dbfakes.FakeEventSource)
+				source.NextReturns(event.Envelope{}, disaster)
+
+				Archive(logArchive, 42, source)
+
+				entries, err := ioutil.ReadDir(dir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+			})
+		})
+
+		Context("when storing the archive fails", func() {
+			disaster := errors.New("nope")
+
+			It("returns the error", func() {
+				fakeLogArchive := new(logarchivefakes.FakeLogArchive)
+				fakeLogArchive.StoreReturns(disaster)
+
+				err := Archive(fakeLogArchive, 42, eventSourceOf(events...))
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("Events", func() {
+		Context("when the build has been archived", func() {
+			BeforeEach(func() {
+				Expect(Archive(logArchive, 42, eventSourceOf(events...))).To(Succeed())
+			})
+
+			It("replays the archived events", func() {
+				source, found, err := Events(logArchive, 42, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				for _, expected := range events {
+					ev, err := source.Next()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ev).To(Equal(expected))
+				}
+
+				_, err = source.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+
+				Expect(source.Close()).To(Succeed())
+
+				_, err = source.Next()
+				Expect(err).To(Equal(db.ErrBuildEventStreamClosed))
+			})
+
+			It("can start from a later event", func() {
+				source, found, err := Events(logArchive, 42, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				ev, err := source.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev).To(Equal(events[2]))
+
+				_, err = source.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+
+			It("ends immediately when starting past the last event", func() {
+				source, found, err := Events(logArchive, 42, 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = source.Next()
+				Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+			})
+		})
+
+		Context("when the build has not been archived", func() {
+			It("returns false", func() {
+				_, found, err := Events(logArchive, 42, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package logarchive
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+type localArchive struct {
+	dir string
+}
+
+// NewLocalArchive returns a LogArchive which keeps each build's archive in
+// <dir>/<build id>.ndjson.gz.
+func NewLocalArchive(dir string) LogArchive {
+	return &localArchive{
+		dir: dir,
+	}
+}
+
+func (archive *localArchive) Store(buildID int, r io.Reader) error {
+	tmp, err := ioutil.TempFile(archive.dir, ".build-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), archive.path(buildID))
+}
+
+func (archive *localArchive) Retrieve(buildID int) (io.ReadCloser, bool, error) {
+	file, err := os.Open(archive.path(buildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (archive *localArchive) path(buildID int) string {
+	return filepath.Join(archive.dir, strconv.Itoa(buildID)+".ndjson.gz")
+}
@@ -0,0 +1,126 @@
+// This file was generated by counterfeiter
+package logarchivefakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/logarchive"
+)
+
+type FakeLogArchive struct {
+	StoreStub        func(buildID int, r io.Reader) error
+	storeMutex       sync.RWMutex
+	storeArgsForCall []struct {
+		buildID int
+		r       io.Reader
+	}
+	storeReturns struct {
+		result1 error
+	}
+	RetrieveStub        func(buildID int) (io.ReadCloser, bool, error)
+	retrieveMutex       sync.RWMutex
+	retrieveArgsForCall []struct {
+		buildID int
+	}
+	retrieveReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLogArchive) Store(buildID int, r io.Reader) error {
+	fake.storeMutex.Lock()
+	fake.storeArgsForCall = append(fake.storeArgsForCall, struct {
+		buildID int
+		r       io.Reader
+	}{buildID, r})
+	fake.recordInvocation("Store", []interface{}{buildID, r})
+	fake.storeMutex.Unlock()
+	if fake.StoreStub != nil {
+		return fake.StoreStub(buildID, r)
+	} else {
+		return fake.storeReturns.result1
+	}
+}
+
+func (fake *FakeLogArchive) StoreCallCount() int {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return len(fake.storeArgsForCall)
+}
+
+func (fake *FakeLogArchive) StoreArgsForCall(i int) (int, io.Reader) {
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	return fake.storeArgsForCall[i].buildID, fake.storeArgsForCall[i].r
+}
+
+func (fake *FakeLogArchive) StoreReturns(result1 error) {
+	fake.StoreStub = nil
+	fake.storeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLogArchive) Retrieve(buildID int) (io.ReadCloser, bool, error) {
+	fake.retrieveMutex.Lock()
+	fake.retrieveArgsForCall = append(fake.retrieveArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("Retrieve", []interface{}{buildID})
+	fake.retrieveMutex.Unlock()
+	if fake.RetrieveStub != nil {
+		return fake.RetrieveStub(buildID)
+	} else {
+		return fake.retrieveReturns.result1, fake.retrieveReturns.result2, fake.retrieveReturns.result3
+	}
+}
+
+func (fake *FakeLogArchive) RetrieveCallCount() int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return len(fake.retrieveArgsForCall)
+}
+
+func (fake *FakeLogArchive) RetrieveArgsForCall(i int) int {
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return fake.retrieveArgsForCall[i].buildID
+}
+
+func (fake *FakeLogArchive) RetrieveReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.RetrieveStub = nil
+	fake.retrieveReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeLogArchive) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.storeMutex.RLock()
+	defer fake.storeMutex.RUnlock()
+	fake.retrieveMutex.RLock()
+	defer fake.retrieveMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLogArchive) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ logarchive.LogArchive = new(FakeLogArchive)
@@ -4,48 +4,51 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 )
 
-type eventEmission struct {
-	event  goryman.Event
-	logger lager.Logger
+type EventState string
+
+const (
+	EventStateOK       EventState = "ok"
+	EventStateWarning  EventState = "warning"
+	EventStateCritical EventState = "critical"
+)
+
+type Event struct {
+	Name       string
+	Value      interface{}
+	State      EventState
+	Attributes map[string]string
+	Tags       []string
+	Host       string
+	Time       time.Time
+}
+
+type Emitter interface {
+	Emit(lager.Logger, Event)
 }
 
-var riemannClient *goryman.GorymanClient
+var emitters []Emitter
 var eventHost string
 var eventTags []string
 var eventAttributes map[string]string
-var eventPrefix string
 
-var clientConnected bool
-var emissions = make(chan eventEmission, 1000)
-
-func Initialize(logger lager.Logger, riemannAddr string, host string, tags []string, attributes map[string]string, prefix string) {
-	client := goryman.NewGorymanClient(riemannAddr)
-
-	riemannClient = client
+func Initialize(logger lager.Logger, host string, tags []string, attributes map[string]string, configuredEmitters ...Emitter) {
 	eventHost = host
 	eventTags = tags
 	eventAttributes = attributes
-	eventPrefix = prefix
-
-	go emitLoop()
+	emitters = configuredEmitters
 }
 
-func emit(logger lager.Logger, event goryman.Event) {
+func emit(logger lager.Logger, event Event) {
 	logger.Debug("emit")
 
-	if riemannClient == nil {
+	if len(emitters) == 0 {
 		return
 	}
 
-	if eventPrefix != "" {
-		event.Service = eventPrefix + event.Service
-	}
-
 	event.Host = eventHost
-	event.Time = time.Now().Unix()
+	event.Time = time.Now()
 	event.Tags = append(event.Tags, eventTags...)
 
 	mergedAttributes := map[string]string{}
@@ -61,34 +64,7 @@ func emit(logger lager.Logger, event goryman.Event) {
 
 	event.Attributes = mergedAttributes
 
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
-	}
-}
-
-func emitLoop() {
-	for emission := range emissions {
-		if !clientConnected {
-			err := riemannClient.Connect()
-			if err != nil {
-				emission.logger.Error("connection-failed", err)
-				continue
-			}
-
-			clientConnected = true
-		}
-
-		err := riemannClient.SendEvent(&emission.event)
-		if err != nil {
-			emission.logger.Error("failed-to-emit", err)
-
-			if err := riemannClient.Close(); err != nil {
-				emission.logger.Error("failed-to-close", err)
-			}
-
-			clientConnected = false
-		}
+	for _, emitter := range emitters {
+		emitter.Emit(logger, event)
 	}
 }
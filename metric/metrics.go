@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
-	"github.com/bigdatadev/goryman"
 
 	"github.com/concourse/atc/db"
 )
@@ -21,14 +20,14 @@ type SchedulingFullDuration struct {
 }
 
 func (event SchedulingFullDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -37,10 +36,10 @@ func (event SchedulingFullDuration) Emit(logger lager.Logger) {
 			"duration": event.Duration.String(),
 		}),
 
-		goryman.Event{
-			Service: "scheduling: full duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  "scheduling: full duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -54,14 +53,14 @@ type SchedulingLoadVersionsDuration struct {
 }
 
 func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -69,10 +68,10 @@ func (event SchedulingLoadVersionsDuration) Emit(logger lager.Logger) {
 			"pipeline": event.PipelineName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: loading versions duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  "scheduling: loading versions duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 			},
@@ -87,14 +86,14 @@ type SchedulingJobDuration struct {
 }
 
 func (event SchedulingJobDuration) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > time.Second {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 5*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -103,10 +102,10 @@ func (event SchedulingJobDuration) Emit(logger lager.Logger) {
 			"job":      event.JobName,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "scheduling: job duration (ms)",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  "scheduling: job duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"pipeline": event.PipelineName,
 				"job":      event.JobName,
@@ -126,10 +125,10 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 			"worker":     event.WorkerName,
 			"containers": event.Containers,
 		}),
-		goryman.Event{
-			Service: "worker containers",
-			Metric:  event.Containers,
-			State:   "ok",
+		Event{
+			Name:  "worker containers",
+			Value: event.Containers,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"worker": event.WorkerName,
 			},
@@ -152,10 +151,10 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 			"build-name": event.BuildName,
 			"build-id":   event.BuildID,
 		}),
-		goryman.Event{
-			Service: "build started",
-			Metric:  event.BuildID,
-			State:   "ok",
+		Event{
+			Name:  "build started",
+			Value: event.BuildID,
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":   event.PipelineName,
 				"job":        event.JobName,
@@ -184,10 +183,10 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			"build-id":     event.BuildID,
 			"build-status": event.BuildStatus,
 		}),
-		goryman.Event{
-			Service: "build finished",
-			Metric:  ms(event.BuildDuration),
-			State:   "ok",
+		Event{
+			Name:  "build finished",
+			Value: ms(event.BuildDuration),
+			State: EventStateOK,
 			Attributes: map[string]string{
 				"pipeline":     event.PipelineName,
 				"job":          event.JobName,
@@ -210,14 +209,14 @@ type HTTPResponseTime struct {
 }
 
 func (event HTTPResponseTime) Emit(logger lager.Logger) {
-	state := "ok"
+	state := EventStateOK
 
 	if event.Duration > 100*time.Millisecond {
-		state = "warning"
+		state = EventStateWarning
 	}
 
 	if event.Duration > 1*time.Second {
-		state = "critical"
+		state = EventStateCritical
 	}
 
 	emit(
@@ -226,10 +225,10 @@ func (event HTTPResponseTime) Emit(logger lager.Logger) {
 			"path":     event.Path,
 			"duration": event.Duration.String(),
 		}),
-		goryman.Event{
-			Service: "http response time",
-			Metric:  ms(event.Duration),
-			State:   state,
+		Event{
+			Name:  "http response time",
+			Value: ms(event.Duration),
+			State: state,
 			Attributes: map[string]string{
 				"route": event.Route,
 				"path":  event.Path,
@@ -0,0 +1,193 @@
+package metric
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type PrometheusEmitter struct {
+	registry *prometheus.Registry
+
+	schedulingFullDuration         *prometheus.HistogramVec
+	schedulingLoadVersionsDuration *prometheus.HistogramVec
+	schedulingJobDuration          *prometheus.HistogramVec
+
+	buildsStarted  *prometheus.CounterVec
+	buildsFinished *prometheus.CounterVec
+	buildDuration  *prometheus.HistogramVec
+
+	httpResponseTime *prometheus.HistogramVec
+
+	workerContainers    *prometheus.GaugeVec
+	trackedContainers   prometheus.Gauge
+	trackedVolumes      prometheus.Gauge
+	databaseQueries     prometheus.Counter
+	databaseConnections prometheus.Gauge
+}
+
+func NewPrometheusEmitter() *PrometheusEmitter {
+	emitter := &PrometheusEmitter{
+		registry: prometheus.NewRegistry(),
+
+		schedulingFullDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "full_duration_seconds",
+			Help:      "Time taken to schedule an entire pipeline.",
+		}, []string{"pipeline"}),
+
+		schedulingLoadVersionsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "load_versions_duration_seconds",
+			Help:      "Time taken to load the versions database of a pipeline.",
+		}, []string{"pipeline"}),
+
+		schedulingJobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "scheduling",
+			Name:      "job_duration_seconds",
+			Help:      "Time taken to schedule a single job.",
+		}, []string{"pipeline", "job"}),
+
+		buildsStarted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "started_total",
+			Help:      "Number of builds started.",
+		}, []string{"pipeline", "job"}),
+
+		buildsFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "finished_total",
+			Help:      "Number of builds finished, by status.",
+		}, []string{"pipeline", "job", "status"}),
+
+		buildDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "builds",
+			Name:      "duration_seconds",
+			Help:      "Duration of finished builds.",
+			Buckets:   []float64{1, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200},
+		}, []string{"pipeline", "job", "status"}),
+
+		httpResponseTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "http",
+			Name:      "response_duration_seconds",
+			Help:      "Time taken to respond to HTTP requests.",
+		}, []string{"route"}),
+
+		workerContainers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "workers",
+			Name:      "containers",
+			Help:      "Number of containers reported by each worker.",
+		}, []string{"worker"}),
+
+		trackedContainers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Name:      "tracked_containers",
+			Help:      "Number of containers tracked by this ATC.",
+		}),
+
+		trackedVolumes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Name:      "tracked_volumes",
+			Help:      "Number of volumes tracked by this ATC.",
+		}),
+
+		databaseQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Number of database queries performed.",
+		}),
+
+		databaseConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "db",
+			Name:      "connections",
+			Help:      "Number of open database connections.",
+		}),
+	}
+
+	emitter.registry.MustRegister(
+		emitter.schedulingFullDuration,
+		emitter.schedulingLoadVersionsDuration,
+		emitter.schedulingJobDuration,
+		emitter.buildsStarted,
+		emitter.buildsFinished,
+		emitter.buildDuration,
+		emitter.httpResponseTime,
+		emitter.workerContainers,
+		emitter.trackedContainers,
+		emitter.trackedVolumes,
+		emitter.databaseQueries,
+		emitter.databaseConnections,
+
+		// gc pauses, mallocs, frees, and goroutines are covered by the standard
+		// collectors
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	return emitter
+}
+
+func (emitter *PrometheusEmitter) Handler() http.Handler {
+	return promhttp.HandlerFor(emitter.registry, promhttp.HandlerOpts{})
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event Event) {
+	value, ok := toFloat64(event.Value)
+	if !ok {
+		logger.Info("unknown-value-type", lager.Data{"event": event.Name})
+		return
+	}
+
+	attr := event.Attributes
+
+	switch event.Name {
+	case "scheduling: full duration (ms)":
+		emitter.schedulingFullDuration.WithLabelValues(attr["pipeline"]).Observe(value / 1000)
+	case "scheduling: loading versions duration (ms)":
+		emitter.schedulingLoadVersionsDuration.WithLabelValues(attr["pipeline"]).Observe(value / 1000)
+	case "scheduling: job duration (ms)":
+		emitter.schedulingJobDuration.WithLabelValues(attr["pipeline"], attr["job"]).Observe(value / 1000)
+	case "build started":
+		emitter.buildsStarted.WithLabelValues(attr["pipeline"], attr["job"]).Inc()
+	case "build finished":
+		emitter.buildsFinished.WithLabelValues(attr["pipeline"], attr["job"], attr["build_status"]).Inc()
+		emitter.buildDuration.WithLabelValues(attr["pipeline"], attr["job"], attr["build_status"]).Observe(value / 1000)
+	case "http response time":
+		emitter.httpResponseTime.WithLabelValues(attr["route"]).Observe(value / 1000)
+	case "worker containers":
+		emitter.workerContainers.WithLabelValues(attr["worker"]).Set(value)
+	case "tracked containers":
+		emitter.trackedContainers.Set(value)
+	case "tracked volumes":
+		emitter.trackedVolumes.Set(value)
+	case "database queries":
+		emitter.databaseQueries.Add(value)
+	case "database connections":
+		emitter.databaseConnections.Set(value)
+	}
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,99 @@
+package metric_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		logger  *lagertest.TestLogger
+		emitter *metric.PrometheusEmitter
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		emitter = metric.NewPrometheusEmitter()
+
+		metric.Initialize(logger, "some-host", nil, map[string]string{"some": "attribute"}, emitter)
+	})
+
+	AfterEach(func() {
+		metric.Initialize(logger, "", nil, nil)
+	})
+
+	scrape := func() string {
+		recorder := httptest.NewRecorder()
+		emitter.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		body, err := ioutil.ReadAll(recorder.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(body)
+	}
+
+	It("counts started builds by pipeline and job", func() {
+		metric.BuildStarted{
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildName:    "42",
+			BuildID:      1234,
+		}.Emit(logger)
+
+		Expect(scrape()).To(ContainSubstring(`concourse_builds_started_total{job="some-job",pipeline="some-pipeline"} 1`))
+	})
+
+	It("counts finished builds and observes their duration by status", func() {
+		metric.BuildFinished{
+			PipelineName:  "some-pipeline",
+			JobName:       "some-job",
+			BuildName:     "42",
+			BuildID:       1234,
+			BuildStatus:   db.StatusSucceeded,
+			BuildDuration: 90 * time.Second,
+		}.Emit(logger)
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_builds_finished_total{job="some-job",pipeline="some-pipeline",status="succeeded"} 1`))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{job="some-job",pipeline="some-pipeline",status="succeeded"} 90`))
+	})
+
+	It("observes scheduling durations in seconds", func() {
+		metric.SchedulingJobDuration{
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			Duration:     1500 * time.Millisecond,
+		}.Emit(logger)
+
+		Expect(scrape()).To(ContainSubstring(`concourse_scheduling_job_duration_seconds_sum{job="some-job",pipeline="some-pipeline"} 1.5`))
+	})
+
+	It("observes HTTP response times by route, not by path", func() {
+		metric.HTTPResponseTime{
+			Route:    "GetBuild",
+			Path:     "/api/v1/builds/42",
+			Duration: 250 * time.Millisecond,
+		}.Emit(logger)
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring(`concourse_http_response_duration_seconds_count{route="GetBuild"} 1`))
+		Expect(metrics).NotTo(ContainSubstring("/api/v1/builds/42"))
+	})
+
+	It("sets the container gauge per worker", func() {
+		metric.WorkerContainers{
+			WorkerName: "some-worker",
+			Containers: 7,
+		}.Emit(logger)
+
+		Expect(scrape()).To(ContainSubstring(`concourse_workers_containers{worker="some-worker"} 7`))
+	})
+})
@@ -0,0 +1,65 @@
+package atc
+
+// PipelineGraph is the dependency graph between a pipeline's jobs and
+// resources.
+type PipelineGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNodeType string
+
+const (
+	GraphNodeJob      GraphNodeType = "job"
+	GraphNodeResource GraphNodeType = "resource"
+)
+
+type GraphNode struct {
+	ID   string        `json:"id"`
+	Type GraphNodeType `json:"type"`
+	Name string        `json:"name"`
+
+	// set for jobs in other pipelines referred to by a passed constraint
+	Pipeline string `json:"pipeline,omitempty"`
+
+	Paused bool `json:"paused,omitempty"`
+
+	// the status of the job's latest finished build
+	Status string `json:"status,omitempty"`
+}
+
+type GraphEdgeType string
+
+const (
+	// a resource that triggers a job
+	GraphEdgeTrigger GraphEdgeType = "trigger"
+
+	// a resource that a job fetches, without triggering it
+	GraphEdgeInput GraphEdgeType = "input"
+
+	// a job whose outputs flow into another job through a passed constraint
+	GraphEdgePassed GraphEdgeType = "passed"
+
+	// a job that puts to a resource
+	GraphEdgeOutput GraphEdgeType = "output"
+)
+
+type GraphEdge struct {
+	Source string        `json:"source"`
+	Target string        `json:"target"`
+	Type   GraphEdgeType `json:"type"`
+
+	// the resource carried along a passed edge
+	Resource string `json:"resource,omitempty"`
+
+	// whether a passed edge triggers its target
+	Trigger bool `json:"trigger,omitempty"`
+}
+
+func GraphJobID(name string) string {
+	return "job:" + name
+}
+
+func GraphResourceID(name string) string {
+	return "resource:" + name
+}
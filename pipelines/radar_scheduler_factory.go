@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -24,34 +25,41 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	engine   engine.Engine
+	tracker           resource.Tracker
+	interval          time.Duration
+	engine            engine.Engine
+	credentialManager creds.CredentialManager
 }
 
 func NewRadarSchedulerFactory(
 	tracker resource.Tracker,
 	interval time.Duration,
 	engine engine.Engine,
+	credentialManager creds.CredentialManager,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		engine:   engine,
+		tracker:           tracker,
+		interval:          interval,
+		engine:            engine,
+		credentialManager: credentialManager,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL)
+	variables := creds.NewVariables(rsf.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+	return radar.NewScanRunnerFactory(rsf.tracker, rsf.interval, pipelineDB, clock.NewClock(), externalURL, variables)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, externalURL string) scheduler.BuildScheduler {
+	variables := creds.NewVariables(rsf.credentialManager, pipelineDB.TeamName(), pipelineDB.GetPipelineName())
+
 	scanner := radar.NewResourceScanner(
 		clock.NewClock(),
 		rsf.tracker,
 		rsf.interval,
 		pipelineDB,
 		externalURL,
+		variables,
 	)
 	inputMapper := inputmapper.NewInputMapper(
 		pipelineDB,
@@ -72,5 +80,6 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, exter
 			rsf.engine,
 		),
 		Scanner: scanner,
+		Clock:   clock.NewClock(),
 	}
 }
@@ -5,10 +5,12 @@ type Plan struct {
 	Attempts []int  `json:"attempts,omitempty"`
 
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
+	InParallel   *InParallelPlan   `json:"in_parallel,omitempty"`
 	Do           *DoPlan           `json:"do,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
 	Task         *TaskPlan         `json:"task,omitempty"`
+	Gate         *GatePlan         `json:"gate,omitempty"`
 	Ensure       *EnsurePlan       `json:"ensure,omitempty"`
 	OnSuccess    *OnSuccessPlan    `json:"on_success,omitempty"`
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
@@ -16,6 +18,9 @@ type Plan struct {
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+
+	// only set along with Retry
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
 }
 
 type PlanID string
@@ -72,6 +77,12 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -118,4 +129,13 @@ type TaskPlan struct {
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
 
+type GatePlan struct {
+	Name string `json:"name"`
+}
+
 type RetryPlan []Plan
+
+type RetryPolicy struct {
+	Backoff *RetryBackoff    `json:"backoff,omitempty"`
+	RetryOn *RetryConditions `json:"retry_on,omitempty"`
+}
@@ -27,6 +27,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 	switch t := step.(type) {
 	case AggregatePlan:
 		plan.Aggregate = &t
+	case InParallelPlan:
+		plan.InParallel = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -35,6 +37,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Put = &t
 	case TaskPlan:
 		plan.Task = &t
+	case GatePlan:
+		plan.Gate = &t
 	case EnsurePlan:
 		plan.Ensure = &t
 	case OnSuccessPlan:
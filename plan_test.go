@@ -244,6 +244,33 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "26",
+					InParallel: &atc.InParallelPlan{
+						Steps: []atc.Plan{
+							atc.Plan{
+								ID: "27",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: map[string]string{"some": "secret"},
+									},
+								},
+							},
+						},
+						Limit:    1,
+						FailFast: true,
+					},
+				},
+
+				atc.Plan{
+					ID: "28",
+					Gate: &atc.GatePlan{
+						Name: "name",
+					},
+				},
 			},
 		}
 
@@ -417,6 +444,28 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "in_parallel": {
+        "steps": [
+          {
+            "id": "27",
+            "task": {
+              "name": "name",
+              "privileged": false
+            }
+          }
+        ],
+        "limit": 1,
+        "fail_fast": true
+      }
+    },
+    {
+      "id": "28",
+      "gate": {
+        "name": "name"
+      }
     }
   ]
 }
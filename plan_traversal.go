@@ -29,6 +29,14 @@ func (pt *planTraversal) Traverse(plan *Plan) error {
 			}
 		}
 
+	case plan.InParallel != nil:
+		for i := range plan.InParallel.Steps {
+			err = pt.Traverse(&plan.InParallel.Steps[i])
+			if err != nil {
+				return err
+			}
+		}
+
 	case plan.Do != nil:
 		for i := range *plan.Do {
 			err = pt.Traverse(&(*plan.Do)[i])
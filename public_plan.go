@@ -7,10 +7,12 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate    *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel   *json.RawMessage `json:"in_parallel,omitempty"`
 		Do           *json.RawMessage `json:"do,omitempty"`
 		Get          *json.RawMessage `json:"get,omitempty"`
 		Put          *json.RawMessage `json:"put,omitempty"`
 		Task         *json.RawMessage `json:"task,omitempty"`
+		Gate         *json.RawMessage `json:"gate,omitempty"`
 		Ensure       *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess    *json.RawMessage `json:"on_success,omitempty"`
 		OnFailure    *json.RawMessage `json:"on_failure,omitempty"`
@@ -26,6 +28,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -42,6 +48,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Task = plan.Task.Public()
 	}
 
+	if plan.Gate != nil {
+		public.Gate = plan.Gate.Public()
+	}
+
 	if plan.Ensure != nil {
 		public.Ensure = plan.Ensure.Public()
 	}
@@ -83,6 +93,24 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -171,6 +199,14 @@ func (plan TaskPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan GatePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -157,6 +157,12 @@ type FakeRadarDB struct {
 		result2 bool
 		result3 error
 	}
+	TeamNameStub        func() string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct{}
+	teamNameReturns     struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -700,6 +706,31 @@ func (fake *FakeRadarDB) AcquireResourceTypeCheckingLockReturns(result1 db.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRadarDB) TeamName() string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct{}{})
+	fake.recordInvocation("TeamName", []interface{}{})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub()
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -737,6 +768,8 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
 	defer fake.acquireResourceTypeCheckingLockMutex.RUnlock()
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -19,6 +20,7 @@ type resourceScanner struct {
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceScanner(
@@ -27,6 +29,7 @@ func NewResourceScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceScanner{
 		clock:           clock,
@@ -34,6 +37,7 @@ func NewResourceScanner(
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -217,6 +221,30 @@ func (scanner *resourceScanner) scan(
 		return errPipelineRemoved
 	}
 
+	source, err := scanner.variables.EvaluateSource(savedResource.Config.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
+	resourceTypes, err := scanner.variables.EvaluateResourceTypes(scanner.db.Config().ResourceTypes)
+	if err != nil {
+		logger.Error("failed-to-evaluate-resource-types", err)
+
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
 	res, err := scanner.tracker.Init(
 		logger,
 		resource.TrackerMetadata{
@@ -228,7 +256,7 @@ func (scanner *resourceScanner) scan(
 		resource.ResourceType(savedResource.Config.Type),
 		[]string{},
 		scanner.db.TeamID(),
-		scanner.db.Config().ResourceTypes,
+		resourceTypes,
 		worker.NoopImageFetchingDelegate{},
 	)
 	if err != nil {
@@ -242,7 +270,7 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
+	newVersions, err := res.Check(source, fromVersion)
 
 	setErr := scanner.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
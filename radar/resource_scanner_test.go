@@ -8,6 +8,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/credsfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/worker"
 
@@ -24,10 +26,11 @@ var _ = Describe("ResourceScanner", func() {
 	var (
 		epoch time.Time
 
-		fakeTracker *rfakes.FakeTracker
-		fakeRadarDB *radarfakes.FakeRadarDB
-		fakeClock   *fakeclock.FakeClock
-		interval    time.Duration
+		fakeTracker           *rfakes.FakeTracker
+		fakeRadarDB           *radarfakes.FakeRadarDB
+		fakeClock             *fakeclock.FakeClock
+		fakeCredentialManager *credsfakes.FakeCredentialManager
+		interval              time.Duration
 
 		scanner Scanner
 
@@ -43,6 +46,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
 		fakeClock = fakeclock.NewFakeClock(epoch)
+		fakeCredentialManager = new(credsfakes.FakeCredentialManager)
 		interval = 1 * time.Minute
 
 		fakeRadarDB.GetPipelineIDReturns(42)
@@ -52,6 +56,7 @@ var _ = Describe("ResourceScanner", func() {
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			creds.NewVariables(fakeCredentialManager, "some-team", "some-pipeline"),
 		)
 
 		resourceConfig = atc.ResourceConfig{
@@ -225,6 +230,44 @@ var _ = Describe("ResourceScanner", func() {
 				Eventually(fakeResource.ReleaseCallCount).Should(Equal(1))
 			})
 
+			Context("when the source references a secret", func() {
+				BeforeEach(func() {
+					savedResource.Config.Source = atc.Source{"uri": "http://((some-secret))@example.com"}
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				Context("when the secret is defined", func() {
+					BeforeEach(func() {
+						fakeCredentialManager.GetReturns("some-password", true, nil)
+					})
+
+					It("checks with the secret resolved", func() {
+						source, _ := fakeResource.CheckArgsForCall(0)
+						Expect(source).To(Equal(atc.Source{"uri": "http://some-password@example.com"}))
+					})
+
+					It("looks up the secret for the pipeline's team", func() {
+						teamName, pipelineName, secretName := fakeCredentialManager.GetArgsForCall(0)
+						Expect(teamName).To(Equal("some-team"))
+						Expect(pipelineName).To(Equal("some-pipeline"))
+						Expect(secretName).To(Equal("some-secret"))
+					})
+				})
+
+				Context("when the secret is not defined", func() {
+					It("does not check", func() {
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, resourceErr := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(resourceErr).To(Equal(creds.UndefinedSecretError{Name: "some-secret"}))
+					})
+				})
+			})
+
 			Context("when there is no current version", func() {
 				It("checks from nil", func() {
 					_, version := fakeResource.CheckArgsForCall(0)
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -15,6 +16,7 @@ type resourceTypeScanner struct {
 	defaultInterval time.Duration
 	db              RadarDB
 	externalURL     string
+	variables       creds.Variables
 }
 
 func NewResourceTypeScanner(
@@ -22,12 +24,14 @@ func NewResourceTypeScanner(
 	defaultInterval time.Duration,
 	db RadarDB,
 	externalURL string,
+	variables creds.Variables,
 ) Scanner {
 	return &resourceTypeScanner{
 		tracker:         tracker,
 		defaultInterval: defaultInterval,
 		db:              db,
 		externalURL:     externalURL,
+		variables:       variables,
 	}
 }
 
@@ -108,6 +112,12 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		Ephemeral: true,
 	}
 
+	source, err := scanner.variables.EvaluateSource(resourceType.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-source", err)
+		return err
+	}
+
 	res, err := scanner.tracker.Init(
 		logger.Session("check-image"),
 		resource.EmptyMetadata{},
@@ -126,7 +136,7 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 
 	logger.Debug("checking")
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(fromVersion))
+	newVersions, err := res.Check(source, atc.Version(fromVersion))
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -43,6 +44,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			interval,
 			fakeRadarDB,
 			"https://www.example.com",
+			creds.NewVariables(creds.NoopCredentialManager{}, "some-team", "some-pipeline"),
 		)
 
 		fakeRadarDB.ScopedNameStub = func(thing string) string {
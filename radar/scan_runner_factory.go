@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/tedsuo/ifrit"
 
@@ -36,6 +37,7 @@ func NewScanRunnerFactory(
 	db RadarDB,
 	clock clock.Clock,
 	externalURL string,
+	variables creds.Variables,
 ) ScanRunnerFactory {
 	resourceScanner := NewResourceScanner(
 		clock,
@@ -43,12 +45,14 @@ func NewScanRunnerFactory(
 		defaultInterval,
 		db,
 		externalURL,
+		variables,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
 		tracker,
 		defaultInterval,
 		db,
 		externalURL,
+		variables,
 	)
 
 	return &scanRunnerFactory{
@@ -3,8 +3,12 @@ package atc
 import "github.com/tedsuo/rata"
 
 const (
-	SaveConfig = "SaveConfig"
-	GetConfig  = "GetConfig"
+	SaveConfig        = "SaveConfig"
+	GetConfig         = "GetConfig"
+	DiffConfig        = "DiffConfig"
+	GetConfigVersions = "GetConfigVersions"
+	GetConfigVersion  = "GetConfigVersion"
+	RollbackConfig    = "RollbackConfig"
 
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
@@ -13,7 +17,9 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	RerunBuild          = "RerunBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	DecideGate          = "DecideGate"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -27,15 +33,18 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
-	ListResources   = "ListResources"
-	GetResource     = "GetResource"
-	PauseResource   = "PauseResource"
-	UnpauseResource = "UnpauseResource"
-	CheckResource   = "CheckResource"
+	ListResources        = "ListResources"
+	GetResource          = "GetResource"
+	PauseResource        = "PauseResource"
+	UnpauseResource      = "UnpauseResource"
+	CheckResource        = "CheckResource"
+	CheckResourceWebHook = "CheckResourceWebHook"
 
 	ListResourceVersions          = "ListResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
+	PinResourceVersion            = "PinResourceVersion"
+	UnpinResourceVersion          = "UnpinResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
 	ListBuildsWithVersionAsOutput = "ListBuildsWithVersionAsOutput"
 
@@ -49,6 +58,7 @@ const (
 	ExposePipeline   = "ExposePipeline"
 	HidePipeline     = "HidePipeline"
 	RenamePipeline   = "RenamePipeline"
+	GetPipelineGraph = "GetPipelineGraph"
 
 	CreatePipe = "CreatePipe"
 	WritePipe  = "WritePipe"
@@ -56,6 +66,9 @@ const (
 
 	RegisterWorker = "RegisterWorker"
 	ListWorkers    = "ListWorkers"
+	LandWorker     = "LandWorker"
+	RetireWorker   = "RetireWorker"
+	PruneWorker    = "PruneWorker"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -76,11 +89,17 @@ const (
 	ListTeams   = "ListTeams"
 	SetTeam     = "SetTeam"
 	DestroyTeam = "DestroyTeam"
+
+	ListAuditEvents = "ListAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/diff", Method: "POST", Name: DiffConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: GetConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetConfigVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version/rollback", Method: "PUT", Name: RollbackConfig},
 
 	{Path: "/api/v1/builds", Method: "POST", Name: CreateBuild},
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
@@ -89,7 +108,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/rerun", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/gates/:plan_id", Method: "POST", Name: DecideGate},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
@@ -112,6 +133,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/expose", Method: "PUT", Name: ExposePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/hide", Method: "PUT", Name: HidePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/versions-db", Method: "GET", Name: GetVersionsDB},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/graph", Method: "GET", Name: GetPipelineGraph},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
@@ -119,10 +141,13 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/unpin", Method: "PUT", Name: UnpinResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/output_of", Method: "GET", Name: ListBuildsWithVersionAsOutput},
 
@@ -132,6 +157,9 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/workers", Method: "GET", Name: ListWorkers},
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
+	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
+	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -152,4 +180,6 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+
+	{Path: "/api/v1/teams/:team_name/audit", Method: "GET", Name: ListAuditEvents},
 })
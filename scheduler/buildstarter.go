@@ -27,6 +27,7 @@ type BuildStarter interface {
 
 type BuildStarterDB interface {
 	GetNextBuildInputs(jobName string) ([]db.BuildInput, bool, error)
+	GetBuildInputOverrides(buildID int) ([]db.BuildInput, error)
 	IsPaused() (bool, error)
 	GetJob(job string) (db.SavedJob, bool, error)
 	UpdateBuildToScheduled(int) (bool, error)
@@ -114,37 +115,11 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := config.JobInputs(jobConfig)
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
-
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
-
-		versions, err := s.db.LoadVersionsDB()
-		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
-			return false, err
-		}
-
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
-		if err != nil {
-			return false, err
-		}
-	}
-
-	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	buildInputs, found, err := s.determineBuildInputs(logger, nextPendingBuild, jobConfig)
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
 		return false, err
 	}
+
 	if !found {
 		return false, nil
 	}
@@ -209,3 +184,102 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+// determineBuildInputs returns the inputs a pending build should run with. A
+// rerun reuses the inputs of the build it reruns as-is, bypassing the input
+// mapper entirely.
+func (s *buildStarter) determineBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild db.Build,
+	jobConfig atc.JobConfig,
+) ([]db.BuildInput, bool, error) {
+	if nextPendingBuild.RerunOf() != 0 {
+		buildInputs, err := s.db.GetBuildInputOverrides(nextPendingBuild.ID())
+		if err != nil {
+			logger.Error("failed-to-get-rerun-build-inputs", err)
+			return nil, false, err
+		}
+
+		return buildInputs, true, nil
+	}
+
+	if nextPendingBuild.IsManuallyTriggered() {
+		jobBuildInputs := config.JobInputs(jobConfig)
+		for _, input := range jobBuildInputs {
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
+
+			err := s.scanner.Scan(scanLog, input.Resource)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		versions, err := s.db.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, false, err
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, jobConfig)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buildInputs, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, false, err
+	}
+
+	if nextPendingBuild.IsManualOverride() {
+		overrides, err := s.db.GetBuildInputOverrides(nextPendingBuild.ID())
+		if err != nil {
+			logger.Error("failed-to-get-build-input-overrides", err)
+			return nil, false, err
+		}
+
+		buildInputs, found = applyInputOverrides(jobConfig, buildInputs, found, overrides)
+	}
+
+	return buildInputs, found, nil
+}
+
+// applyInputOverrides replaces the inputs chosen by the input mapper with the
+// ones chosen by hand. If the input mapper could not satisfy every input, the
+// overrides are only enough if they cover all of them.
+func applyInputOverrides(
+	jobConfig atc.JobConfig,
+	buildInputs []db.BuildInput,
+	found bool,
+	overrides []db.BuildInput,
+) ([]db.BuildInput, bool) {
+	overridden := map[string]db.BuildInput{}
+	for _, override := range overrides {
+		overridden[override.Name] = override
+	}
+
+	if !found {
+		for _, input := range config.JobInputs(jobConfig) {
+			if _, ok := overridden[input.Name]; !ok {
+				return nil, false
+			}
+		}
+
+		return overrides, true
+	}
+
+	merged := []db.BuildInput{}
+	for _, input := range buildInputs {
+		if override, ok := overridden[input.Name]; ok {
+			merged = append(merged, override)
+		} else {
+			merged = append(merged, input)
+		}
+	}
+
+	return merged, true
+}
@@ -219,6 +219,196 @@ var _ = Describe("I'm a BuildStarter", func() {
 			})
 		})
 
+		Context("when triggered with input overrides", func() {
+			var nextInputs []db.BuildInput
+			var overrideInput db.BuildInput
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}, {Get: "input-2"}}}
+
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.IDReturns(66)
+				createdBuild.IsManuallyTriggeredReturns(true)
+				createdBuild.IsManualOverrideReturns(true)
+
+				pendingBuilds = []db.Build{createdBuild}
+
+				nextInputs = []db.BuildInput{
+					{Name: "input-1", VersionedResource: db.VersionedResource{Resource: "input-1", Version: db.Version{"ref": "latest"}}},
+					{Name: "input-2", VersionedResource: db.VersionedResource{Resource: "input-2", Version: db.Version{"ref": "latest"}}},
+				}
+
+				overrideInput = db.BuildInput{
+					Name:              "input-1",
+					VersionedResource: db.VersionedResource{Resource: "input-1", Version: db.Version{"ref": "old"}},
+					FirstOccurrence:   false,
+				}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.LoadVersionsDBReturns(&algorithm.VersionsDB{}, nil)
+				fakeDB.GetBuildInputOverridesReturns([]db.BuildInput{overrideInput}, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeDB.UpdateBuildToScheduledReturns(true, nil)
+				fakeFactory.CreateReturns(atc.Plan{}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					atc.ResourceTypes{{Name: "some-resource-type"}},
+					pendingBuilds,
+				)
+			})
+
+			Context("when the input mapper found inputs", func() {
+				BeforeEach(func() {
+					fakeDB.GetNextBuildInputsReturns(nextInputs, true, nil)
+				})
+
+				It("looks up the overrides of the build", func() {
+					Expect(fakeDB.GetBuildInputOverridesCallCount()).To(Equal(1))
+					Expect(fakeDB.GetBuildInputOverridesArgsForCall(0)).To(Equal(66))
+				})
+
+				It("uses the overridden versions in place of the mapped ones", func() {
+					Expect(tryStartErr).NotTo(HaveOccurred())
+					Expect(fakeDB.UseInputsForBuildCallCount()).To(Equal(1))
+
+					actualBuildID, actualInputs := fakeDB.UseInputsForBuildArgsForCall(0)
+					Expect(actualBuildID).To(Equal(66))
+					Expect(actualInputs).To(Equal([]db.BuildInput{overrideInput, nextInputs[1]}))
+
+					_, _, _, factoryInputs := fakeFactory.CreateArgsForCall(0)
+					Expect(factoryInputs).To(Equal([]db.BuildInput{overrideInput, nextInputs[1]}))
+				})
+			})
+
+			Context("when the input mapper could not satisfy every input", func() {
+				BeforeEach(func() {
+					fakeDB.GetNextBuildInputsReturns(nil, false, nil)
+				})
+
+				Context("and the overrides do not cover every input", func() {
+					It("does not start the build", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+						Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+					})
+				})
+
+				Context("and the overrides cover every input", func() {
+					var otherOverride db.BuildInput
+
+					BeforeEach(func() {
+						otherOverride = db.BuildInput{
+							Name:              "input-2",
+							VersionedResource: db.VersionedResource{Resource: "input-2", Version: db.Version{"ref": "old"}},
+						}
+
+						fakeDB.GetBuildInputOverridesReturns([]db.BuildInput{overrideInput, otherOverride}, nil)
+					})
+
+					It("starts the build with the overrides", func() {
+						Expect(tryStartErr).NotTo(HaveOccurred())
+						Expect(fakeDB.UseInputsForBuildCallCount()).To(Equal(1))
+
+						_, actualInputs := fakeDB.UseInputsForBuildArgsForCall(0)
+						Expect(actualInputs).To(Equal([]db.BuildInput{overrideInput, otherOverride}))
+					})
+				})
+			})
+
+			Context("when getting the overrides fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetNextBuildInputsReturns(nextInputs, true, nil)
+					fakeDB.GetBuildInputOverridesReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+
+				It("does not start the build", func() {
+					Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when rerunning a build", func() {
+			var rerunInputs []db.BuildInput
+
+			BeforeEach(func() {
+				jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}}}
+
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.IDReturns(67)
+				createdBuild.IsManuallyTriggeredReturns(true)
+				createdBuild.RerunOfReturns(66)
+
+				pendingBuilds = []db.Build{createdBuild}
+
+				rerunInputs = []db.BuildInput{
+					{Name: "input-1", VersionedResource: db.VersionedResource{Resource: "input-1", Version: db.Version{"ref": "original"}}},
+				}
+
+				fakeUpdater.UpdateMaxInFlightReachedReturns(false, nil)
+				fakeDB.GetBuildInputOverridesReturns(rerunInputs, nil)
+				fakeDB.IsPausedReturns(false, nil)
+				fakeDB.GetJobReturns(db.SavedJob{Paused: false}, true, nil)
+				fakeDB.UpdateBuildToScheduledReturns(true, nil)
+				fakeFactory.CreateReturns(atc.Plan{}, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			JustBeforeEach(func() {
+				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
+					lagertest.NewTestLogger("test"),
+					jobConfig,
+					atc.ResourceConfigs{{Name: "some-resource"}},
+					atc.ResourceTypes{{Name: "some-resource-type"}},
+					pendingBuilds,
+				)
+			})
+
+			It("bypasses scanning and the input mapper", func() {
+				Expect(fakeScanner.ScanCallCount()).To(BeZero())
+				Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.GetNextBuildInputsCallCount()).To(BeZero())
+			})
+
+			It("starts the build with the inputs of the original build", func() {
+				Expect(tryStartErr).NotTo(HaveOccurred())
+
+				Expect(fakeDB.GetBuildInputOverridesCallCount()).To(Equal(1))
+				Expect(fakeDB.GetBuildInputOverridesArgsForCall(0)).To(Equal(67))
+
+				Expect(fakeDB.UseInputsForBuildCallCount()).To(Equal(1))
+				actualBuildID, actualInputs := fakeDB.UseInputsForBuildArgsForCall(0)
+				Expect(actualBuildID).To(Equal(67))
+				Expect(actualInputs).To(Equal(rerunInputs))
+
+				_, _, _, factoryInputs := fakeFactory.CreateArgsForCall(0)
+				Expect(factoryInputs).To(Equal(rerunInputs))
+			})
+
+			Context("when getting the inputs of the original build fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetBuildInputOverridesReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(tryStartErr).To(Equal(disaster))
+				})
+
+				It("does not start the build", func() {
+					Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when not manually triggered", func() {
 			JustBeforeEach(func() {
 				tryStartErr = buildStarter.TryStartPendingBuildsForJob(
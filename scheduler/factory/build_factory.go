@@ -120,6 +120,13 @@ func (factory *buildFactory) constructPlanFromConfig(
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
+
+		if planConfig.Backoff != nil || planConfig.RetryOn != nil {
+			plan.RetryPolicy = &atc.RetryPolicy{
+				Backoff: planConfig.Backoff,
+				RetryOn: planConfig.RetryOn,
+			}
+		}
 	}
 
 	return factory.applyHooks(constructionParams{
@@ -238,6 +245,12 @@ func (factory *buildFactory) constructUnhookedPlan(
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
 		})
+
+	case planConfig.Gate != "":
+		plan = factory.planFactory.NewPlan(atc.GatePlan{
+			Name: planConfig.Gate,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -271,6 +284,28 @@ func (factory *buildFactory) constructUnhookedPlan(
 		}
 
 		plan = factory.planFactory.NewPlan(aggregate)
+
+	case planConfig.InParallel != nil:
+		inParallel := atc.InParallelPlan{
+			Limit:    planConfig.InParallel.Limit,
+			FailFast: planConfig.InParallel.FailFast,
+		}
+
+		for _, planConfig := range planConfig.InParallel.Steps {
+			nextStep, err := factory.constructPlanFromConfig(
+				planConfig,
+				resources,
+				resourceTypes,
+				inputs,
+			)
+			if err != nil {
+				return atc.Plan{}, err
+			}
+
+			inParallel.Steps = append(inParallel.Steps, nextStep)
+		}
+
+		plan = factory.planFactory.NewPlan(inParallel)
 	}
 
 	if planConfig.Timeout != "" {
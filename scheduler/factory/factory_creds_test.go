@@ -0,0 +1,86 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Credentials", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		input               atc.JobConfig
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"private_key": "((some-secret))"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"password": "((some-secret))"},
+			},
+		}
+
+		input = atc.JobConfig{
+			Plan: atc.PlanSequence{
+				{
+					Get:      "some-get",
+					Resource: "some-resource",
+					Params:   atc.Params{"token": "((some-secret))"},
+				},
+				{
+					Task: "some-task",
+					TaskConfig: &atc.TaskConfig{
+						Params: map[string]string{"SECRET": "((some-secret))"},
+					},
+				},
+			},
+		}
+	})
+
+	It("keeps the ((references)) in the plan so that secrets are never stored with the build", func() {
+		actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+			expectedPlanFactory.NewPlan(atc.GetPlan{
+				Type:          "git",
+				Name:          "some-get",
+				Resource:      "some-resource",
+				PipelineID:    42,
+				Source:        atc.Source{"private_key": "((some-secret))"},
+				Params:        atc.Params{"token": "((some-secret))"},
+				ResourceTypes: resourceTypes,
+			}),
+			expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:       "some-task",
+				PipelineID: 42,
+				Config: &atc.TaskConfig{
+					Params: map[string]string{"SECRET": "((some-secret))"},
+				},
+				ResourceTypes: resourceTypes,
+			}),
+		})
+		Expect(actual).To(testhelpers.MatchPlan(expected))
+	})
+})
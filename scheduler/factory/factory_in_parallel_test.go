@@ -0,0 +1,124 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory InParallel", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resources           atc.ResourceConfigs
+		resourceTypes       atc.ResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when I have an in_parallel step", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Task: "some thing",
+								},
+								{
+									Task: "some other thing",
+								},
+							},
+							Limit:    1,
+							FailFast: true,
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some thing",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some other thing",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+				},
+				Limit:    1,
+				FailFast: true,
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when an in_parallel step has hooks", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						InParallel: &atc.InParallelConfig{
+							Steps: atc.PlanSequence{
+								{
+									Task: "some thing",
+								},
+							},
+						},
+						Failure: &atc.PlanConfig{
+							Task: "some failure thing",
+						},
+					},
+				},
+			}, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:          "some thing",
+							PipelineID:    42,
+							ResourceTypes: resourceTypes,
+						}),
+					},
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some failure thing",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
@@ -66,6 +66,54 @@ var _ = Describe("Factory Retry Step", func() {
 		})
 	})
 
+	Context("when there is a task annotated with 'attempts', 'backoff' and 'retry_on'", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "second task",
+						Attempts: 2,
+						Backoff: &atc.RetryBackoff{
+							Initial:    "10s",
+							Multiplier: 1.5,
+							Max:        "1m",
+						},
+						RetryOn: &atc.RetryConditions{
+							ExitStatuses: []int{2},
+						},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "second task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "second task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			expected.RetryPolicy = &atc.RetryPolicy{
+				Backoff: &atc.RetryBackoff{
+					Initial:    "10s",
+					Multiplier: 1.5,
+					Max:        "1m",
+				},
+				RetryOn: &atc.RetryConditions{
+					ExitStatuses: []int{2},
+				},
+			}
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when there is a task annotated with 'attempts' and 'on_success'", func() {
 		It("builds correctly", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
@@ -1,6 +1,8 @@
 package inputconfig
 
 import (
+	"fmt"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db"
@@ -19,6 +21,17 @@ type TransformerDB interface {
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (db.SavedVersionedResource, bool, error)
 }
 
+// UnknownPassedJobError is returned when an input's passed constraint refers
+// to a job in another pipeline which could not be found, as the input could
+// otherwise never be satisfied without anyone noticing.
+type UnknownPassedJobError struct {
+	Job string
+}
+
+func (err UnknownPassedJobError) Error() string {
+	return fmt.Sprintf("passed constraint references unknown job '%s'", err.Job)
+}
+
 func NewTransformer(db TransformerDB) Transformer {
 	return &transformer{db: db}
 }
@@ -51,7 +64,14 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 
 		jobs := algorithm.JobSet{}
 		for _, passedJobName := range input.Passed {
-			jobs[db.JobIDs[passedJobName]] = struct{}{}
+			jobID, found := db.JobIDs[passedJobName]
+			if !found {
+				if _, _, crossPipeline := config.CrossPipelineJob(passedJobName); crossPipeline {
+					return nil, UnknownPassedJobError{Job: passedJobName}
+				}
+			}
+
+			jobs[jobID] = struct{}{}
 		}
 
 		inputConfigs = append(inputConfigs, algorithm.InputConfig{
@@ -190,6 +190,53 @@ var _ = Describe("Transformer", func() {
 			})
 		})
 
+		Context("when an input is passed a job in another pipeline", func() {
+			var (
+				versionsDB      *algorithm.VersionsDB
+				algorithmInputs algorithm.InputConfigs
+				transformErr    error
+			)
+
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{
+					JobIDs:      map[string]int{"j1": 1, "other-pipeline/j2": 2},
+					ResourceIDs: map[string]int{"r1": 11},
+				}
+			})
+
+			JustBeforeEach(func() {
+				algorithmInputs, transformErr = transformer.TransformInputConfigs(
+					versionsDB,
+					"j1",
+					[]config.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Passed:   []string{"other-pipeline/j2"},
+					}},
+				)
+			})
+
+			It("expresses it as a JobSet like any other job", func() {
+				Expect(transformErr).NotTo(HaveOccurred())
+				Expect(algorithmInputs).To(ConsistOf(algorithm.InputConfig{
+					Name:       "job-input-1",
+					ResourceID: 11,
+					Passed:     algorithm.JobSet{2: struct{}{}},
+					JobID:      1,
+				}))
+			})
+
+			Context("when the job could not be found", func() {
+				BeforeEach(func() {
+					delete(versionsDB.JobIDs, "other-pipeline/j2")
+				})
+
+				It("returns an error", func() {
+					Expect(transformErr).To(Equal(inputconfig.UnknownPassedJobError{Job: "other-pipeline/j2"}))
+				})
+			})
+		})
+
 		Context("when an input has things that don't exist", func() {
 			It("at least doesn't panic", func() {
 				algorithmInputs, transformErr := transformer.TransformInputConfigs(
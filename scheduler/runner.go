@@ -27,6 +27,7 @@ type BuildScheduler interface {
 		jobConfig atc.JobConfig,
 		resourceConfigs atc.ResourceConfigs,
 		resourceTypes atc.ResourceTypes,
+		inputOverrides map[string]int,
 	) (db.Build, Waiter, error)
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
 }
@@ -4,9 +4,11 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/cron"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/scheduler/inputmapper"
@@ -17,6 +19,7 @@ type Scheduler struct {
 	InputMapper  inputmapper.InputMapper
 	BuildStarter BuildStarter
 	Scanner      Scanner
+	Clock        clock.Clock
 }
 
 //go:generate counterfeiter . SchedulerDB
@@ -28,7 +31,11 @@ type SchedulerDB interface {
 	Reload() (bool, error)
 	Config() atc.Config
 	CreateJobBuild(job string) (db.Build, error)
+	CreateJobBuildWithInputOverrides(job string, overrides map[string]int) (db.Build, error)
 	EnsurePendingBuildExists(jobName string) error
+	EnsureScheduledBuildExists(jobName string, scheduledAt time.Time) (bool, error)
+	GetJobLastScheduled(jobName string) (time.Time, bool, error)
+	InitJobLastScheduled(jobName string, startedAt time.Time) error
 	GetAllPendingBuilds() (map[string][]db.Build, error)
 	GetPendingBuildsForJob(jobName string) ([]db.Build, error)
 }
@@ -50,7 +57,12 @@ func (s *Scheduler) Schedule(
 
 	for _, jobConfig := range jobConfigs {
 		jStart := time.Now()
-		err := s.ensurePendingBuildExists(logger, versions, jobConfig)
+		err := s.ensureScheduledBuildExists(logger, jobConfig)
+		if err != nil {
+			return jobSchedulingTime, err
+		}
+
+		err = s.ensurePendingBuildExists(logger, versions, jobConfig)
 		jobSchedulingTime[jobConfig.Name] = time.Since(jStart)
 
 		if err != nil {
@@ -110,6 +122,73 @@ func (s *Scheduler) ensurePendingBuildExists(
 	return nil
 }
 
+// ensureScheduledBuildExists fires the job's schedule if a slot has passed
+// since it last fired. Any slots missed while no ATC was scheduling are
+// coalesced into a single build for the most recent one.
+func (s *Scheduler) ensureScheduledBuildExists(logger lager.Logger, jobConfig atc.JobConfig) error {
+	if jobConfig.Schedule == nil {
+		return nil
+	}
+
+	logger = logger.Session("schedule", lager.Data{"job": jobConfig.Name})
+
+	schedule, err := cron.Parse(jobConfig.Schedule.Cron)
+	if err != nil {
+		logger.Error("failed-to-parse-cron", err)
+		return nil
+	}
+
+	location, err := jobConfig.Schedule.Location()
+	if err != nil {
+		logger.Error("failed-to-load-timezone", err)
+		return nil
+	}
+
+	now := s.Clock.Now().In(location)
+
+	lastScheduled, found, err := s.DB.GetJobLastScheduled(jobConfig.Name)
+	if err != nil {
+		logger.Error("failed-to-get-last-scheduled", err)
+		return err
+	}
+
+	if !found {
+		err := s.DB.InitJobLastScheduled(jobConfig.Name, now)
+		if err != nil {
+			logger.Error("failed-to-init-last-scheduled", err)
+			return err
+		}
+
+		return nil
+	}
+
+	slot := schedule.Next(lastScheduled.In(location))
+	if slot.IsZero() || slot.After(now) {
+		return nil
+	}
+
+	for {
+		next := schedule.Next(slot)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+
+		slot = next
+	}
+
+	triggered, err := s.DB.EnsureScheduledBuildExists(jobConfig.Name, slot)
+	if err != nil {
+		logger.Error("failed-to-ensure-scheduled-build-exists", err)
+		return err
+	}
+
+	if triggered {
+		logger.Info("triggered", lager.Data{"slot": slot})
+	}
+
+	return nil
+}
+
 type Waiter interface {
 	Wait()
 }
@@ -119,10 +198,17 @@ func (s *Scheduler) TriggerImmediately(
 	jobConfig atc.JobConfig,
 	resourceConfigs atc.ResourceConfigs,
 	resourceTypes atc.ResourceTypes,
+	inputOverrides map[string]int,
 ) (db.Build, Waiter, error) {
 	logger = logger.Session("trigger-immediately", lager.Data{"job_name": jobConfig.Name})
 
-	build, err := s.DB.CreateJobBuild(jobConfig.Name)
+	var build db.Build
+	var err error
+	if len(inputOverrides) > 0 {
+		build, err = s.DB.CreateJobBuildWithInputOverrides(jobConfig.Name, inputOverrides)
+	} else {
+		build, err = s.DB.CreateJobBuild(jobConfig.Name)
+	}
 	if err != nil {
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
@@ -2,7 +2,9 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -21,6 +23,7 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
 		fakeScanner      *schedulerfakes.FakeScanner
+		fakeClock        *fakeclock.FakeClock
 
 		scheduler *Scheduler
 
@@ -32,12 +35,14 @@ var _ = Describe("Scheduler", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 		fakeScanner = new(schedulerfakes.FakeScanner)
+		fakeClock = fakeclock.NewFakeClock(time.Date(2017, time.March, 1, 10, 30, 0, 0, time.UTC))
 
 		scheduler = &Scheduler{
 			DB:           fakeDB,
 			InputMapper:  fakeInputMapper,
 			BuildStarter: fakeBuildStarter,
 			Scanner:      fakeScanner,
+			Clock:        fakeClock,
 		}
 
 		disaster = errors.New("bad thing")
@@ -232,16 +237,146 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			BeforeEach(func() {
+				jobConfigs = atc.JobConfigs{
+					{
+						Name:     "some-job",
+						Schedule: &atc.ScheduleConfig{Cron: "0 * * * *"},
+					},
+				}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+				fakeBuildStarter.TryStartPendingBuildsForJobReturns(nil)
+			})
+
+			Context("when the schedule has never been evaluated", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Time{}, false, nil)
+				})
+
+				It("starts counting from now", func() {
+					Expect(fakeDB.InitJobLastScheduledCallCount()).To(Equal(1))
+					jobName, startedAt := fakeDB.InitJobLastScheduledArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(startedAt).To(Equal(fakeClock.Now()))
+				})
+
+				It("does not trigger a build", func() {
+					Expect(fakeDB.EnsureScheduledBuildExistsCallCount()).To(BeZero())
+				})
+
+				Context("when recording the start fails", func() {
+					BeforeEach(func() {
+						fakeDB.InitJobLastScheduledReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the next slot has not come yet", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC), true, nil)
+				})
+
+				It("does not trigger a build", func() {
+					Expect(fakeDB.EnsureScheduledBuildExistsCallCount()).To(BeZero())
+					Expect(scheduleErr).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("when a slot has passed", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Date(2017, time.March, 1, 9, 0, 0, 0, time.UTC), true, nil)
+				})
+
+				It("triggers a build for the slot", func() {
+					Expect(fakeDB.EnsureScheduledBuildExistsCallCount()).To(Equal(1))
+					jobName, slot := fakeDB.EnsureScheduledBuildExistsArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(slot).To(Equal(time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)))
+				})
+
+				It("does not record a new start", func() {
+					Expect(fakeDB.InitJobLastScheduledCallCount()).To(BeZero())
+				})
+
+				Context("when triggering the build fails", func() {
+					BeforeEach(func() {
+						fakeDB.EnsureScheduledBuildExistsReturns(false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when several slots have passed", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Date(2017, time.February, 28, 22, 0, 0, 0, time.UTC), true, nil)
+				})
+
+				It("triggers a single build for the most recent slot", func() {
+					Expect(fakeDB.EnsureScheduledBuildExistsCallCount()).To(Equal(1))
+					_, slot := fakeDB.EnsureScheduledBuildExistsArgsForCall(0)
+					Expect(slot).To(Equal(time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)))
+				})
+			})
+
+			Context("when the schedule has a timezone", func() {
+				BeforeEach(func() {
+					jobConfigs[0].Schedule = &atc.ScheduleConfig{Cron: "0 5 * * *", Timezone: "America/New_York"}
+					fakeDB.GetJobLastScheduledReturns(time.Date(2017, time.February, 28, 12, 0, 0, 0, time.UTC), true, nil)
+				})
+
+				It("evaluates the schedule in the timezone", func() {
+					Expect(fakeDB.EnsureScheduledBuildExistsCallCount()).To(Equal(1))
+					_, slot := fakeDB.EnsureScheduledBuildExistsArgsForCall(0)
+					Expect(slot.UTC()).To(Equal(time.Date(2017, time.March, 1, 10, 0, 0, 0, time.UTC)))
+				})
+			})
+
+			Context("when getting the last scheduled time fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetJobLastScheduledReturns(time.Time{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(scheduleErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when the job has no schedule", func() {
+			BeforeEach(func() {
+				jobConfigs = atc.JobConfigs{{Name: "some-job"}}
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			It("does not look up when it was last scheduled", func() {
+				Expect(fakeDB.GetJobLastScheduledCallCount()).To(BeZero())
+			})
+		})
 	})
 
 	Describe("TriggerImmediately", func() {
 		var (
 			jobConfig         atc.JobConfig
+			inputOverrides    map[string]int
 			triggeredBuild    db.Build
 			triggerErr        error
 			nextPendingBuilds []db.Build
 		)
 
+		BeforeEach(func() {
+			inputOverrides = nil
+		})
+
 		JustBeforeEach(func() {
 			jobConfig = atc.JobConfig{Name: "some-job", Plan: atc.PlanSequence{{Get: "input-1"}, {Get: "input-2"}}}
 
@@ -250,7 +385,9 @@ var _ = Describe("Scheduler", func() {
 				lagertest.NewTestLogger("test"),
 				jobConfig,
 				atc.ResourceConfigs{{Name: "some-resource"}},
-				atc.ResourceTypes{{Name: "some-resource-type"}})
+				atc.ResourceTypes{{Name: "some-resource-type"}},
+				inputOverrides,
+			)
 			if waiter != nil {
 				waiter.Wait()
 			}
@@ -280,6 +417,10 @@ var _ = Describe("Scheduler", func() {
 				Expect(fakeDB.CreateJobBuildArgsForCall(0)).To(Equal("some-job"))
 			})
 
+			It("does not create a build with input overrides", func() {
+				Expect(fakeDB.CreateJobBuildWithInputOverridesCallCount()).To(BeZero())
+			})
+
 			Context("when get pending builds for job fails", func() {
 				BeforeEach(func() {
 					fakeDB.GetPendingBuildsForJobReturns(nil, disaster)
@@ -314,6 +455,43 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when input overrides are given", func() {
+			var createdBuild *dbfakes.FakeBuild
+
+			BeforeEach(func() {
+				inputOverrides = map[string]int{"input-1": 42}
+
+				createdBuild = new(dbfakes.FakeBuild)
+				createdBuild.IsManuallyTriggeredReturns(true)
+				createdBuild.IsManualOverrideReturns(true)
+				fakeDB.CreateJobBuildWithInputOverridesReturns(createdBuild, nil)
+			})
+
+			It("creates a build with the overrides", func() {
+				Expect(fakeDB.CreateJobBuildCallCount()).To(BeZero())
+				Expect(fakeDB.CreateJobBuildWithInputOverridesCallCount()).To(Equal(1))
+
+				jobName, overrides := fakeDB.CreateJobBuildWithInputOverridesArgsForCall(0)
+				Expect(jobName).To(Equal("some-job"))
+				Expect(overrides).To(Equal(map[string]int{"input-1": 42}))
+			})
+
+			It("returns the build", func() {
+				Expect(triggerErr).NotTo(HaveOccurred())
+				Expect(triggeredBuild).To(Equal(createdBuild))
+			})
+
+			Context("when creating the build fails", func() {
+				BeforeEach(func() {
+					fakeDB.CreateJobBuildWithInputOverridesReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(triggerErr).To(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("SaveNextInputMapping", func() {
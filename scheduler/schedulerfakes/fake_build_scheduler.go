@@ -26,28 +26,29 @@ type FakeBuildScheduler struct {
 		result1 map[string]time.Duration
 		result2 error
 	}
-	TriggerImmediatelyStub        func(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error)
+	SaveNextInputMappingStub        func(logger lager.Logger, job atc.JobConfig) error
+	saveNextInputMappingMutex       sync.RWMutex
+	saveNextInputMappingArgsForCall []struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}
+	saveNextInputMappingReturns struct {
+		result1 error
+	}
+	TriggerImmediatelyStub        func(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, inputOverrides map[string]int) (db.Build, scheduler.Waiter, error)
 	triggerImmediatelyMutex       sync.RWMutex
 	triggerImmediatelyArgsForCall []struct {
 		logger          lager.Logger
 		jobConfig       atc.JobConfig
 		resourceConfigs atc.ResourceConfigs
 		resourceTypes   atc.ResourceTypes
+		inputOverrides  map[string]int
 	}
 	triggerImmediatelyReturns struct {
 		result1 db.Build
 		result2 scheduler.Waiter
 		result3 error
 	}
-	SaveNextInputMappingStub        func(logger lager.Logger, job atc.JobConfig) error
-	saveNextInputMappingMutex       sync.RWMutex
-	saveNextInputMappingArgsForCall []struct {
-		logger lager.Logger
-		job    atc.JobConfig
-	}
-	saveNextInputMappingReturns struct {
-		result1 error
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,44 +91,6 @@ func (fake *FakeBuildScheduler) ScheduleReturns(result1 map[string]time.Duration
 	}{result1, result2}
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes) (db.Build, scheduler.Waiter, error) {
-	fake.triggerImmediatelyMutex.Lock()
-	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
-		logger          lager.Logger
-		jobConfig       atc.JobConfig
-		resourceConfigs atc.ResourceConfigs
-		resourceTypes   atc.ResourceTypes
-	}{logger, jobConfig, resourceConfigs, resourceTypes})
-	fake.recordInvocation("TriggerImmediately", []interface{}{logger, jobConfig, resourceConfigs, resourceTypes})
-	fake.triggerImmediatelyMutex.Unlock()
-	if fake.TriggerImmediatelyStub != nil {
-		return fake.TriggerImmediatelyStub(logger, jobConfig, resourceConfigs, resourceTypes)
-	} else {
-		return fake.triggerImmediatelyReturns.result1, fake.triggerImmediatelyReturns.result2, fake.triggerImmediatelyReturns.result3
-	}
-}
-
-func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
-	fake.triggerImmediatelyMutex.RLock()
-	defer fake.triggerImmediatelyMutex.RUnlock()
-	return len(fake.triggerImmediatelyArgsForCall)
-}
-
-func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes) {
-	fake.triggerImmediatelyMutex.RLock()
-	defer fake.triggerImmediatelyMutex.RUnlock()
-	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].jobConfig, fake.triggerImmediatelyArgsForCall[i].resourceConfigs, fake.triggerImmediatelyArgsForCall[i].resourceTypes
-}
-
-func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
-	fake.TriggerImmediatelyStub = nil
-	fake.triggerImmediatelyReturns = struct {
-		result1 db.Build
-		result2 scheduler.Waiter
-		result3 error
-	}{result1, result2, result3}
-}
-
 func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error {
 	fake.saveNextInputMappingMutex.Lock()
 	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
@@ -162,15 +125,54 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, inputOverrides map[string]int) (db.Build, scheduler.Waiter, error) {
+	fake.triggerImmediatelyMutex.Lock()
+	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
+		logger          lager.Logger
+		jobConfig       atc.JobConfig
+		resourceConfigs atc.ResourceConfigs
+		resourceTypes   atc.ResourceTypes
+		inputOverrides  map[string]int
+	}{logger, jobConfig, resourceConfigs, resourceTypes, inputOverrides})
+	fake.recordInvocation("TriggerImmediately", []interface{}{logger, jobConfig, resourceConfigs, resourceTypes, inputOverrides})
+	fake.triggerImmediatelyMutex.Unlock()
+	if fake.TriggerImmediatelyStub != nil {
+		return fake.TriggerImmediatelyStub(logger, jobConfig, resourceConfigs, resourceTypes, inputOverrides)
+	} else {
+		return fake.triggerImmediatelyReturns.result1, fake.triggerImmediatelyReturns.result2, fake.triggerImmediatelyReturns.result3
+	}
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
+	fake.triggerImmediatelyMutex.RLock()
+	defer fake.triggerImmediatelyMutex.RUnlock()
+	return len(fake.triggerImmediatelyArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes, map[string]int) {
+	fake.triggerImmediatelyMutex.RLock()
+	defer fake.triggerImmediatelyMutex.RUnlock()
+	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].jobConfig, fake.triggerImmediatelyArgsForCall[i].resourceConfigs, fake.triggerImmediatelyArgsForCall[i].resourceTypes, fake.triggerImmediatelyArgsForCall[i].inputOverrides
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 db.Build, result2 scheduler.Waiter, result3 error) {
+	fake.TriggerImmediatelyStub = nil
+	fake.triggerImmediatelyReturns = struct {
+		result1 db.Build
+		result2 scheduler.Waiter
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
-	fake.triggerImmediatelyMutex.RLock()
-	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.triggerImmediatelyMutex.RLock()
+	defer fake.triggerImmediatelyMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -62,6 +62,15 @@ type FakeBuildStarterDB struct {
 		result1 *algorithm.VersionsDB
 		result2 error
 	}
+	GetBuildInputOverridesStub        func(buildID int) ([]db.BuildInput, error)
+	getBuildInputOverridesMutex       sync.RWMutex
+	getBuildInputOverridesArgsForCall []struct {
+		buildID int
+	}
+	getBuildInputOverridesReturns struct {
+		result1 []db.BuildInput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -261,6 +270,40 @@ func (fake *FakeBuildStarterDB) LoadVersionsDBReturns(result1 *algorithm.Version
 	}{result1, result2}
 }
 
+func (fake *FakeBuildStarterDB) GetBuildInputOverrides(buildID int) ([]db.BuildInput, error) {
+	fake.getBuildInputOverridesMutex.Lock()
+	fake.getBuildInputOverridesArgsForCall = append(fake.getBuildInputOverridesArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("GetBuildInputOverrides", []interface{}{buildID})
+	fake.getBuildInputOverridesMutex.Unlock()
+	if fake.GetBuildInputOverridesStub != nil {
+		return fake.GetBuildInputOverridesStub(buildID)
+	} else {
+		return fake.getBuildInputOverridesReturns.result1, fake.getBuildInputOverridesReturns.result2
+	}
+}
+
+func (fake *FakeBuildStarterDB) GetBuildInputOverridesCallCount() int {
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
+	return len(fake.getBuildInputOverridesArgsForCall)
+}
+
+func (fake *FakeBuildStarterDB) GetBuildInputOverridesArgsForCall(i int) int {
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
+	return fake.getBuildInputOverridesArgsForCall[i].buildID
+}
+
+func (fake *FakeBuildStarterDB) GetBuildInputOverridesReturns(result1 []db.BuildInput, result2 error) {
+	fake.GetBuildInputOverridesStub = nil
+	fake.getBuildInputOverridesReturns = struct {
+		result1 []db.BuildInput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildStarterDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -276,6 +319,8 @@ func (fake *FakeBuildStarterDB) Invocations() map[string][][]interface{} {
 	defer fake.useInputsForBuildMutex.RUnlock()
 	fake.loadVersionsDBMutex.RLock()
 	defer fake.loadVersionsDBMutex.RUnlock()
+	fake.getBuildInputOverridesMutex.RLock()
+	defer fake.getBuildInputOverridesMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -83,6 +83,45 @@ type FakeSchedulerDB struct {
 		result1 []db.Build
 		result2 error
 	}
+	EnsureScheduledBuildExistsStub        func(jobName string, scheduledAt time.Time) (bool, error)
+	ensureScheduledBuildExistsMutex       sync.RWMutex
+	ensureScheduledBuildExistsArgsForCall []struct {
+		jobName     string
+		scheduledAt time.Time
+	}
+	ensureScheduledBuildExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	GetJobLastScheduledStub        func(jobName string) (time.Time, bool, error)
+	getJobLastScheduledMutex       sync.RWMutex
+	getJobLastScheduledArgsForCall []struct {
+		jobName string
+	}
+	getJobLastScheduledReturns struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}
+	InitJobLastScheduledStub        func(jobName string, startedAt time.Time) error
+	initJobLastScheduledMutex       sync.RWMutex
+	initJobLastScheduledArgsForCall []struct {
+		jobName   string
+		startedAt time.Time
+	}
+	initJobLastScheduledReturns struct {
+		result1 error
+	}
+	CreateJobBuildWithInputOverridesStub        func(job string, overrides map[string]int) (db.Build, error)
+	createJobBuildWithInputOverridesMutex       sync.RWMutex
+	createJobBuildWithInputOverridesArgsForCall []struct {
+		job       string
+		overrides map[string]int
+	}
+	createJobBuildWithInputOverridesReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -352,6 +391,145 @@ func (fake *FakeSchedulerDB) GetPendingBuildsForJobReturns(result1 []db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeSchedulerDB) EnsureScheduledBuildExists(jobName string, scheduledAt time.Time) (bool, error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	fake.ensureScheduledBuildExistsArgsForCall = append(fake.ensureScheduledBuildExistsArgsForCall, struct {
+		jobName     string
+		scheduledAt time.Time
+	}{jobName, scheduledAt})
+	fake.recordInvocation("EnsureScheduledBuildExists", []interface{}{jobName, scheduledAt})
+	fake.ensureScheduledBuildExistsMutex.Unlock()
+	if fake.EnsureScheduledBuildExistsStub != nil {
+		return fake.EnsureScheduledBuildExistsStub(jobName, scheduledAt)
+	} else {
+		return fake.ensureScheduledBuildExistsReturns.result1, fake.ensureScheduledBuildExistsReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) EnsureScheduledBuildExistsCallCount() int {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return len(fake.ensureScheduledBuildExistsArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) EnsureScheduledBuildExistsArgsForCall(i int) (string, time.Time) {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return fake.ensureScheduledBuildExistsArgsForCall[i].jobName, fake.ensureScheduledBuildExistsArgsForCall[i].scheduledAt
+}
+
+func (fake *FakeSchedulerDB) EnsureScheduledBuildExistsReturns(result1 bool, result2 error) {
+	fake.EnsureScheduledBuildExistsStub = nil
+	fake.ensureScheduledBuildExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduled(jobName string) (time.Time, bool, error) {
+	fake.getJobLastScheduledMutex.Lock()
+	fake.getJobLastScheduledArgsForCall = append(fake.getJobLastScheduledArgsForCall, struct {
+		jobName string
+	}{jobName})
+	fake.recordInvocation("GetJobLastScheduled", []interface{}{jobName})
+	fake.getJobLastScheduledMutex.Unlock()
+	if fake.GetJobLastScheduledStub != nil {
+		return fake.GetJobLastScheduledStub(jobName)
+	} else {
+		return fake.getJobLastScheduledReturns.result1, fake.getJobLastScheduledReturns.result2, fake.getJobLastScheduledReturns.result3
+	}
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledCallCount() int {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return len(fake.getJobLastScheduledArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledArgsForCall(i int) string {
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	return fake.getJobLastScheduledArgsForCall[i].jobName
+}
+
+func (fake *FakeSchedulerDB) GetJobLastScheduledReturns(result1 time.Time, result2 bool, result3 error) {
+	fake.GetJobLastScheduledStub = nil
+	fake.getJobLastScheduledReturns = struct {
+		result1 time.Time
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSchedulerDB) InitJobLastScheduled(jobName string, startedAt time.Time) error {
+	fake.initJobLastScheduledMutex.Lock()
+	fake.initJobLastScheduledArgsForCall = append(fake.initJobLastScheduledArgsForCall, struct {
+		jobName   string
+		startedAt time.Time
+	}{jobName, startedAt})
+	fake.recordInvocation("InitJobLastScheduled", []interface{}{jobName, startedAt})
+	fake.initJobLastScheduledMutex.Unlock()
+	if fake.InitJobLastScheduledStub != nil {
+		return fake.InitJobLastScheduledStub(jobName, startedAt)
+	} else {
+		return fake.initJobLastScheduledReturns.result1
+	}
+}
+
+func (fake *FakeSchedulerDB) InitJobLastScheduledCallCount() int {
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	return len(fake.initJobLastScheduledArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) InitJobLastScheduledArgsForCall(i int) (string, time.Time) {
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	return fake.initJobLastScheduledArgsForCall[i].jobName, fake.initJobLastScheduledArgsForCall[i].startedAt
+}
+
+func (fake *FakeSchedulerDB) InitJobLastScheduledReturns(result1 error) {
+	fake.InitJobLastScheduledStub = nil
+	fake.initJobLastScheduledReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSchedulerDB) CreateJobBuildWithInputOverrides(job string, overrides map[string]int) (db.Build, error) {
+	fake.createJobBuildWithInputOverridesMutex.Lock()
+	fake.createJobBuildWithInputOverridesArgsForCall = append(fake.createJobBuildWithInputOverridesArgsForCall, struct {
+		job       string
+		overrides map[string]int
+	}{job, overrides})
+	fake.recordInvocation("CreateJobBuildWithInputOverrides", []interface{}{job, overrides})
+	fake.createJobBuildWithInputOverridesMutex.Unlock()
+	if fake.CreateJobBuildWithInputOverridesStub != nil {
+		return fake.CreateJobBuildWithInputOverridesStub(job, overrides)
+	} else {
+		return fake.createJobBuildWithInputOverridesReturns.result1, fake.createJobBuildWithInputOverridesReturns.result2
+	}
+}
+
+func (fake *FakeSchedulerDB) CreateJobBuildWithInputOverridesCallCount() int {
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
+	return len(fake.createJobBuildWithInputOverridesArgsForCall)
+}
+
+func (fake *FakeSchedulerDB) CreateJobBuildWithInputOverridesArgsForCall(i int) (string, map[string]int) {
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
+	return fake.createJobBuildWithInputOverridesArgsForCall[i].job, fake.createJobBuildWithInputOverridesArgsForCall[i].overrides
+}
+
+func (fake *FakeSchedulerDB) CreateJobBuildWithInputOverridesReturns(result1 db.Build, result2 error) {
+	fake.CreateJobBuildWithInputOverridesStub = nil
+	fake.createJobBuildWithInputOverridesReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -373,6 +551,14 @@ func (fake *FakeSchedulerDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPendingBuildsMutex.RUnlock()
 	fake.getPendingBuildsForJobMutex.RLock()
 	defer fake.getPendingBuildsForJobMutex.RUnlock()
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	fake.getJobLastScheduledMutex.RLock()
+	defer fake.getJobLastScheduledMutex.RUnlock()
+	fake.initJobLastScheduledMutex.RLock()
+	defer fake.initJobLastScheduledMutex.RUnlock()
+	fake.createJobBuildWithInputOverridesMutex.RLock()
+	defer fake.createJobBuildWithInputOverridesMutex.RUnlock()
 	return fake.invocations
 }
 
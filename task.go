@@ -31,6 +31,34 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// The set of paths whose contents persist between builds of the same job
+	// on the same worker.
+	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Limits on the resources the task's container may use.
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+type ContainerLimits struct {
+	// The container's relative share of CPU time.
+	CPU int64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// The maximum memory, in bytes, the container may use.
+	Memory int64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge overrides the limits with any that are set in other.
+func (limits ContainerLimits) Merge(other ContainerLimits) ContainerLimits {
+	if other.CPU != 0 {
+		limits.CPU = other.CPU
+	}
+
+	if other.Memory != 0 {
+		limits.Memory = other.Memory
+	}
+
+	return limits
 }
 
 type ImageResource struct {
@@ -110,6 +138,16 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.ContainerLimits != nil {
+		var limits ContainerLimits
+		if config.ContainerLimits != nil {
+			limits = *config.ContainerLimits
+		}
+
+		limits = limits.Merge(*other.ContainerLimits)
+		config.ContainerLimits = &limits
+	}
+
 	return config
 }
 
@@ -125,6 +163,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateContainerLimits()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -133,6 +172,24 @@ func (config TaskConfig) Validate() error {
 	return nil
 }
 
+func (config TaskConfig) validateContainerLimits() []string {
+	messages := []string{}
+
+	if config.ContainerLimits == nil {
+		return messages
+	}
+
+	if config.ContainerLimits.CPU < 0 {
+		messages = append(messages, fmt.Sprintf("  container_limits has a negative cpu limit (%d)", config.ContainerLimits.CPU))
+	}
+
+	if config.ContainerLimits.Memory < 0 {
+		messages = append(messages, fmt.Sprintf("  container_limits has a negative memory limit (%d)", config.ContainerLimits.Memory))
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputsAndOutputs() []string {
 	messages := []string{}
 
@@ -140,6 +197,7 @@ func (config TaskConfig) validateInputsAndOutputs() []string {
 	messages = append(messages, config.validateOutputContainsNames()...)
 	messages = append(messages, config.validateDotPath()...)
 	messages = append(messages, config.validateOverlappingPaths()...)
+	messages = append(messages, config.validateCachePaths()...)
 
 	return messages
 }
@@ -315,6 +373,61 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCachePaths() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		path := filepath.Clean(cache.Path)
+
+		switch {
+		case cache.Path == "":
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+		case filepath.IsAbs(path):
+			messages = append(messages, fmt.Sprintf("  cache in position %d has an absolute path ('%s')", i, cache.Path))
+		case path == "." || path == ".." || strings.HasPrefix(path, "../"):
+			messages = append(messages, fmt.Sprintf("  cache in position %d is not within the working directory ('%s')", i, cache.Path))
+		default:
+			messages = append(messages, config.validateCacheOverlap(i, path)...)
+		}
+	}
+
+	return messages
+}
+
+// validateCacheOverlap checks that a cache is not the same as, nested in, or
+// a parent of an input, an output, or an earlier cache. Caches are mounted
+// over the working directory as volumes of their own, so anything they
+// overlapped with would either hide them or be hidden by them.
+func (config TaskConfig) validateCacheOverlap(position int, path string) []string {
+	messages := []string{}
+
+	overlaps := func(other string) bool {
+		return other == path || pathContains(path, other) || pathContains(other, path)
+	}
+
+	for _, input := range config.Inputs {
+		inputPath := filepath.Clean(input.resolvePath())
+		if overlaps(inputPath) {
+			messages = append(messages, fmt.Sprintf("  cache in position %d overlaps with input '%s' ('%s')", position, input.Name, path))
+		}
+	}
+
+	for _, output := range config.Outputs {
+		outputPath := filepath.Clean(output.resolvePath())
+		if overlaps(outputPath) {
+			messages = append(messages, fmt.Sprintf("  cache in position %d overlaps with output '%s' ('%s')", position, output.Name, path))
+		}
+	}
+
+	for i, cache := range config.Caches[:position] {
+		if overlaps(filepath.Clean(cache.Path)) {
+			messages = append(messages, fmt.Sprintf("  cache in position %d overlaps with cache in position %d ('%s')", position, i, path))
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -348,6 +461,10 @@ func (input TaskInputConfig) resolvePath() string {
 	return input.Name
 }
 
+type CacheConfig struct {
+	Path string `json:"path" yaml:"path"`
+}
+
 type TaskOutputConfig struct {
 	Name string `json:"name" yaml:"name"`
 	Path string `json:"path,omitempty" yaml:"path"`
@@ -234,6 +234,121 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, CacheConfig{Path: "some/cache"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"}, CacheConfig{Path: ""})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+
+			Context("when cache.path is absolute", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "/some/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 has an absolute path ('/some/cache')")))
+				})
+			})
+
+			Context("when cache.path is outside of the working directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/../../cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 is not within the working directory ('some/../../cache')")))
+				})
+			})
+
+			Context("when cache.path is the same as an input's path", func() {
+				BeforeEach(func() {
+					invalidConfig.Inputs = append(invalidConfig.Inputs, TaskInputConfig{Name: "some-input", Path: "some/cache"})
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "./some/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 overlaps with input 'some-input' ('some/cache')")))
+				})
+			})
+
+			Context("when cache.path is within an input's directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Inputs = append(invalidConfig.Inputs, TaskInputConfig{Name: "some-input"})
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some-input/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 overlaps with input 'some-input' ('some-input/cache')")))
+				})
+			})
+
+			Context("when cache.path contains an output's directory", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "some-output", Path: "some/cache/output"})
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 0 overlaps with output 'some-output' ('some/cache')")))
+				})
+			})
+
+			Context("when cache.path is only a sibling of an input's path", func() {
+				BeforeEach(func() {
+					validConfig.Inputs = append(validConfig.Inputs, TaskInputConfig{Name: "some-cache-input", Path: "some/cache-input"})
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when cache.path is repeated", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some/cache"}, CacheConfig{Path: "some/cache/"})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 overlaps with cache in position 0 ('some/cache')")))
+				})
+			})
+		})
+
+		Context("when the task has container limits", func() {
+			BeforeEach(func() {
+				validConfig.ContainerLimits = &ContainerLimits{CPU: 512, Memory: 1073741824}
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when a limit is negative", func() {
+				BeforeEach(func() {
+					invalidConfig.ContainerLimits = &ContainerLimits{CPU: -1, Memory: -1024}
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+					Expect(err).To(MatchError(ContainSubstring("  container_limits has a negative cpu limit (-1)")))
+					Expect(err).To(MatchError(ContainSubstring("  container_limits has a negative memory limit (-1024)")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -723,5 +838,32 @@ run: {path: a/file}
 				}))
 
 		})
+
+		It("overrides the container limits that are set", func() {
+			Expect(TaskConfig{
+				ContainerLimits: &ContainerLimits{CPU: 512, Memory: 1024},
+			}.Merge(TaskConfig{
+				ContainerLimits: &ContainerLimits{Memory: 2048},
+			})).To(
+
+				Equal(TaskConfig{
+					ContainerLimits: &ContainerLimits{CPU: 512, Memory: 2048},
+				}))
+
+		})
+
+		It("preserves the container limits when none are given", func() {
+			Expect(TaskConfig{
+				ContainerLimits: &ContainerLimits{CPU: 512},
+			}.Merge(TaskConfig{
+				Image: "some-image",
+			})).To(
+
+				Equal(TaskConfig{
+					Image:           "some-image",
+					ContainerLimits: &ContainerLimits{CPU: 512},
+				}))
+
+		})
 	})
 })
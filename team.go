@@ -12,6 +12,59 @@ type Team struct {
 	GitHubAuth   *GitHubAuth   `json:"github_auth,omitempty"`
 	UAAAuth      *UAAAuth      `json:"uaa_auth,omitempty"`
 	GenericOAuth *GenericOAuth `json:"genericoauth_auth,omitempty"`
+
+	// Roles grants restricted roles to identities which are not admitted by
+	// the team's own auth configuration. Anyone admitted by the team's own
+	// auth configuration is an owner.
+	Roles []TeamRoleBinding `json:"roles,omitempty"`
+}
+
+// TeamRole determines what a team member may do within their team.
+type TeamRole string
+
+const (
+	// TeamRoleOwner may do anything, including configuring the team itself.
+	TeamRoleOwner TeamRole = "owner"
+
+	// TeamRoleMember may configure pipelines, run one-off builds and hijack
+	// containers.
+	TeamRoleMember TeamRole = "member"
+
+	// TeamRoleOperator may trigger and abort builds and pause, unpause and
+	// check pipelines, jobs and resources.
+	TeamRoleOperator TeamRole = "operator"
+
+	// TeamRoleViewer has read-only access to the team.
+	TeamRoleViewer TeamRole = "viewer"
+)
+
+var teamRoleRanks = map[TeamRole]int{
+	TeamRoleViewer:   1,
+	TeamRoleOperator: 2,
+	TeamRoleMember:   3,
+	TeamRoleOwner:    4,
+}
+
+func (role TeamRole) IsValid() bool {
+	_, found := teamRoleRanks[role]
+	return found
+}
+
+// Includes returns true if the role grants everything that other grants.
+func (role TeamRole) Includes(other TeamRole) bool {
+	return role.IsValid() && teamRoleRanks[role] >= teamRoleRanks[other]
+}
+
+// TeamRoleBinding grants a role to the identities of the team's auth
+// providers which it lists.
+type TeamRoleBinding struct {
+	Role TeamRole `json:"role"`
+
+	GitHubOrganizations []string     `json:"github_organizations,omitempty"`
+	GitHubTeams         []GitHubTeam `json:"github_teams,omitempty"`
+	GitHubUsers         []string     `json:"github_users,omitempty"`
+	CFSpaces            []string     `json:"cf_spaces,omitempty"`
+	GenericOAuthScope   string       `json:"genericoauth_scope,omitempty"`
 }
 
 type BasicAuth struct {
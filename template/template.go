@@ -0,0 +1,185 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Variables are the values substituted for the {{name}} placeholders of a
+// pipeline template.
+type Variables map[string]interface{}
+
+// ParseVariables loads a YAML (or JSON) variables document.
+func ParseVariables(payload []byte) (Variables, error) {
+	var raw map[string]interface{}
+	err := yaml.Unmarshal(payload, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := Variables{}
+	for name, value := range raw {
+		sanitized, err := sanitize(value)
+		if err != nil {
+			return nil, err
+		}
+
+		variables[name] = sanitized
+	}
+
+	return variables, nil
+}
+
+// sanitize converts the map[interface{}]interface{} values produced by the
+// YAML parser into map[string]interface{} so that they can be encoded as JSON.
+func sanitize(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		sanitized := map[string]interface{}{}
+		for key, val := range v {
+			str, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("non-string key: %v", key)
+			}
+
+			sub, err := sanitize(val)
+			if err != nil {
+				return nil, err
+			}
+
+			sanitized[str] = sub
+		}
+
+		return sanitized, nil
+
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+		for i, val := range v {
+			sub, err := sanitize(val)
+			if err != nil {
+				return nil, err
+			}
+
+			sanitized[i] = sub
+		}
+
+		return sanitized, nil
+
+	default:
+		return value, nil
+	}
+}
+
+var placeholderRegex = regexp.MustCompile(`"?\{\{([-\w\p{L}]+)\}\}"?`)
+
+// Evaluate substitutes every {{name}} placeholder in the template with the
+// JSON encoding of the variable's value, so that strings, numbers, lists and
+// maps all survive being parsed as YAML afterwards. Surrounding quotes are
+// consumed along with the placeholder.
+//
+// Placeholders for unset variables are replaced with null.
+func Evaluate(content []byte, variables Variables) ([]byte, error) {
+	var evaluateErr error
+
+	evaluated := placeholderRegex.ReplaceAllFunc(content, func(match []byte) []byte {
+		name := string(placeholderRegex.FindSubmatch(match)[1])
+
+		value, found := variables[name]
+		if !found {
+			return []byte("null")
+		}
+
+		payload, err := json.Marshal(value)
+		if err != nil {
+			evaluateErr = err
+			return match
+		}
+
+		return payload
+	})
+
+	if evaluateErr != nil {
+		return nil, evaluateErr
+	}
+
+	return evaluated, nil
+}
+
+// RenderJSON evaluates the template and converts the resulting YAML document
+// to JSON.
+func RenderJSON(content []byte, variables Variables) ([]byte, error) {
+	evaluated, err := Evaluate(content, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	err = yaml.Unmarshal(evaluated, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitized, err := sanitize(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(sanitized)
+}
+
+// Placeholders returns the sorted, de-duplicated names of the variables
+// referenced by the template.
+func Placeholders(content []byte) []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, match := range placeholderRegex.FindAllSubmatch(content, -1) {
+		name := string(match[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Missing returns the names of the variables referenced by the template that
+// have no value.
+func Missing(content []byte, variables Variables) []string {
+	missing := []string{}
+
+	for _, name := range Placeholders(content) {
+		if _, found := variables[name]; !found {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// Unused returns the names of the variables which are not referenced by the
+// template.
+func Unused(content []byte, variables Variables) []string {
+	referenced := map[string]bool{}
+	for _, name := range Placeholders(content) {
+		referenced[name] = true
+	}
+
+	unused := []string{}
+	for name := range variables {
+		if !referenced[name] {
+			unused = append(unused, name)
+		}
+	}
+
+	sort.Strings(unused)
+
+	return unused
+}
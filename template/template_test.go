@@ -0,0 +1,107 @@
+package template_test
+
+import (
+	"github.com/concourse/atc/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template", func() {
+	Describe("Evaluate", func() {
+		It("substitutes variables as JSON", func() {
+			evaluated, err := template.Evaluate([]byte(`
+uri: {{uri}}
+branch: "{{branch}}"
+paths: {{paths}}
+retries: {{retries}}
+`), template.Variables{
+				"uri":     "git@example.com:some/repo.git",
+				"branch":  "master",
+				"paths":   []interface{}{"a", "b"},
+				"retries": 3,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(evaluated)).To(Equal(`
+uri: "git@example.com:some/repo.git"
+branch: "master"
+paths: ["a","b"]
+retries: 3
+`))
+		})
+
+		It("replaces unset variables with null", func() {
+			evaluated, err := template.Evaluate([]byte(`uri: {{uri}}`), template.Variables{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(evaluated)).To(Equal(`uri: null`))
+		})
+
+		It("leaves the content alone when there are no placeholders", func() {
+			evaluated, err := template.Evaluate([]byte(`uri: some-uri`), template.Variables{"uri": "nope"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(evaluated)).To(Equal(`uri: some-uri`))
+		})
+	})
+
+	Describe("RenderJSON", func() {
+		It("evaluates the template and converts it to JSON", func() {
+			rendered, err := template.RenderJSON([]byte(`
+resources:
+- name: some-resource
+  source: {{source}}
+`), template.Variables{
+				"source": map[string]interface{}{"uri": "some-uri"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rendered).To(MatchJSON(`{
+				"resources": [{
+					"name": "some-resource",
+					"source": {"uri": "some-uri"}
+				}]
+			}`))
+		})
+	})
+
+	Describe("ParseVariables", func() {
+		It("parses nested YAML into JSON-friendly values", func() {
+			variables, err := template.ParseVariables([]byte(`
+uri: some-uri
+source:
+  private_key: some-key
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(variables).To(Equal(template.Variables{
+				"uri": "some-uri",
+				"source": map[string]interface{}{
+					"private_key": "some-key",
+				},
+			}))
+		})
+
+		It("fails on malformed documents", func() {
+			_, err := template.ParseVariables([]byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Missing and Unused", func() {
+		var content []byte
+		var variables template.Variables
+
+		BeforeEach(func() {
+			content = []byte(`{{a}} {{b}} {{a}}`)
+			variables = template.Variables{"b": "set", "c": "extra"}
+		})
+
+		It("reports referenced variables without values", func() {
+			Expect(template.Missing(content, variables)).To(Equal([]string{"a"}))
+		})
+
+		It("reports variables that are never referenced", func() {
+			Expect(template.Unused(content, variables)).To(Equal([]string{"c"}))
+		})
+	})
+})
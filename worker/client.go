@@ -150,6 +150,30 @@ func (strategy HostRootFSStrategy) dbIdentifier() db.VolumeIdentifier {
 	}
 }
 
+type TaskCacheStrategy struct {
+	WorkerName string
+	PipelineID int
+	JobName    string
+	StepName   string
+	Path       string
+}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
+func (strategy TaskCacheStrategy) dbIdentifier() db.VolumeIdentifier {
+	return db.VolumeIdentifier{
+		TaskCache: &db.TaskCacheIdentifier{
+			WorkerName: strategy.WorkerName,
+			PipelineID: strategy.PipelineID,
+			JobName:    strategy.JobName,
+			StepName:   strategy.StepName,
+			Path:       strategy.Path,
+		},
+	}
+}
+
 //go:generate counterfeiter . Container
 
 type Container interface {
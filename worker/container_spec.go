@@ -31,6 +31,9 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional CPU and memory limits. Zero values are unlimited.
+	Limits atc.ContainerLimits
 }
 
 type ImageSpec struct {
@@ -68,10 +68,14 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(savedWorkers))
+	workers := []Worker{}
 
-	for i, savedWorker := range savedWorkers {
-		workers[i] = provider.newGardenWorker(tikTok, savedWorker)
+	for _, savedWorker := range savedWorkers {
+		if !reachable(savedWorker) {
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -83,7 +87,7 @@ func (provider *dbProvider) GetWorker(name string) (Worker, bool, error) {
 		return nil, false, err
 	}
 
-	if !found {
+	if !found || !reachable(savedWorker) {
 		return nil, false, nil
 	}
 
@@ -106,6 +110,13 @@ func (provider *dbProvider) ReapContainer(handle string) error {
 	return provider.db.ReapContainer(handle)
 }
 
+// stalled and landed workers are kept for operators to see, but can't be
+// talked to
+func reachable(savedWorker db.SavedWorker) bool {
+	return savedWorker.State != db.WorkerStateStalled &&
+		savedWorker.State != db.WorkerStateLanded
+}
+
 func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.SavedWorker) Worker {
 	gcf := NewGardenConnectionFactory(
 		provider.db,
@@ -151,6 +162,7 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker db.S
 		savedWorker.TeamID,
 		savedWorker.Name,
 		savedWorker.StartTime,
+		savedWorker.State,
 		savedWorker.HTTPProxyURL,
 		savedWorker.HTTPSProxyURL,
 		savedWorker.NoProxy,
@@ -142,6 +142,35 @@ var _ = Describe("DBProvider", func() {
 				Expect(workers).To(HaveLen(2))
 			})
 
+			Context("when some of the workers are stalled or landed", func() {
+				BeforeEach(func() {
+					fakeDB.WorkersReturns([]db.SavedWorker{
+						{
+							WorkerInfo: db.WorkerInfo{Name: "running-worker", GardenAddr: gardenAddr},
+							State:      db.WorkerStateRunning,
+						},
+						{
+							WorkerInfo: db.WorkerInfo{Name: "landing-worker", GardenAddr: gardenAddr},
+							State:      db.WorkerStateLanding,
+						},
+						{
+							WorkerInfo: db.WorkerInfo{Name: "stalled-worker", GardenAddr: gardenAddr},
+							State:      db.WorkerStateStalled,
+						},
+						{
+							WorkerInfo: db.WorkerInfo{Name: "landed-worker", GardenAddr: gardenAddr},
+							State:      db.WorkerStateLanded,
+						},
+					}, nil)
+				})
+
+				It("returns only the workers that can be reached", func() {
+					Expect(workers).To(HaveLen(2))
+					Expect(workers[0].Name()).To(Equal("running-worker"))
+					Expect(workers[1].Name()).To(Equal("landing-worker"))
+				})
+			})
+
 			Context("creating the connection to garden", func() {
 				var id Identifier
 				var spec ContainerSpec
@@ -325,6 +354,22 @@ var _ = Describe("DBProvider", func() {
 				Expect(worker.IsOwnedByTeam()).To(BeTrue())
 			})
 		})
+
+		Context("when we find a stalled worker", func() {
+			It("returns found as false", func() {
+				fakeDB.GetWorkerReturns(db.SavedWorker{
+					WorkerInfo: db.WorkerInfo{
+						Name: "some-worker",
+					},
+					State: db.WorkerStateStalled,
+				}, true, nil)
+
+				worker, found, workersErr = provider.GetWorker("some-worker")
+				Expect(workersErr).NotTo(HaveOccurred())
+				Expect(worker).To(BeNil())
+				Expect(found).To(BeFalse())
+			})
+		})
 	})
 
 	Context("when we call to get a container info by identifier", func() {
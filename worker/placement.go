@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+//go:generate counterfeiter . InputSource
+
+// InputSource is an artifact which will be provided to a container, either by
+// mounting a volume that already lives on the chosen worker or by streaming
+// it in.
+type InputSource interface {
+	VolumeOn(Worker) (Volume, bool, error)
+}
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// ContainerPlacementStrategy picks the worker on which a container will be
+// created, given the workers that satisfy its spec and the inputs it will be
+// given.
+type ContainerPlacementStrategy interface {
+	Choose([]Worker, []InputSource) (Worker, error)
+}
+
+const (
+	RandomPlacement                 = "random"
+	FewestActiveContainersPlacement = "fewest-active-containers"
+	VolumeLocalityPlacement         = "volume-locality"
+)
+
+func NewContainerPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case RandomPlacement:
+		return NewRandomPlacementStrategy(), nil
+	case FewestActiveContainersPlacement:
+		return NewFewestActiveContainersPlacementStrategy(), nil
+	case VolumeLocalityPlacement:
+		return NewVolumeLocalityPlacementStrategy(), nil
+	default:
+		return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+	}
+}
+
+type randomPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(workers []Worker, inputs []InputSource) (Worker, error) {
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	return workers[strategy.rand.Intn(len(workers))], nil
+}
+
+type fewestActiveContainersPlacementStrategy struct {
+	random ContainerPlacementStrategy
+}
+
+func NewFewestActiveContainersPlacementStrategy() ContainerPlacementStrategy {
+	return &fewestActiveContainersPlacementStrategy{
+		random: NewRandomPlacementStrategy(),
+	}
+}
+
+func (strategy *fewestActiveContainersPlacementStrategy) Choose(workers []Worker, inputs []InputSource) (Worker, error) {
+	var leastBusy []Worker
+
+	for _, w := range workers {
+		if len(leastBusy) == 0 || w.ActiveContainers() < leastBusy[0].ActiveContainers() {
+			leastBusy = []Worker{w}
+		} else if w.ActiveContainers() == leastBusy[0].ActiveContainers() {
+			leastBusy = append(leastBusy, w)
+		}
+	}
+
+	return strategy.random.Choose(leastBusy, inputs)
+}
+
+type volumeLocalityPlacementStrategy struct {
+	random ContainerPlacementStrategy
+}
+
+// NewVolumeLocalityPlacementStrategy returns a strategy which prefers the
+// workers that already have volumes for the most inputs, so that as little as
+// possible has to be streamed between workers.
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+	return &volumeLocalityPlacementStrategy{
+		random: NewRandomPlacementStrategy(),
+	}
+}
+
+func (strategy *volumeLocalityPlacementStrategy) Choose(workers []Worker, inputs []InputSource) (Worker, error) {
+	var mostLocal []Worker
+	mostVolumes := -1
+
+	for _, w := range workers {
+		volumes := 0
+
+		for _, input := range inputs {
+			volume, found, err := input.VolumeOn(w)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				// only counting; the caller will look it up again and hold on to it
+				volume.Release(nil)
+				volumes++
+			}
+		}
+
+		if volumes > mostVolumes {
+			mostLocal = []Worker{w}
+			mostVolumes = volumes
+		} else if volumes == mostVolumes {
+			mostLocal = append(mostLocal, w)
+		}
+	}
+
+	return strategy.random.Choose(mostLocal, inputs)
+}
@@ -0,0 +1,150 @@
+package worker_test
+
+import (
+	"errors"
+
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+
+		workers []Worker
+		inputs  []InputSource
+
+		strategy ContainerPlacementStrategy
+
+		chosenWorker Worker
+		chooseErr    error
+	)
+
+	BeforeEach(func() {
+		workerA = new(workerfakes.FakeWorker)
+		workerB = new(workerfakes.FakeWorker)
+		workerC = new(workerfakes.FakeWorker)
+
+		workers = []Worker{workerA, workerB, workerC}
+		inputs = nil
+	})
+
+	JustBeforeEach(func() {
+		chosenWorker, chooseErr = strategy.Choose(workers, inputs)
+	})
+
+	Describe("NewContainerPlacementStrategy", func() {
+		It("returns an error for an unknown strategy", func() {
+			_, err := NewContainerPlacementStrategy("bogus")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("knows about every built-in strategy", func() {
+			for _, name := range []string{RandomPlacement, FewestActiveContainersPlacement, VolumeLocalityPlacement} {
+				_, err := NewContainerPlacementStrategy(name)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			strategy = NewRandomPlacementStrategy()
+		})
+
+		It("picks one of the workers", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(workers).To(ContainElement(chosenWorker))
+		})
+
+		Context("with no workers", func() {
+			BeforeEach(func() {
+				workers = nil
+			})
+
+			It("returns ErrNoWorkers", func() {
+				Expect(chooseErr).To(Equal(ErrNoWorkers))
+			})
+		})
+	})
+
+	Describe("fewest active containers", func() {
+		BeforeEach(func() {
+			strategy = NewFewestActiveContainersPlacementStrategy()
+
+			workerA.ActiveContainersReturns(3)
+			workerB.ActiveContainersReturns(1)
+			workerC.ActiveContainersReturns(2)
+		})
+
+		It("picks the least busy worker", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerB))
+		})
+
+		Context("when workers are tied", func() {
+			BeforeEach(func() {
+				workerC.ActiveContainersReturns(1)
+			})
+
+			It("picks one of them", func() {
+				Expect(chooseErr).NotTo(HaveOccurred())
+				Expect([]Worker{workerB, workerC}).To(ContainElement(chosenWorker))
+			})
+		})
+	})
+
+	Describe("volume locality", func() {
+		var (
+			inputA *workerfakes.FakeInputSource
+			inputB *workerfakes.FakeInputSource
+
+			volume *workerfakes.FakeVolume
+		)
+
+		BeforeEach(func() {
+			strategy = NewVolumeLocalityPlacementStrategy()
+
+			volume = new(workerfakes.FakeVolume)
+
+			inputA = new(workerfakes.FakeInputSource)
+			inputB = new(workerfakes.FakeInputSource)
+
+			inputA.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				return volume, w == workerB || w == workerC, nil
+			}
+
+			inputB.VolumeOnStub = func(w Worker) (Volume, bool, error) {
+				return volume, w == workerC, nil
+			}
+
+			inputs = []InputSource{inputA, inputB}
+		})
+
+		It("picks the worker with the most input volumes", func() {
+			Expect(chooseErr).NotTo(HaveOccurred())
+			Expect(chosenWorker).To(Equal(workerC))
+		})
+
+		It("releases the volumes it found", func() {
+			Expect(volume.ReleaseCallCount()).To(Equal(3))
+		})
+
+		Context("when looking up a volume fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				inputB.VolumeOnReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(chooseErr).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -47,14 +46,13 @@ func (err NoCompatibleWorkersError) Error() string {
 
 type pool struct {
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
 	}
 }
 
@@ -126,8 +124,8 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	return pool.strategy.Choose(compatibleWorkers, nil)
 }
 
 func (pool *pool) CreateContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes) (Container, error) {
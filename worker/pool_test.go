@@ -25,7 +25,7 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(fakeProvider, NewRandomPlacementStrategy())
 	})
 
 	Describe("GetWorker", func() {
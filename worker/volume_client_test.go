@@ -596,6 +596,44 @@ var _ = Describe("VolumeClient", func() {
 				})
 			})
 
+			Context("when creating a TaskCacheStrategy volume", func() {
+				BeforeEach(func() {
+					volumeSpec.Strategy = worker.TaskCacheStrategy{
+						WorkerName: workerName,
+						PipelineID: 42,
+						JobName:    "some-job",
+						StepName:   "some-task",
+						Path:       "some/cache",
+					}
+				})
+
+				It("succeeds", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+				})
+
+				It("creates an empty volume via BaggageClaim", func() {
+					Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
+
+					_, spec := fakeBaggageclaimClient.CreateVolumeArgsForCall(0)
+					Expect(spec.Strategy).To(Equal(baggageclaim.EmptyStrategy{}))
+				})
+
+				It("inserts the volume into the database", func() {
+					Expect(fakeGardenWorkerDB.InsertVolumeCallCount()).To(Equal(1))
+
+					dbVolume := fakeGardenWorkerDB.InsertVolumeArgsForCall(0)
+					Expect(dbVolume.Identifier).To(Equal(db.VolumeIdentifier{
+						TaskCache: &db.TaskCacheIdentifier{
+							WorkerName: workerName,
+							PipelineID: 42,
+							JobName:    "some-job",
+							StepName:   "some-task",
+							Path:       "some/cache",
+						},
+					}))
+				})
+			})
+
 			Context("when creating an HostRootFSStrategy volume", func() {
 				BeforeEach(func() {
 					volumeSpec.Strategy = worker.HostRootFSStrategy{
@@ -24,6 +24,7 @@ var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
 var ErrNoVolumeManager = errors.New("worker does not support volume management")
 var ErrTeamMismatch = errors.New("mismatched team")
+var ErrWorkerNotRunning = errors.New("worker is not running")
 
 type MalformedMetadataError struct {
 	UnmarshalError error
@@ -91,6 +92,7 @@ type gardenWorker struct {
 	teamID           int
 	name             string
 	startTime        int64
+	state            db.WorkerState
 	httpProxyURL     string
 	httpsProxyURL    string
 	noProxy          string
@@ -113,6 +115,7 @@ func NewGardenWorker(
 	teamID int,
 	name string,
 	startTime int64,
+	state db.WorkerState,
 	httpProxyURL string,
 	httpsProxyURL string,
 	noProxy string,
@@ -134,6 +137,7 @@ func NewGardenWorker(
 		teamID:             teamID,
 		name:               name,
 		startTime:          startTime,
+		state:              state,
 		httpProxyURL:       httpProxyURL,
 		httpsProxyURL:      httpsProxyURL,
 		noProxy:            noProxy,
@@ -461,6 +465,10 @@ func (worker *gardenWorker) CreateContainer(
 		Properties: gardenProperties,
 		RootFSPath: imageURL,
 		Env:        env,
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: uint64(spec.Limits.CPU)},
+			Memory: garden.MemoryLimits{LimitInBytes: uint64(spec.Limits.Memory)},
+		},
 	}
 
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
@@ -598,6 +606,11 @@ func (worker *gardenWorker) ActiveContainers() int {
 }
 
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
+	// landing and retiring workers finish their builds, but take no new ones
+	if worker.state == db.WorkerStateLanding || worker.state == db.WorkerStateRetiring {
+		return nil, ErrWorkerNotRunning
+	}
+
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
 	}
@@ -667,6 +680,10 @@ func (worker *gardenWorker) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if worker.state == db.WorkerStateLanding || worker.state == db.WorkerStateRetiring {
+		messages = append(messages, fmt.Sprintf("state '%s'", worker.state))
+	}
+
 	return strings.Join(messages, ", ")
 }
 
@@ -45,6 +45,7 @@ var _ = Describe("Worker", func() {
 		teamID                 int
 		workerName             string
 		workerStartTime        int64
+		workerState            db.WorkerState
 		httpProxyURL           string
 		httpsProxyURL          string
 		noProxy                string
@@ -80,6 +81,7 @@ var _ = Describe("Worker", func() {
 		teamID = 17
 		workerName = "some-worker"
 		workerStartTime = fakeClock.Now().Unix()
+		workerState = db.WorkerStateRunning
 		workerUptime = 0
 	})
 
@@ -101,6 +103,7 @@ var _ = Describe("Worker", func() {
 			teamID,
 			workerName,
 			workerStartTime,
+			workerState,
 			httpProxyURL,
 			httpsProxyURL,
 			noProxy,
@@ -219,6 +222,22 @@ var _ = Describe("Worker", func() {
 			Expect(volumeHandles).To(BeEmpty())
 		})
 
+		Context("when the spec specifies limits", func() {
+			BeforeEach(func() {
+				containerSpec.Limits = atc.ContainerLimits{CPU: 512, Memory: 1073741824}
+			})
+
+			It("tries to create a container in garden with the limits", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+				actualGardenSpec := fakeGardenClient.CreateArgsForCall(0)
+				Expect(actualGardenSpec.Limits).To(Equal(garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1073741824},
+				}))
+			})
+		})
+
 		Context("when the spec does not specify ImageURL", func() {
 			BeforeEach(func() {
 				containerSpec.ImageSpec.ImageURL = ""
@@ -1338,6 +1357,7 @@ var _ = Describe("Worker", func() {
 								teamID,
 								workerName,
 								workerStartTime,
+								workerState,
 								httpProxyURL,
 								httpsProxyURL,
 								noProxy,
@@ -1402,6 +1422,7 @@ var _ = Describe("Worker", func() {
 								teamID,
 								workerName,
 								workerStartTime,
+								workerState,
 								httpProxyURL,
 								httpsProxyURL,
 								noProxy,
@@ -2023,6 +2044,26 @@ var _ = Describe("Worker", func() {
 			satisfyingWorker, satisfyingErr = gardenWorker.Satisfying(spec, customTypes)
 		})
 
+		Context("when the worker is landing", func() {
+			BeforeEach(func() {
+				workerState = db.WorkerStateLanding
+			})
+
+			It("returns ErrWorkerNotRunning", func() {
+				Expect(satisfyingErr).To(Equal(ErrWorkerNotRunning))
+			})
+		})
+
+		Context("when the worker is retiring", func() {
+			BeforeEach(func() {
+				workerState = db.WorkerStateRetiring
+			})
+
+			It("returns ErrWorkerNotRunning", func() {
+				Expect(satisfyingErr).To(Equal(ErrWorkerNotRunning))
+			})
+		})
+
 		Context("when the platform is compatible", func() {
 			BeforeEach(func() {
 				spec.Platform = "some-platform"
@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeInputSource struct {
+	VolumeOnStub        func(worker.Worker) (worker.Volume, bool, error)
+	volumeOnMutex       sync.RWMutex
+	volumeOnArgsForCall []struct {
+		arg1 worker.Worker
+	}
+	volumeOnReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeInputSource) VolumeOn(arg1 worker.Worker) (worker.Volume, bool, error) {
+	fake.volumeOnMutex.Lock()
+	fake.volumeOnArgsForCall = append(fake.volumeOnArgsForCall, struct {
+		arg1 worker.Worker
+	}{arg1})
+	fake.recordInvocation("VolumeOn", []interface{}{arg1})
+	fake.volumeOnMutex.Unlock()
+	if fake.VolumeOnStub != nil {
+		return fake.VolumeOnStub(arg1)
+	} else {
+		return fake.volumeOnReturns.result1, fake.volumeOnReturns.result2, fake.volumeOnReturns.result3
+	}
+}
+
+func (fake *FakeInputSource) VolumeOnCallCount() int {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return len(fake.volumeOnArgsForCall)
+}
+
+func (fake *FakeInputSource) VolumeOnArgsForCall(i int) worker.Worker {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return fake.volumeOnArgsForCall[i].arg1
+}
+
+func (fake *FakeInputSource) VolumeOnReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.VolumeOnStub = nil
+	fake.volumeOnReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeInputSource) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeInputSource) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.InputSource = new(FakeInputSource)
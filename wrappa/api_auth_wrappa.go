@@ -2,6 +2,7 @@ package wrappa
 
 import (
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
 	"github.com/concourse/atc/auth"
 	"github.com/tedsuo/rata"
 )
@@ -13,6 +14,7 @@ type APIAuthWrappa struct {
 	checkPipelineAccessHandlerFactory   auth.CheckPipelineAccessHandlerFactory
 	checkBuildReadAccessHandlerFactory  auth.CheckBuildReadAccessHandlerFactory
 	checkBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory
+	auditHandlerFactory                 audit.HandlerFactory
 }
 
 func NewAPIAuthWrappa(
@@ -22,6 +24,7 @@ func NewAPIAuthWrappa(
 	checkPipelineAccessHandlerFactory auth.CheckPipelineAccessHandlerFactory,
 	checkBuildReadAccessHandlerFactory auth.CheckBuildReadAccessHandlerFactory,
 	checkBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory,
+	auditHandlerFactory audit.HandlerFactory,
 ) *APIAuthWrappa {
 	return &APIAuthWrappa{
 		authValidator:                       authValidator,
@@ -30,6 +33,7 @@ func NewAPIAuthWrappa(
 		checkPipelineAccessHandlerFactory:   checkPipelineAccessHandlerFactory,
 		checkBuildReadAccessHandlerFactory:  checkBuildReadAccessHandlerFactory,
 		checkBuildWriteAccessHandlerFactory: checkBuildWriteAccessHandlerFactory,
+		auditHandlerFactory:                 auditHandlerFactory,
 	}
 }
 
@@ -38,6 +42,25 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 	rejector := auth.UnauthorizedRejector{}
 
+	// not audited even though they aren't GETs: workers register themselves
+	// every few seconds as a heartbeat, diffing a config changes nothing, and
+	// pipes stream the inputs and outputs of one-off builds, all of which
+	// would drown out everything else
+	unauditedRoutes := map[string]bool{
+		atc.RegisterWorker: true,
+		atc.DiffConfig:     true,
+		atc.CreatePipe:     true,
+		atc.WritePipe:      true,
+		atc.ReadPipe:       true,
+	}
+
+	mutatingRoutes := map[string]bool{}
+	for _, route := range atc.Routes {
+		if route.Method != "GET" && !unauditedRoutes[route.Name] {
+			mutatingRoutes[route.Name] = true
+		}
+	}
+
 	for name, handler := range handlers {
 		newHandler := handler
 
@@ -50,7 +73,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllPipelines,
 			atc.ListPipelines,
 			atc.ListBuilds,
-			atc.MainJobBadge:
+			atc.MainJobBadge,
+			atc.CheckResourceWebHook:
 
 		// pipeline is public or authorized
 		case atc.GetBuild,
@@ -63,12 +87,18 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.BuildEvents:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
-		// resource belongs to authorized team
-		case atc.AbortBuild:
-			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
+		// resource belongs to authorized team, and role may operate it
+		case atc.AbortBuild,
+			atc.RerunBuild:
+			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(auth.CheckRoleHandler(handler, atc.TeamRoleOperator, rejector), rejector)
+
+		// resource belongs to authorized team, and role may sign off on it
+		case atc.DecideGate:
+			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(auth.CheckRoleHandler(handler, atc.TeamRoleMember, rejector), rejector)
 
 		// pipeline is public or authorized
 		case atc.GetPipeline,
+			atc.GetPipelineGraph,
 			atc.GetJobBuild,
 			atc.JobBadge,
 			atc.ListJobs,
@@ -83,52 +113,80 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// authenticated
 		case atc.GetAuthToken,
-			atc.CreateBuild,
-			atc.CreatePipe,
 			atc.GetContainer,
-			atc.HijackContainer,
 			atc.ListContainers,
 			atc.ListWorkers,
-			atc.ReadPipe,
-			atc.RegisterWorker,
-			atc.SetTeam,
-			atc.DestroyTeam,
-			atc.WritePipe,
 			atc.ListVolumes,
 			atc.GetUser:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
+		// authenticated, and role may run arbitrary code
+		case atc.CreateBuild,
+			atc.CreatePipe,
+			atc.HijackContainer,
+			atc.ReadPipe,
+			atc.RegisterWorker,
+			atc.WritePipe:
+			newHandler = auth.CheckAuthenticationHandler(auth.CheckRoleHandler(handler, atc.TeamRoleMember, rejector), rejector)
+
+		// authenticated, and role may configure the team
+		case atc.SetTeam,
+			atc.DestroyTeam:
+			newHandler = auth.CheckAuthenticationHandler(auth.CheckRoleHandler(handler, atc.TeamRoleOwner, rejector), rejector)
+
 		case atc.GetLogLevel,
-			atc.SetLogLevel:
+			atc.SetLogLevel,
+			atc.LandWorker,
+			atc.RetireWorker,
+			atc.PruneWorker:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
 
 		// authorized (requested team matches resource team)
+		case atc.GetConfig,
+			atc.DiffConfig,
+			atc.GetConfigVersions,
+			atc.GetConfigVersion,
+			atc.GetVersionsDB,
+			atc.ListAuditEvents,
+			atc.ListJobInputs:
+			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
+
+		// authorized, and role may operate pipelines
 		case atc.CheckResource,
 			atc.CreateJobBuild,
-			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
-			atc.GetConfig,
-			atc.GetVersionsDB,
-			atc.ListJobInputs,
-			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
 			atc.PauseResource,
-			atc.RenamePipeline,
+			atc.PinResourceVersion,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
 			atc.UnpauseResource,
+			atc.UnpinResourceVersion:
+			newHandler = auth.CheckAuthorizationHandler(auth.CheckRoleHandler(handler, atc.TeamRoleOperator, rejector), rejector)
+
+		// authorized, and role may configure pipelines
+		case atc.DeletePipeline,
+			atc.OrderPipelines,
+			atc.RenamePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
+			atc.RollbackConfig,
 			atc.SaveConfig:
-			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
+			newHandler = auth.CheckAuthorizationHandler(auth.CheckRoleHandler(handler, atc.TeamRoleMember, rejector), rejector)
 
 		// think about it!
 		default:
 			panic("you missed a spot")
 		}
 
+		// audited within the auth context so that the actor is known, and
+		// outside of the checks so that rejected attempts are recorded too
+		if mutatingRoutes[name] {
+			newHandler = wrappa.auditHandlerFactory.HandlerFor(name, newHandler)
+		}
+
 		if name == atc.GetAuthToken {
 			newHandler = auth.WrapHandler(newHandler, wrappa.getTokenValidator, wrappa.userContextReader)
 		} else {
@@ -3,7 +3,10 @@ package wrappa_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db/dbfakes"
@@ -22,6 +25,7 @@ var _ = Describe("APIAuthWrappa", func() {
 		fakeCheckPipelineAccessHandlerFactory   auth.CheckPipelineAccessHandlerFactory
 		fakeCheckBuildReadAccessHandlerFactory  auth.CheckBuildReadAccessHandlerFactory
 		fakeCheckBuildWriteAccessHandlerFactory auth.CheckBuildWriteAccessHandlerFactory
+		auditHandlerFactory                     audit.HandlerFactory
 	)
 
 	BeforeEach(func() {
@@ -38,101 +42,72 @@ var _ = Describe("APIAuthWrappa", func() {
 		buildsDB := new(authfakes.FakeBuildsDB)
 		fakeCheckBuildReadAccessHandlerFactory = auth.NewCheckBuildReadAccessHandlerFactory(buildsDB)
 		fakeCheckBuildWriteAccessHandlerFactory = auth.NewCheckBuildWriteAccessHandlerFactory(buildsDB)
+
+		auditHandlerFactory = audit.NewHandlerFactory(lagertest.NewTestLogger("test"), new(auditfakes.FakeAuditDB))
 	})
 
 	unauthenticated := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			handler,
-			fakeAuthValidator,
-			fakeUserContextReader,
-		)
+		return handler
 	}
 
 	authenticated := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			auth.CheckAuthenticationHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return auth.CheckAuthenticationHandler(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
-	authenticatedAndAdmin := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			auth.CheckAdminHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
-		)
+	authenticatedWithRole := func(role atc.TeamRole) func(http.Handler) http.Handler {
+		return func(handler http.Handler) http.Handler {
+			return authenticated(auth.CheckRoleHandler(handler, role, auth.UnauthorizedRejector{}))
+		}
 	}
 
-	authenticatedWithGetTokenValidator := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			auth.CheckAuthenticationHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeGetTokenValidator,
-			fakeUserContextReader,
+	authenticatedAndAdmin := func(handler http.Handler) http.Handler {
+		return auth.CheckAdminHandler(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
 	authorized := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			auth.CheckAuthorizationHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return auth.CheckAuthorizationHandler(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
+	authorizedWithRole := func(role atc.TeamRole) func(http.Handler) http.Handler {
+		return func(handler http.Handler) http.Handler {
+			return authorized(auth.CheckRoleHandler(handler, role, auth.UnauthorizedRejector{}))
+		}
+	}
+
 	openForPublicPipelineOrAuthorized := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			fakeCheckPipelineAccessHandlerFactory.HandlerFor(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return fakeCheckPipelineAccessHandlerFactory.HandlerFor(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
 	doesNotCheckIfPrivateJob := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			fakeCheckBuildReadAccessHandlerFactory.AnyJobHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return fakeCheckBuildReadAccessHandlerFactory.AnyJobHandler(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
 	checksIfPrivateJob := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			fakeCheckBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return fakeCheckBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
 	checkWritePermissionForBuild := func(handler http.Handler) http.Handler {
-		return auth.WrapHandler(
-			fakeCheckBuildWriteAccessHandlerFactory.HandlerFor(
-				handler,
-				auth.UnauthorizedRejector{},
-			),
-			fakeAuthValidator,
-			fakeUserContextReader,
+		return fakeCheckBuildWriteAccessHandlerFactory.HandlerFor(
+			handler,
+			auth.UnauthorizedRejector{},
 		)
 	}
 
@@ -153,14 +128,15 @@ var _ = Describe("APIAuthWrappa", func() {
 
 			expectedHandlers = rata.Handlers{
 				// unauthenticated / delegating to handler
-				atc.GetInfo:          unauthenticated(inputHandlers[atc.GetInfo]),
-				atc.DownloadCLI:      unauthenticated(inputHandlers[atc.DownloadCLI]),
-				atc.ListAuthMethods:  unauthenticated(inputHandlers[atc.ListAuthMethods]),
-				atc.ListAllPipelines: unauthenticated(inputHandlers[atc.ListAllPipelines]),
-				atc.ListBuilds:       unauthenticated(inputHandlers[atc.ListBuilds]),
-				atc.ListPipelines:    unauthenticated(inputHandlers[atc.ListPipelines]),
-				atc.ListTeams:        unauthenticated(inputHandlers[atc.ListTeams]),
-				atc.MainJobBadge:     unauthenticated(inputHandlers[atc.MainJobBadge]),
+				atc.GetInfo:              unauthenticated(inputHandlers[atc.GetInfo]),
+				atc.DownloadCLI:          unauthenticated(inputHandlers[atc.DownloadCLI]),
+				atc.ListAuthMethods:      unauthenticated(inputHandlers[atc.ListAuthMethods]),
+				atc.ListAllPipelines:     unauthenticated(inputHandlers[atc.ListAllPipelines]),
+				atc.ListBuilds:           unauthenticated(inputHandlers[atc.ListBuilds]),
+				atc.ListPipelines:        unauthenticated(inputHandlers[atc.ListPipelines]),
+				atc.ListTeams:            unauthenticated(inputHandlers[atc.ListTeams]),
+				atc.MainJobBadge:         unauthenticated(inputHandlers[atc.MainJobBadge]),
+				atc.CheckResourceWebHook: unauthenticated(inputHandlers[atc.CheckResourceWebHook]),
 
 				// authorized or public pipeline
 				atc.GetBuild:       doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuild]),
@@ -172,10 +148,13 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild: checkWritePermissionForBuild(auth.CheckRoleHandler(inputHandlers[atc.AbortBuild], atc.TeamRoleOperator, auth.UnauthorizedRejector{})),
+				atc.RerunBuild: checkWritePermissionForBuild(auth.CheckRoleHandler(inputHandlers[atc.RerunBuild], atc.TeamRoleOperator, auth.UnauthorizedRejector{})),
+				atc.DecideGate: checkWritePermissionForBuild(auth.CheckRoleHandler(inputHandlers[atc.DecideGate], atc.TeamRoleMember, auth.UnauthorizedRejector{})),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
+				atc.GetPipelineGraph:              openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipelineGraph]),
 				atc.GetJobBuild:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobBuild]),
 				atc.JobBadge:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.JobBadge]),
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
@@ -188,46 +167,79 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListResourceVersions:          openForPublicPipelineOrAuthorized(inputHandlers[atc.ListResourceVersions]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.CreatePipe:      authenticated(inputHandlers[atc.CreatePipe]),
-				atc.GetAuthToken:    authenticatedWithGetTokenValidator(inputHandlers[atc.GetAuthToken]),
+				atc.CreateBuild:     authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.CreateBuild]),
+				atc.CreatePipe:      authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.CreatePipe]),
+				atc.GetAuthToken:    authenticated(inputHandlers[atc.GetAuthToken]),
 				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
+				atc.HijackContainer: authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.ReadPipe:        authenticated(inputHandlers[atc.ReadPipe]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.ReadPipe:        authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.ReadPipe]),
+				atc.RegisterWorker:  authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.RegisterWorker]),
 
-				atc.SetTeam:     authenticated(inputHandlers[atc.SetTeam]),
-				atc.DestroyTeam: authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
+				atc.SetTeam:     authenticatedWithRole(atc.TeamRoleOwner)(inputHandlers[atc.SetTeam]),
+				atc.DestroyTeam: authenticatedWithRole(atc.TeamRoleOwner)(inputHandlers[atc.DestroyTeam]),
+				atc.WritePipe:   authenticatedWithRole(atc.TeamRoleMember)(inputHandlers[atc.WritePipe]),
 				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 
+				atc.LandWorker:   authenticatedAndAdmin(inputHandlers[atc.LandWorker]),
+				atc.RetireWorker: authenticatedAndAdmin(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:  authenticatedAndAdmin(inputHandlers[atc.PruneWorker]),
+
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.CheckResource:          authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:         authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:         authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion: authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:  authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:     authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResourceVersion:   authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.UnpinResourceVersion]),
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
+				atc.DiffConfig:             authorized(inputHandlers[atc.DiffConfig]),
+				atc.GetConfigVersions:      authorized(inputHandlers[atc.GetConfigVersions]),
+				atc.GetConfigVersion:       authorized(inputHandlers[atc.GetConfigVersion]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListAuditEvents:        authorized(inputHandlers[atc.ListAuditEvents]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.OrderPipelines:         authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:               authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:          authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:          authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.PauseResource]),
+				atc.RenamePipeline:         authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.RenamePipeline]),
+				atc.RollbackConfig:         authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.RollbackConfig]),
+				atc.SaveConfig:             authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:             authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:        authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:        authorizedWithRole(atc.TeamRoleOperator)(inputHandlers[atc.UnpauseResource]),
+				atc.ExposePipeline:         authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:           authorizedWithRole(atc.TeamRoleMember)(inputHandlers[atc.HidePipeline]),
+			}
+
+			for _, route := range atc.Routes {
+				handler := expectedHandlers[route.Name]
+
+				// mutating routes are audited, including rejected attempts,
+				// except for worker heartbeats, config diffs and pipes
+				unaudited := route.Name == atc.RegisterWorker ||
+					route.Name == atc.DiffConfig ||
+					route.Name == atc.CreatePipe ||
+					route.Name == atc.WritePipe
+
+				if route.Method != "GET" && !unaudited {
+					handler = auditHandlerFactory.HandlerFor(route.Name, handler)
+				}
+
+				validator := fakeAuthValidator
+				if route.Name == atc.GetAuthToken {
+					validator = fakeGetTokenValidator
+				}
+
+				expectedHandlers[route.Name] = auth.WrapHandler(handler, validator, fakeUserContextReader)
 			}
 		})
 
@@ -239,6 +251,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				fakeCheckPipelineAccessHandlerFactory,
 				fakeCheckBuildReadAccessHandlerFactory,
 				fakeCheckBuildWriteAccessHandlerFactory,
+				auditHandlerFactory,
 			).Wrap(inputHandlers)
 		})
 